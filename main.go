@@ -1,12 +1,24 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: selectel.Provider,
+	server, err := selectel.NewProviderServer(context.Background(), selectel.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf6server.Serve("registry.terraform.io/selectel/selectel", func() tfprotov6.ProviderServer {
+		return server
 	})
+	if err != nil {
+		log.Fatal(err)
+	}
 }
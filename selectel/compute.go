@@ -0,0 +1,53 @@
+package selectel
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getComputeClient returns the OpenStack Compute client of the resource
+// project and region.
+func getComputeClient(d *schema.ResourceData, meta interface{}) (*gophercloud.ServiceClient, diag.Diagnostics) {
+	computeClient, err := newComputeClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return computeClient, nil
+}
+
+func newComputeClient(meta interface{}, projectID, region string) (*gophercloud.ServiceClient, error) {
+	config := meta.(*Config)
+
+	endpoint, ok := config.endpointOverride(Compute)
+	if !ok {
+		selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+		if err != nil {
+			return nil, fmt.Errorf("can't get project-scope selvpc client for compute: %w", err)
+		}
+
+		catalogEndpoint, err := selvpcClient.Catalog.GetEndpoint(Compute, region)
+		if err != nil {
+			return nil, fmt.Errorf("can't get endpoint to init compute client: %w", err)
+		}
+		endpoint = catalogEndpoint.URL
+	}
+
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token to init compute client: %w", err)
+	}
+
+	provider := &gophercloud.ProviderClient{
+		HTTPClient: *config.newHTTPClient(projectID),
+	}
+	provider.SetToken(token)
+
+	return &gophercloud.ServiceClient{
+		ProviderClient: provider,
+		Endpoint:       gophercloud.NormalizeURL(endpoint),
+	}, nil
+}
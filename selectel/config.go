@@ -2,17 +2,32 @@ package selectel
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient"
+	"github.com/selectel/go-selvpcclient/v3/selvpcclient/clients"
+	clientservices "github.com/selectel/go-selvpcclient/v3/selvpcclient/clients/services"
 )
 
-var (
-	cfgSingletone *Config
-	once          sync.Once
+// tokenRefreshWindow is how long before the Keystone token expiration
+// the cached clients are re-authenticated.
+const tokenRefreshWindow = 10 * time.Minute
+
+// Keystone authentication methods supported by the provider.
+const (
+	authMethodPassword              = "password"
+	authMethodToken                 = "token"
+	authMethodApplicationCredential = "application_credential"
 )
 
 // Config contains all available configuration options.
@@ -20,42 +35,104 @@ type Config struct {
 	Region    string
 	ProjectID string
 
-	Context        context.Context
-	AuthURL        string
-	AuthRegion     string
-	Username       string
-	Password       string
-	UserDomainName string
-	DomainName     string
-	clientsCache   map[string]*selvpcclient.Client
-	lock           sync.Mutex
+	Context                     context.Context
+	AuthURL                     string
+	AuthRegion                  string
+	AuthMethod                  string
+	Username                    string
+	Password                    string
+	UserDomainName              string
+	DomainName                  string
+	Token                       string
+	ApplicationCredentialID     string
+	ApplicationCredentialSecret string
+	MaxRetries                  int
+	RetryWaitMin                time.Duration
+	RetryWaitMax                time.Duration
+	Endpoints                   map[string]string
+	SkipQuotaCheck              bool
+	clientsCache                map[string]*clientsCacheEntry
+	mksQuotaReservations        mksQuotaReservations
+	lock                        sync.Mutex
 }
 
+// getConfig builds a new Config for every configured provider instance, so aliased
+// provider blocks never share credentials or cached clients.
 func getConfig(d *schema.ResourceData) (*Config, diag.Diagnostics) {
-	once.Do(func() {
-		cfgSingletone = &Config{
-			Username:   d.Get("username").(string),
-			Password:   d.Get("password").(string),
-			DomainName: d.Get("domain_name").(string),
-		}
-		if v, ok := d.GetOk("auth_url"); ok {
-			cfgSingletone.AuthURL = v.(string)
-		}
-		if v, ok := d.GetOk("auth_region"); ok {
-			cfgSingletone.AuthRegion = v.(string)
-		}
-		if v, ok := d.GetOk("user_domain_name"); ok {
-			cfgSingletone.UserDomainName = v.(string)
-		}
-		if v, ok := d.GetOk("project_id"); ok {
-			cfgSingletone.ProjectID = v.(string)
-		}
-		if v, ok := d.GetOk("region"); ok {
-			cfgSingletone.Region = v.(string)
+	config := &Config{
+		Username:                    d.Get("username").(string),
+		Password:                    d.Get("password").(string),
+		DomainName:                  d.Get("domain_name").(string),
+		Token:                       d.Get("auth_token").(string),
+		ApplicationCredentialID:     d.Get("application_credential_id").(string),
+		ApplicationCredentialSecret: d.Get("application_credential_secret").(string),
+		MaxRetries:                  d.Get("max_retries").(int),
+		RetryWaitMin:                time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		RetryWaitMax:                time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+		Endpoints:                   expandEndpoints(d.Get("endpoints").([]interface{})),
+		SkipQuotaCheck:              d.Get("skip_quota_check").(bool),
+	}
+	if config.RetryWaitMin > config.RetryWaitMax {
+		return nil, diag.Errorf("\"retry_wait_min\" can't be greater than \"retry_wait_max\"")
+	}
+	if v, ok := d.GetOk("auth_url"); ok {
+		config.AuthURL = v.(string)
+	}
+	if v, ok := d.GetOk("auth_region"); ok {
+		config.AuthRegion = v.(string)
+	}
+	if v, ok := d.GetOk("user_domain_name"); ok {
+		config.UserDomainName = v.(string)
+	}
+	if v, ok := d.GetOk("project_id"); ok {
+		config.ProjectID = v.(string)
+	}
+	if v, ok := d.GetOk("region"); ok {
+		config.Region = v.(string)
+	}
+
+	authMethod, err := config.chooseAuthMethod()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	config.AuthMethod = authMethod
+
+	return config, nil
+}
+
+// chooseAuthMethod picks the Keystone authentication method from the configured
+// credentials. A pre-issued token takes precedence over application credentials,
+// and application credentials take precedence over a service user password.
+func (c *Config) chooseAuthMethod() (string, error) {
+	switch {
+	case c.Token != "":
+		return authMethodToken, nil
+	case c.ApplicationCredentialID != "" || c.ApplicationCredentialSecret != "":
+		if c.ApplicationCredentialID == "" || c.ApplicationCredentialSecret == "" {
+			return "", errors.New("both \"application_credential_id\" and \"application_credential_secret\" " +
+				"must be set to authenticate with application credentials")
 		}
-	})
 
-	return cfgSingletone, nil
+		return authMethodApplicationCredential, nil
+	}
+
+	var missing []string
+	if c.DomainName == "" {
+		missing = append(missing, "domain_name")
+	}
+	if c.Username == "" {
+		missing = append(missing, "username")
+	}
+	if c.Password == "" {
+		missing = append(missing, "password")
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s must be set to authenticate with a service user password, "+
+			"alternatively set \"auth_token\" or \"application_credential_id\" and \"application_credential_secret\"",
+			strings.Join(missing, ", "))
+	}
+
+	return authMethodPassword, nil
 }
 
 func (c *Config) GetSelVPCClient() (*selvpcclient.Client, error) {
@@ -63,35 +140,214 @@ func (c *Config) GetSelVPCClient() (*selvpcclient.Client, error) {
 }
 
 func (c *Config) GetSelVPCClientWithProjectScope(projectID string) (*selvpcclient.Client, error) {
+	entry, err := c.getClientsCacheEntry(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.client, nil
+}
+
+// GetXAuthTokenWithProjectScope returns a Keystone token for the given project scope
+// that is valid for at least tokenRefreshWindow. An empty projectID means the domain scope.
+func (c *Config) GetXAuthTokenWithProjectScope(projectID string) (string, error) {
+	entry, err := c.getClientsCacheEntry(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return entry.provider.Token(), nil
+}
+
+// reauthenticate issues a new Keystone token for the given project scope unless
+// the rejected token has already been replaced by a concurrent request.
+func (c *Config) reauthenticate(projectID, rejectedToken string) (string, error) {
+	entry, err := c.getClientsCacheEntry(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] re-authenticating selvpc client for project '%s'", projectID)
+	if err := entry.provider.Reauthenticate(rejectedToken); err != nil {
+		return "", fmt.Errorf("can't re-authenticate selvpc client: %w", err)
+	}
+
+	return entry.provider.Token(), nil
+}
+
+func (c *Config) getClientsCacheEntry(projectID string) (*clientsCacheEntry, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	clientsCacheKey := fmt.Sprintf("client_%s", projectID)
 
-	if client, ok := c.clientsCache[clientsCacheKey]; ok {
-		return client, nil
-	}
+	if entry, ok := c.clientsCache[clientsCacheKey]; ok {
+		if !entry.tokenExpiresWithin(tokenRefreshWindow) {
+			return entry, nil
+		}
+
+		log.Printf("[DEBUG] keystone token for project '%s' expires at %s, re-authenticating",
+			projectID, entry.tokenExpiresAt())
+		if err := entry.provider.Reauthenticate(entry.provider.Token()); err != nil {
+			return nil, fmt.Errorf("can't re-authenticate selvpc client: %w", err)
+		}
 
-	opts := &selvpcclient.ClientOptions{
-		DomainName:     c.DomainName,
-		Username:       c.Username,
-		Password:       c.Password,
-		ProjectID:      projectID,
-		AuthURL:        c.AuthURL,
-		AuthRegion:     c.AuthRegion,
-		UserDomainName: c.UserDomainName,
+		return entry, nil
 	}
 
-	client, err := selvpcclient.NewClient(opts)
+	entry, err := c.newClientsCacheEntry(projectID)
 	if err != nil {
 		return nil, err
 	}
 
 	if c.clientsCache == nil {
-		c.clientsCache = map[string]*selvpcclient.Client{}
+		c.clientsCache = map[string]*clientsCacheEntry{}
 	}
 
-	c.clientsCache[clientsCacheKey] = client
+	c.clientsCache[clientsCacheKey] = entry
+
+	return entry, nil
+}
+
+// newClientsCacheEntry authenticates in Keystone the same way selvpcclient.NewClient does,
+// but keeps the underlying provider client so the token can be inspected and refreshed.
+func (c *Config) newClientsCacheEntry(projectID string) (*clientsCacheEntry, error) {
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = selvpcclient.DefaultAuthURL
+	}
+	authRegion := c.AuthRegion
+	if authRegion == "" {
+		authRegion = selvpcclient.DefaultAuthRegion
+	}
+	userDomainName := c.UserDomainName
+	if userDomainName == "" {
+		userDomainName = c.DomainName
+	}
+
+	authOpts := gophercloud.AuthOptions{
+		AllowReauth:      true,
+		IdentityEndpoint: authURL,
+		Scope: &gophercloud.AuthScope{
+			ProjectID: projectID,
+		},
+	}
+
+	// If project scope is not set, we use domain scope.
+	if projectID == "" {
+		authOpts.Scope.DomainName = c.DomainName
+	}
+
+	switch c.AuthMethod {
+	case authMethodToken:
+		authOpts.TokenID = c.Token
+		// A token without a scope is passed through as is and can't be re-issued.
+		if *authOpts.Scope == (gophercloud.AuthScope{}) {
+			authOpts.AllowReauth = false
+		}
+	case authMethodApplicationCredential:
+		// Application credentials are always bound to their own project scope,
+		// it's checked against the requested project after authentication.
+		authOpts.ApplicationCredentialID = c.ApplicationCredentialID
+		authOpts.ApplicationCredentialSecret = c.ApplicationCredentialSecret
+		authOpts.Scope = nil
+	default:
+		authOpts.Username = c.Username
+		authOpts.Password = c.Password
+		authOpts.DomainName = userDomainName
+	}
+
+	provider, err := openstack.AuthenticatedClient(authOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth provider, err: %w", err)
+	}
+	provider.Context = c.Context
+
+	if c.AuthMethod == authMethodApplicationCredential && projectID != "" {
+		if err := checkApplicationCredentialProject(provider, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	serviceClient, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{
+		Availability: gophercloud.AvailabilityPublic,
+		Region:       authRegion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service client, err: %w", err)
+	}
+	serviceClient.HTTPClient = *clientservices.NewHTTPClient()
+	serviceClient.UserAgent.Prepend(fmt.Sprintf("%s/%s", selvpcclient.AppName, selvpcclient.AppVersion))
+
+	catalogService, err := clientservices.NewCatalogService(serviceClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize endpoints catalog service, err: %w", err)
+	}
+
+	requestService := clientservices.NewRequestService(serviceClient)
+
+	return &clientsCacheEntry{
+		client: &selvpcclient.Client{
+			Resell:       clients.NewResellClient(requestService, catalogService, authRegion),
+			QuotaManager: clients.NewQuotaManagerClient(requestService, catalogService),
+			Catalog:      catalogService,
+		},
+		provider: provider,
+	}, nil
+}
+
+// checkApplicationCredentialProject returns an error if the token of the
+// application credential is scoped to a project other than projectID.
+func checkApplicationCredentialProject(provider *gophercloud.ProviderClient, projectID string) error {
+	result, ok := provider.GetAuthResult().(tokens.CreateResult)
+	if !ok {
+		return nil
+	}
+	project, err := result.ExtractProject()
+	if err != nil || project == nil {
+		log.Printf("[WARN] can't check the project of the application credential token against project '%s'", projectID)
+
+		return nil
+	}
+	if project.ID != projectID {
+		return fmt.Errorf("application credential is bound to project '%s' and can't manage resources of project '%s', "+
+			"use an application credential of that project or another authentication method", project.ID, projectID)
+	}
+
+	return nil
+}
+
+// clientsCacheEntry holds a selvpc client together with the provider client
+// that owns its Keystone token.
+type clientsCacheEntry struct {
+	client   *selvpcclient.Client
+	provider *gophercloud.ProviderClient
+}
+
+// tokenExpiresAt returns the expiration time of the current token or zero time
+// if it is unknown.
+func (e *clientsCacheEntry) tokenExpiresAt() time.Time {
+	// Both tokens.CreateResult and tokens.GetResult carry the token details.
+	result, ok := e.provider.GetAuthResult().(interface {
+		ExtractToken() (*tokens.Token, error)
+	})
+	if !ok {
+		return time.Time{}
+	}
+
+	token, err := result.ExtractToken()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return token.ExpiresAt
+}
+
+func (e *clientsCacheEntry) tokenExpiresWithin(d time.Duration) bool {
+	expiresAt := e.tokenExpiresAt()
+	if expiresAt.IsZero() {
+		return false
+	}
 
-	return client, nil
+	return time.Now().Add(d).After(expiresAt)
 }
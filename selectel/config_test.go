@@ -0,0 +1,409 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeystone is a minimal Keystone v3 stub that issues tokens and serves
+// an endpoints catalog.
+type testKeystone struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	issued   int
+	authBody []map[string]interface{}
+	tokenTTL time.Duration
+	project  string
+}
+
+func newTestKeystone(t *testing.T) *testKeystone {
+	t.Helper()
+
+	ks := &testKeystone{
+		tokenTTL: 24 * time.Hour,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/v3/auth/tokens", ks.handleTokens)
+	ks.Server = httptest.NewServer(mux)
+	t.Cleanup(ks.Close)
+
+	return ks
+}
+
+func (ks *testKeystone) AuthURL() string {
+	return ks.URL + "/identity/v3/"
+}
+
+// Issued returns the number of tokens issued so far.
+func (ks *testKeystone) Issued() int {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	return ks.issued
+}
+
+// SetTokenTTL sets the lifetime of newly issued tokens.
+func (ks *testKeystone) SetTokenTTL(ttl time.Duration) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.tokenTTL = ttl
+}
+
+// SetProject sets the project newly issued tokens are scoped to.
+func (ks *testKeystone) SetProject(projectID string) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.project = projectID
+}
+
+// Requests returns bodies of every authentication request.
+func (ks *testKeystone) Requests() []map[string]interface{} {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	return append([]map[string]interface{}{}, ks.authBody...)
+}
+
+// Users returns user names from every password authentication request.
+func (ks *testKeystone) Users() []string {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	users := make([]string, 0, len(ks.authBody))
+	for _, body := range ks.authBody {
+		identity := body["auth"].(map[string]interface{})["identity"].(map[string]interface{})
+		password, ok := identity["password"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		users = append(users, password["user"].(map[string]interface{})["name"].(string))
+	}
+
+	return users
+}
+
+func (ks *testKeystone) handleTokens(w http.ResponseWriter, r *http.Request) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	var token string
+	switch r.Method {
+	case http.MethodPost:
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ks.authBody = append(ks.authBody, body)
+		ks.issued++
+		token = fmt.Sprintf("token-%d", ks.issued)
+		w.Header().Set("X-Subject-Token", token)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		token = r.Header.Get("X-Subject-Token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(ks.tokenBody())
+}
+
+func (ks *testKeystone) tokenBody() map[string]interface{} {
+	body := map[string]interface{}{
+		"token": map[string]interface{}{
+			"methods":    []string{"password"},
+			"issued_at":  time.Now().UTC().Format(time.RFC3339),
+			"expires_at": time.Now().Add(ks.tokenTTL).UTC().Format(time.RFC3339),
+			"catalog": []map[string]interface{}{
+				{
+					"id":   "identity",
+					"type": "identity",
+					"name": "keystone",
+					"endpoints": []map[string]interface{}{
+						{
+							"id":        "identity-public",
+							"interface": "public",
+							"region":    DefaultAuthRegion,
+							"region_id": DefaultAuthRegion,
+							"url":       ks.AuthURL(),
+						},
+					},
+				},
+			},
+		},
+	}
+	if ks.project != "" {
+		body["token"].(map[string]interface{})["project"] = map[string]interface{}{
+			"id":     ks.project,
+			"name":   ks.project,
+			"domain": map[string]interface{}{"id": "default", "name": "111111"},
+		}
+	}
+
+	return body
+}
+
+func configureTestProvider(t *testing.T, raw map[string]interface{}) *Config {
+	t.Helper()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	return provider.Meta().(*Config)
+}
+
+func TestGetConfigPerProviderInstance(t *testing.T) {
+	ks := newTestKeystone(t)
+
+	first := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"username":    "first-user",
+		"password":    "first-password",
+	})
+	second := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"auth_region": DefaultAuthRegion,
+		"domain_name": "222222",
+		"username":    "second-user",
+		"password":    "second-password",
+	})
+
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "first-user", first.Username)
+	assert.Equal(t, "second-user", second.Username)
+	assert.Equal(t, "111111", first.DomainName)
+	assert.Equal(t, "222222", second.DomainName)
+
+	firstClient, err := first.GetSelVPCClient()
+	require.NoError(t, err)
+	secondClient, err := second.GetSelVPCClient()
+	require.NoError(t, err)
+
+	assert.NotSame(t, firstClient, secondClient)
+	firstToken, err := first.GetXAuthTokenWithProjectScope("")
+	require.NoError(t, err)
+	secondToken, err := second.GetXAuthTokenWithProjectScope("")
+	require.NoError(t, err)
+	assert.NotEqual(t, firstToken, secondToken)
+	assert.Equal(t, []string{"first-user", "second-user"}, ks.Users())
+
+	// Cached clients are reused within a single provider instance only.
+	cachedClient, err := first.GetSelVPCClient()
+	require.NoError(t, err)
+	assert.Same(t, firstClient, cachedClient)
+	assert.Len(t, first.clientsCache, 1)
+	assert.Len(t, second.clientsCache, 1)
+	assert.Equal(t, []string{"first-user", "second-user"}, ks.Users())
+}
+
+func TestGetXAuthTokenReauthenticatesBeforeExpiry(t *testing.T) {
+	ks := newTestKeystone(t)
+	ks.SetTokenTTL(tokenRefreshWindow / 2)
+
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"username":    "user",
+		"password":    "password",
+	})
+
+	client, err := config.GetSelVPCClient()
+	require.NoError(t, err)
+
+	// The token expires within the refresh window, so every call re-authenticates.
+	token, err := config.GetXAuthTokenWithProjectScope("")
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	ks.SetTokenTTL(24 * time.Hour)
+	token, err = config.GetXAuthTokenWithProjectScope("")
+	require.NoError(t, err)
+	assert.Equal(t, "token-3", token)
+
+	// The fresh token is far from the expiration and is reused.
+	token, err = config.GetXAuthTokenWithProjectScope("")
+	require.NoError(t, err)
+	assert.Equal(t, "token-3", token)
+	assert.Equal(t, 3, ks.Issued())
+
+	// The cached selvpc client keeps working with the refreshed token.
+	cachedClient, err := config.GetSelVPCClient()
+	require.NoError(t, err)
+	assert.Same(t, client, cachedClient)
+}
+
+func TestGetConfigAuthMethod(t *testing.T) {
+	tableTests := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected string
+		errMsg   string
+	}{
+		{
+			name: "password",
+			raw: map[string]interface{}{
+				"domain_name": "111111",
+				"username":    "user",
+				"password":    "password",
+			},
+			expected: authMethodPassword,
+		},
+		{
+			name: "token",
+			raw: map[string]interface{}{
+				"auth_token": "pre-issued",
+			},
+			expected: authMethodToken,
+		},
+		{
+			name: "application credential",
+			raw: map[string]interface{}{
+				"application_credential_id":     "app-cred-id",
+				"application_credential_secret": "app-cred-secret",
+			},
+			expected: authMethodApplicationCredential,
+		},
+		{
+			name: "token takes precedence",
+			raw: map[string]interface{}{
+				"auth_token":                    "pre-issued",
+				"application_credential_id":     "app-cred-id",
+				"application_credential_secret": "app-cred-secret",
+				"domain_name":                   "111111",
+				"username":                      "user",
+				"password":                      "password",
+			},
+			expected: authMethodToken,
+		},
+		{
+			name: "application credential without secret",
+			raw: map[string]interface{}{
+				"application_credential_id": "app-cred-id",
+			},
+			errMsg: "both \"application_credential_id\" and \"application_credential_secret\" must be set",
+		},
+		{
+			name: "retry wait min greater than max",
+			raw: map[string]interface{}{
+				"auth_token":     "pre-issued",
+				"retry_wait_min": 10,
+				"retry_wait_max": 5,
+			},
+			errMsg: "\"retry_wait_min\" can't be greater than \"retry_wait_max\"",
+		},
+		{
+			name: "password without username",
+			raw: map[string]interface{}{
+				"domain_name": "111111",
+				"password":    "password",
+			},
+			errMsg: "username must be set",
+		},
+	}
+
+	for _, test := range tableTests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := Provider()
+			diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(test.raw))
+			if test.errMsg != "" {
+				require.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, test.errMsg)
+
+				return
+			}
+
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, test.expected, provider.Meta().(*Config).AuthMethod)
+		})
+	}
+}
+
+func TestGetSelVPCClientWithToken(t *testing.T) {
+	ks := newTestKeystone(t)
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"auth_token":  "pre-issued",
+	})
+
+	_, err := config.GetSelVPCClient()
+	require.NoError(t, err)
+	_, err = config.GetSelVPCClientWithProjectScope("project-id")
+	require.NoError(t, err)
+
+	requests := ks.Requests()
+	require.Len(t, requests, 2)
+
+	domainAuth := requests[0]["auth"].(map[string]interface{})
+	identity := domainAuth["identity"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"token"}, identity["methods"])
+	assert.Equal(t, "pre-issued", identity["token"].(map[string]interface{})["id"])
+	assert.Equal(t, map[string]interface{}{"domain": map[string]interface{}{"name": "111111"}}, domainAuth["scope"])
+
+	projectAuth := requests[1]["auth"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"project": map[string]interface{}{"id": "project-id"}}, projectAuth["scope"])
+}
+
+func TestGetSelVPCClientWithApplicationCredential(t *testing.T) {
+	ks := newTestKeystone(t)
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":                      ks.AuthURL(),
+		"application_credential_id":     "app-cred-id",
+		"application_credential_secret": "app-cred-secret",
+	})
+
+	_, err := config.GetSelVPCClientWithProjectScope("project-id")
+	require.NoError(t, err)
+	token, err := config.GetXAuthTokenWithProjectScope("project-id")
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	requests := ks.Requests()
+	require.Len(t, requests, 1)
+
+	auth := requests[0]["auth"].(map[string]interface{})
+	identity := auth["identity"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"application_credential"}, identity["methods"])
+	assert.Equal(t, map[string]interface{}{
+		"id":     "app-cred-id",
+		"secret": "app-cred-secret",
+	}, identity["application_credential"])
+	assert.NotContains(t, auth, "scope")
+}
+
+func TestGetSelVPCClientWithApplicationCredentialOfAnotherProject(t *testing.T) {
+	ks := newTestKeystone(t)
+	ks.SetProject("credential-project-id")
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":                      ks.AuthURL(),
+		"application_credential_id":     "app-cred-id",
+		"application_credential_secret": "app-cred-secret",
+	})
+
+	_, err := config.GetSelVPCClientWithProjectScope("credential-project-id")
+	require.NoError(t, err)
+
+	_, err = config.GetSelVPCClientWithProjectScope("project-id")
+	require.EqualError(t, err, "application credential is bound to project 'credential-project-id' and can't manage "+
+		"resources of project 'project-id', use an application credential of that project or another authentication method")
+}
@@ -35,8 +35,8 @@ func waitForCRaaSRegistryV1StableState(
 		Target:       target,
 		Timeout:      timeout,
 		Refresh:      craasRegistryV1StateRefreshFunc(ctx, client, registryID),
-		Delay:        1 * time.Second,
-		PollInterval: 1 * time.Second,
+		Delay:        waitTime(1 * time.Second),
+		PollInterval: waitTime(1 * time.Second),
 	}
 
 	_, err := stateConf.WaitForStateContext(ctx)
@@ -64,17 +64,26 @@ func craasRegistryV1StateRefreshFunc(
 
 func getCRaaSClient(d *schema.ResourceData, meta interface{}) (*v1.ServiceClient, diag.Diagnostics) {
 	config := meta.(*Config)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(d.Get("project_id").(string))
+	projectID := d.Get("project_id").(string)
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
 		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for craas: %w", err))
 	}
 
-	endpoint, diagErr := getEndpointForCRaaS(selvpcClient)
-	if diagErr != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init craas client: %w", err))
+	endpoint, ok := config.endpointOverride(CRaaS)
+	if !ok {
+		endpoint, err = getEndpointForCRaaS(selvpcClient)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init craas client: %w", err))
+		}
+	}
+
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, diag.FromErr(fmt.Errorf("can't get token to init craas client: %w", err))
 	}
 
-	craasClient := v1.NewCRaaSClientV1(selvpcClient.GetXAuthToken(), endpoint)
+	craasClient := v1.NewCRaaSClientV1WithCustomHTTP(config.newHTTPClient(projectID), token, endpoint)
 
 	return craasClient, nil
 }
@@ -24,12 +24,20 @@ func newCRaaSTestClient(rs *terraform.ResourceState, testAccProvider *schema.Pro
 		return nil, fmt.Errorf("can't get selvpc client for craas acc tests: %w", err)
 	}
 
-	craasEndpoint, err := getEndpointForCRaaS(selvpcClient)
+	craasEndpoint, ok := config.endpointOverride(CRaaS)
+	if !ok {
+		craasEndpoint, err = getEndpointForCRaaS(selvpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("can't get endpoint for craas acc tests: %w", err)
+		}
+	}
+
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, fmt.Errorf("can't get endpoint for craas acc tests: %w", err)
+		return nil, fmt.Errorf("can't get token for craas acc tests: %w", err)
 	}
 
-	craasClient := v1.NewCRaaSClientV1(selvpcClient.GetXAuthToken(), craasEndpoint)
+	craasClient := v1.NewCRaaSClientV1(token, craasEndpoint)
 
 	return craasClient, nil
 }
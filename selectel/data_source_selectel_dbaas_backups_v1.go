@@ -0,0 +1,96 @@
+package selectel
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDBaaSBackupsV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDBaaSBackupsV1Read,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datastore_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"backups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"datastore_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDBaaSBackupsV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dbaasClient, diagErr := getDBaaSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	datastoreID := d.Get("datastore_id").(string)
+
+	log.Print(msgGet(objectBackups, datastoreID))
+	backups, err := getDBaaSBackups(ctx, dbaasClient, datastoreID)
+	if err != nil {
+		return diag.FromErr(errGettingObjects(objectBackups, err))
+	}
+
+	backupIDs := []string{}
+	for _, backup := range backups {
+		backupIDs = append(backupIDs, backup.ID)
+	}
+
+	if err := d.Set("backups", flattenDBaaSBackups(backups)); err != nil {
+		return diag.FromErr(err)
+	}
+	checksum, err := stringListChecksum(append(backupIDs, datastoreID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(checksum)
+
+	return nil
+}
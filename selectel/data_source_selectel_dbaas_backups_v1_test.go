@@ -0,0 +1,71 @@
+package selectel
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/selectel/dbaas-go"
+	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/projects"
+)
+
+func TestAccDBaaSDataSourceBackupsV1Basic(t *testing.T) {
+	var project projects.Project
+
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDBaaSDataSourceBackupsV1Basic(projectName, datastoreName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2ProjectExists("selectel_vpc_project_v2.project_tf_acc_test_1", &project),
+					resource.TestCheckTypeSetElemAttrPair("data.selectel_dbaas_backups_v1.backups_tf_acc_test_1", "backups.*.id", "selectel_dbaas_backup_v1.backup_tf_acc_test_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDBaaSDataSourceBackupsV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	dataSourceName := "data.selectel_dbaas_backups_v1.backups_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDataSourceBackupsV1Basic(projectName, datastoreName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "backups.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "backups.0.id", "selectel_dbaas_backup_v1.backup_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "backups.0.datastore_id", "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "backups.0.status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttr(dataSourceName, "backups.0.type", "manual"),
+					resource.TestCheckResourceAttr(dataSourceName, "backups.0.size", strconv.Itoa(16<<20)),
+					resource.TestCheckResourceAttrSet(dataSourceName, "backups.0.created_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDBaaSDataSourceBackupsV1Basic(projectName, datastoreName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "selectel_dbaas_backups_v1" "backups_tf_acc_test_1" {
+  project_id   = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region       = "ru-3"
+  datastore_id = "${selectel_dbaas_backup_v1.backup_tf_acc_test_1.datastore_id}"
+}`, testAccDBaaSBackupV1Basic(projectName, datastoreName, "v1"))
+}
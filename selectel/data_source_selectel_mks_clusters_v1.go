@@ -0,0 +1,294 @@
+package selectel
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+type mksClustersSearchFilter struct {
+	nameRegex   *regexp.Regexp
+	status      string
+	kubeVersion string
+	zonal       *bool
+}
+
+func dataSourceMKSClustersV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMKSClustersV1Read,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"filter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"kube_version": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"zonal": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kube_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kube_api_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"maintenance_window_start": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"maintenance_window_end": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enable_autorepair": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"enable_patch_version_auto_upgrade": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"enable_pod_security_policy": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"zonal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"private_kube_api": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"feature_gates": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"admission_controllers": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMKSClustersV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	mksClient, diagErr := getMKSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	mksClusters, _, err := cluster.List(ctx, mksClient)
+	if err != nil {
+		return diag.FromErr(errGettingObjects(objectClusters, err))
+	}
+
+	filter, err := expandMKSClustersSearchFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mksClusters = filterMKSClusters(mksClusters, filter)
+
+	clusterIDs := make([]string, len(mksClusters))
+	for i, mksCluster := range mksClusters {
+		clusterIDs[i] = mksCluster.ID
+	}
+
+	if err := d.Set("clusters", flattenMKSClustersV1(mksClusters)); err != nil {
+		return diag.FromErr(err)
+	}
+	checksum, err := stringListChecksum(clusterIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(checksum)
+
+	return nil
+}
+
+func expandMKSClustersSearchFilter(d *schema.ResourceData) (mksClustersSearchFilter, error) {
+	filter := mksClustersSearchFilter{}
+	filterSet := d.Get("filter").(*schema.Set)
+	if filterSet.Len() == 0 {
+		return filter, nil
+	}
+
+	resourceFilterMap := filterSet.List()[0].(map[string]interface{})
+
+	if nameRegex, ok := resourceFilterMap["name_regex"]; ok && nameRegex.(string) != "" {
+		re, err := regexp.Compile(nameRegex.(string))
+		if err != nil {
+			return filter, err
+		}
+		filter.nameRegex = re
+	}
+
+	status, ok := resourceFilterMap["status"]
+	if ok {
+		filter.status = status.(string)
+	}
+
+	kubeVersion, ok := resourceFilterMap["kube_version"]
+	if ok {
+		filter.kubeVersion = kubeVersion.(string)
+	}
+
+	// The set doesn't distinguish an unset zonal from false, so it is checked
+	// in the configuration.
+	if mksClustersFilterZonalIsSet(d.GetRawConfig().GetAttr("filter")) {
+		zonal := resourceFilterMap["zonal"].(bool)
+		filter.zonal = &zonal
+	}
+
+	return filter, nil
+}
+
+func mksClustersFilterZonalIsSet(rawFilter cty.Value) bool {
+	if rawFilter.IsNull() || !rawFilter.IsKnown() || rawFilter.LengthInt() == 0 {
+		return false
+	}
+
+	for it := rawFilter.ElementIterator(); it.Next(); {
+		_, rawFilterElem := it.Element()
+		if !rawFilterElem.GetAttr("zonal").IsNull() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filterMKSClusters(mksClusters []*cluster.View, filter mksClustersSearchFilter) []*cluster.View {
+	filteredClusters := make([]*cluster.View, 0, len(mksClusters))
+	for _, mksCluster := range mksClusters {
+		if filter.nameRegex != nil && !filter.nameRegex.MatchString(mksCluster.Name) {
+			continue
+		}
+		if filter.status != "" && string(mksCluster.Status) != filter.status {
+			continue
+		}
+		if filter.kubeVersion != "" && !mksClusterV1KubeVersionMatches(mksCluster.KubeVersion, filter.kubeVersion) {
+			continue
+		}
+		if filter.zonal != nil && mksCluster.Zonal != *filter.zonal {
+			continue
+		}
+
+		filteredClusters = append(filteredClusters, mksCluster)
+	}
+
+	return filteredClusters
+}
+
+// mksClusterV1KubeVersionMatches checks the version against a full version,
+// for example, 1.28.5, or a minor one, for example, 1.28.
+func mksClusterV1KubeVersionMatches(kubeVersion, filterVersion string) bool {
+	return kubeVersion == filterVersion || strings.HasPrefix(kubeVersion, filterVersion+".")
+}
+
+func flattenMKSClustersV1(mksClusters []*cluster.View) []interface{} {
+	clustersList := make([]interface{}, len(mksClusters))
+	for i, mksCluster := range mksClusters {
+		clusterMap := map[string]interface{}{
+			"id":                                mksCluster.ID,
+			"name":                              mksCluster.Name,
+			"status":                            string(mksCluster.Status),
+			"project_id":                        mksCluster.ProjectID,
+			"region":                            mksCluster.Region,
+			"kube_version":                      mksCluster.KubeVersion,
+			"kube_api_ip":                       mksCluster.KubeAPIIP,
+			"network_id":                        mksCluster.NetworkID,
+			"subnet_id":                         mksCluster.SubnetID,
+			"maintenance_window_start":          mksCluster.MaintenanceWindowStart,
+			"maintenance_window_end":            mksCluster.MaintenanceWindowEnd,
+			"enable_autorepair":                 mksCluster.EnableAutorepair,
+			"enable_patch_version_auto_upgrade": mksCluster.EnablePatchVersionAutoUpgrade,
+			"zonal":                             mksCluster.Zonal,
+			"private_kube_api":                  mksCluster.PrivateKubeAPI,
+		}
+		if mksCluster.KubernetesOptions != nil {
+			clusterMap["enable_pod_security_policy"] = mksCluster.KubernetesOptions.EnablePodSecurityPolicy
+			clusterMap["feature_gates"] = mksCluster.KubernetesOptions.FeatureGates
+			clusterMap["admission_controllers"] = mksCluster.KubernetesOptions.AdmissionControllers
+		}
+
+		clustersList[i] = clusterMap
+	}
+
+	return clustersList
+}
@@ -0,0 +1,210 @@
+package selectel
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeselectel"
+)
+
+func TestAccMKSClustersV1Basic(t *testing.T) {
+	var mksCluster cluster.View
+
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMKSClustersV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists("selectel_mks_cluster_v1.cluster_tf_acc_test_1", &mksCluster),
+					resource.TestCheckResourceAttr("data.selectel_mks_clusters_v1.clusters_tf_acc_test_1", "clusters.#", "1"),
+					resource.TestCheckResourceAttrPair("data.selectel_mks_clusters_v1.clusters_tf_acc_test_1", "clusters.0.id", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttr("data.selectel_mks_clusters_v1.clusters_tf_acc_test_1", "clusters.0.name", clusterName),
+					resource.TestCheckResourceAttr("data.selectel_mks_clusters_v1.clusters_tf_acc_test_1", "clusters.0.zonal", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSClustersV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksCluster cluster.View
+
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	dataSourceName := "data.selectel_mks_clusters_v1.clusters_tf_acc_test_1"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClustersV1Basic(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists("selectel_mks_cluster_v1.cluster_tf_acc_test_1", &mksCluster),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "clusters.0.id", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.name", clusterName),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.status", "ACTIVE"),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.kube_version", fakeselectel.DefaultKubeVersion),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.region", "ru-9"),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.zonal", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "clusters.0.maintenance_window_start", maintenanceWindowStart),
+					resource.TestCheckResourceAttrPair(dataSourceName, "clusters.0.kube_api_ip", "selectel_mks_cluster_v1.cluster_tf_acc_test_1", "kube_api_ip"),
+					resource.TestCheckResourceAttr("data.selectel_mks_clusters_v1.clusters_tf_acc_test_2", "clusters.#", "2"),
+					resource.TestCheckResourceAttr("data.selectel_mks_clusters_v1.clusters_tf_acc_test_3", "clusters.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterMKSClusters(t *testing.T) {
+	mksClusters := []*cluster.View{
+		{ID: "1", Name: "prod-a", Status: cluster.StatusActive, KubeVersion: "1.28.6", Zonal: false},
+		{ID: "2", Name: "prod-b", Status: cluster.StatusPendingUpdate, KubeVersion: "1.29.2", Zonal: true},
+		{ID: "3", Name: "dev", Status: cluster.StatusActive, KubeVersion: "1.28.10", Zonal: true},
+	}
+	zonal := true
+	notZonal := false
+
+	tests := []struct {
+		name        string
+		filter      mksClustersSearchFilter
+		expectedIDs []string
+	}{
+		{
+			name:        "no filter",
+			expectedIDs: []string{"1", "2", "3"},
+		},
+		{
+			name:        "name regex",
+			filter:      mksClustersSearchFilter{nameRegex: regexp.MustCompile("^prod-")},
+			expectedIDs: []string{"1", "2"},
+		},
+		{
+			name:        "status",
+			filter:      mksClustersSearchFilter{status: "ACTIVE"},
+			expectedIDs: []string{"1", "3"},
+		},
+		{
+			name:        "minor kube version",
+			filter:      mksClustersSearchFilter{kubeVersion: "1.28"},
+			expectedIDs: []string{"1", "3"},
+		},
+		{
+			name:        "patch kube version",
+			filter:      mksClustersSearchFilter{kubeVersion: "1.28.6"},
+			expectedIDs: []string{"1"},
+		},
+		{
+			name:        "zonal",
+			filter:      mksClustersSearchFilter{zonal: &zonal},
+			expectedIDs: []string{"2", "3"},
+		},
+		{
+			name:        "not zonal",
+			filter:      mksClustersSearchFilter{zonal: &notZonal},
+			expectedIDs: []string{"1"},
+		},
+		{
+			name:        "all filters",
+			filter:      mksClustersSearchFilter{nameRegex: regexp.MustCompile("prod"), status: "ACTIVE", kubeVersion: "1.28", zonal: &zonal},
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := []string{}
+			for _, mksCluster := range filterMKSClusters(mksClusters, tc.filter) {
+				ids = append(ids, mksCluster.ID)
+			}
+
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func testAccMKSClustersV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%[1]s"
+}
+
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                              = "%[2]s"
+  kube_version                      = "%[3]s"
+  project_id                        = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                            = "ru-9"
+  maintenance_window_start          = "%[4]s"
+  enable_patch_version_auto_upgrade = false
+  zonal                             = true
+}
+
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_2" {
+  name                              = "%[2]s-regional"
+  kube_version                      = "%[3]s"
+  project_id                        = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                            = "ru-9"
+  maintenance_window_start          = "%[4]s"
+  enable_patch_version_auto_upgrade = false
+}
+
+data "selectel_mks_clusters_v1" "clusters_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+  filter {
+    name_regex   = "^%[2]s"
+    kube_version = "%[3]s"
+    zonal        = true
+  }
+
+  depends_on = [
+    selectel_mks_cluster_v1.cluster_tf_acc_test_1,
+    selectel_mks_cluster_v1.cluster_tf_acc_test_2,
+  ]
+}
+
+data "selectel_mks_clusters_v1" "clusters_tf_acc_test_2" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+  filter {
+    status = "ACTIVE"
+  }
+
+  depends_on = [
+    selectel_mks_cluster_v1.cluster_tf_acc_test_1,
+    selectel_mks_cluster_v1.cluster_tf_acc_test_2,
+  ]
+}
+
+data "selectel_mks_clusters_v1" "clusters_tf_acc_test_3" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+  filter {
+    name_regex = "^%[2]s-regional$"
+    zonal      = true
+  }
+
+  depends_on = [
+    selectel_mks_cluster_v1.cluster_tf_acc_test_1,
+    selectel_mks_cluster_v1.cluster_tf_acc_test_2,
+  ]
+}`, projectName, clusterName, kubeVersion, maintenanceWindowStart)
+}
@@ -2,12 +2,79 @@ package selectel
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"log"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"gopkg.in/yaml.v3"
 )
 
+const (
+	mksKubeconfigV1ExecAPIVersionV1      = "client.authentication.k8s.io/v1"
+	mksKubeconfigV1ExecAPIVersionV1Beta1 = "client.authentication.k8s.io/v1beta1"
+)
+
+type mksKubeconfigV1 struct {
+	APIVersion     string                   `yaml:"apiVersion"`
+	Kind           string                   `yaml:"kind"`
+	Clusters       []mksKubeconfigV1Cluster `yaml:"clusters"`
+	Contexts       []mksKubeconfigV1Context `yaml:"contexts"`
+	CurrentContext string                   `yaml:"current-context"`
+	Users          []mksKubeconfigV1User    `yaml:"users"`
+}
+
+type mksKubeconfigV1Cluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		Server                   string `yaml:"server"`
+	} `yaml:"cluster"`
+}
+
+type mksKubeconfigV1Context struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster   string `yaml:"cluster"`
+		User      string `yaml:"user"`
+		Namespace string `yaml:"namespace,omitempty"`
+	} `yaml:"context"`
+}
+
+type mksKubeconfigV1User struct {
+	Name string                  `yaml:"name"`
+	User mksKubeconfigV1AuthInfo `yaml:"user"`
+}
+
+// mksKubeconfigV1AuthInfo contains credentials of a kubeconfig user: client
+// certificate with key, token or exec plugin.
+type mksKubeconfigV1AuthInfo struct {
+	ClientCertificateData string               `yaml:"client-certificate-data,omitempty"`
+	ClientKeyData         string               `yaml:"client-key-data,omitempty"`
+	Token                 string               `yaml:"token,omitempty"`
+	Exec                  *mksKubeconfigV1Exec `yaml:"exec,omitempty"`
+}
+
+type mksKubeconfigV1Exec struct {
+	APIVersion      string                   `yaml:"apiVersion"`
+	Command         string                   `yaml:"command"`
+	Args            []string                 `yaml:"args,omitempty"`
+	Env             []mksKubeconfigV1ExecEnv `yaml:"env,omitempty"`
+	InteractiveMode string                   `yaml:"interactiveMode,omitempty"`
+}
+
+type mksKubeconfigV1ExecEnv struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
 func dataSourceMKSKubeconfigV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceMKSKubeconfigV1Read,
@@ -51,6 +118,84 @@ func dataSourceMKSKubeconfigV1() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"client_cert_expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"context_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"exec": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  mksKubeconfigV1ExecAPIVersionV1Beta1,
+							ValidateFunc: validation.StringInSlice([]string{
+								mksKubeconfigV1ExecAPIVersionV1,
+								mksKubeconfigV1ExecAPIVersionV1Beta1,
+							}, false),
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"interactive_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "IfAvailable",
+							ValidateFunc: validation.StringInSlice([]string{
+								"Never",
+								"IfAvailable",
+								"Always",
+							}, false),
+						},
+					},
+				},
+			},
+			"kubeconfig": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"token_kubeconfig": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"exec_kubeconfig": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
@@ -80,5 +225,136 @@ func dataSourceMKSKubeconfigV1Read(ctx context.Context, d *schema.ResourceData,
 	d.Set("client_cert", parsedKubeconfig.ClientCert)
 	d.Set("client_key", parsedKubeconfig.ClientKey)
 
+	expiresAt, err := parseMKSKubeconfigV1CertExpiry(parsedKubeconfig.ClientCert)
+	if err != nil {
+		log.Printf("[DEBUG] unable to parse client certificate of the cluster %s: %s", clusterID, err)
+	} else {
+		d.Set("client_cert_expires_at", expiresAt.Format(time.RFC3339))
+	}
+
+	contextName := d.Get("context_name").(string)
+	if contextName == "" {
+		contextName = "admin@" + mksCluster.Name
+	}
+	kubeconfig := newMKSKubeconfigV1(mksCluster.Name, contextName, d.Get("namespace").(string), parsedKubeconfig)
+
+	certKubeconfig, err := kubeconfig.withAuthInfo(mksKubeconfigV1AuthInfo{
+		ClientCertificateData: parsedKubeconfig.ClientCert,
+		ClientKeyData:         parsedKubeconfig.ClientKey,
+	})
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectKubeConfig, clusterID, err))
+	}
+	d.Set("kubeconfig", certKubeconfig)
+
+	tokenKubeconfig := ""
+	if token := d.Get("token").(string); token != "" {
+		tokenKubeconfig, err = kubeconfig.withAuthInfo(mksKubeconfigV1AuthInfo{
+			Token: token,
+		})
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectKubeConfig, clusterID, err))
+		}
+	}
+	d.Set("token_kubeconfig", tokenKubeconfig)
+
+	execKubeconfig := ""
+	if exec := expandMKSKubeconfigV1Exec(d.Get("exec").([]interface{})); exec != nil {
+		execKubeconfig, err = kubeconfig.withAuthInfo(mksKubeconfigV1AuthInfo{
+			Exec: exec,
+		})
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectKubeConfig, clusterID, err))
+		}
+	}
+	d.Set("exec_kubeconfig", execKubeconfig)
+
 	return nil
 }
+
+// newMKSKubeconfigV1 returns a kubeconfig with a single context for the
+// cluster and a user without credentials.
+func newMKSKubeconfigV1(clusterName, contextName, namespace string, parsedKubeconfig *cluster.KubeconfigFields) mksKubeconfigV1 {
+	kubeconfigCluster := mksKubeconfigV1Cluster{Name: clusterName}
+	kubeconfigCluster.Cluster.CertificateAuthorityData = parsedKubeconfig.ClusterCA
+	kubeconfigCluster.Cluster.Server = parsedKubeconfig.Server
+
+	kubeconfigContext := mksKubeconfigV1Context{Name: contextName}
+	kubeconfigContext.Context.Cluster = clusterName
+	kubeconfigContext.Context.User = contextName
+	kubeconfigContext.Context.Namespace = namespace
+
+	return mksKubeconfigV1{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		Clusters:       []mksKubeconfigV1Cluster{kubeconfigCluster},
+		Contexts:       []mksKubeconfigV1Context{kubeconfigContext},
+		CurrentContext: contextName,
+		Users:          []mksKubeconfigV1User{{Name: contextName}},
+	}
+}
+
+// withAuthInfo returns the kubeconfig with the user credentials in YAML.
+func (k mksKubeconfigV1) withAuthInfo(authInfo mksKubeconfigV1AuthInfo) (string, error) {
+	k.Users = []mksKubeconfigV1User{{Name: k.CurrentContext, User: authInfo}}
+
+	kubeconfig, err := yaml.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+
+	return string(kubeconfig), nil
+}
+
+func expandMKSKubeconfigV1Exec(v []interface{}) *mksKubeconfigV1Exec {
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+	execMap := v[0].(map[string]interface{})
+
+	exec := &mksKubeconfigV1Exec{
+		APIVersion: execMap["api_version"].(string),
+		Command:    execMap["command"].(string),
+	}
+	for _, arg := range execMap["args"].([]interface{}) {
+		exec.Args = append(exec.Args, arg.(string))
+	}
+
+	env := execMap["env"].(map[string]interface{})
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		exec.Env = append(exec.Env, mksKubeconfigV1ExecEnv{Name: name, Value: env[name].(string)})
+	}
+
+	// The interactive mode is only known since the v1 API version.
+	if exec.APIVersion == mksKubeconfigV1ExecAPIVersionV1 {
+		exec.InteractiveMode = execMap["interactive_mode"].(string)
+	}
+
+	return exec
+}
+
+// parseMKSKubeconfigV1CertExpiry returns the expiration time of the base64
+// encoded PEM certificate.
+func parseMKSKubeconfigV1CertExpiry(certData string) (time.Time, error) {
+	certPEM, err := base64.StdEncoding.DecodeString(certData)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, errors.New("certificate is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter.UTC(), nil
+}
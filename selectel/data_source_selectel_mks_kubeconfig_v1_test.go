@@ -3,12 +3,17 @@ package selectel
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeselectel"
+	"gopkg.in/yaml.v3"
 )
 
 func TestAccMKSKubeconfigV1DataSourceBasic(t *testing.T) {
@@ -18,9 +23,9 @@ func TestAccMKSKubeconfigV1DataSourceBasic(t *testing.T) {
 	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSKubeconfigV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -32,6 +37,87 @@ func TestAccMKSKubeconfigV1DataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestUnitMKSKubeconfigV1DataSourceBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	dataSourceName := "data.selectel_mks_kubeconfig_v1.kubeconfig_tf_acc_test_1"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSKubeconfigV1Variants(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSKubeconfigV1(dataSourceName),
+					resource.TestCheckResourceAttrWith(dataSourceName, "client_cert_expires_at", testAccCheckMKSKubeconfigV1CertExpiry),
+					resource.TestMatchResourceAttr(dataSourceName, "kubeconfig", regexp.MustCompile("current-context: ci\n")),
+					resource.TestMatchResourceAttr(dataSourceName, "kubeconfig", regexp.MustCompile("namespace: apps\n")),
+					resource.TestMatchResourceAttr(dataSourceName, "kubeconfig", regexp.MustCompile("client-certificate-data: ")),
+					resource.TestMatchResourceAttr(dataSourceName, "token_kubeconfig", regexp.MustCompile("token: secret-token\n")),
+					resource.TestMatchResourceAttr(dataSourceName, "exec_kubeconfig", regexp.MustCompile("command: kubectl-oidc_login\n")),
+					resource.TestMatchResourceAttr(dataSourceName, "exec_kubeconfig", regexp.MustCompile("interactiveMode: Never\n")),
+					resource.TestCheckResourceAttr("data.selectel_mks_kubeconfig_v1.kubeconfig_tf_acc_test_2", "token_kubeconfig", ""),
+					resource.TestCheckResourceAttr("data.selectel_mks_kubeconfig_v1.kubeconfig_tf_acc_test_2", "exec_kubeconfig", ""),
+					resource.TestMatchResourceAttr("data.selectel_mks_kubeconfig_v1.kubeconfig_tf_acc_test_2", "kubeconfig", regexp.MustCompile("current-context: admin@"+clusterName+"\n")),
+				),
+			},
+		},
+	})
+}
+
+func TestMKSKubeconfigV1WithAuthInfo(t *testing.T) {
+	kubeconfig := newMKSKubeconfigV1("cluster-1", "ci", "apps", &cluster.KubeconfigFields{
+		ClusterCA: "Y2E=",
+		Server:    "https://127.0.0.1:6443",
+	})
+	exec := expandMKSKubeconfigV1Exec([]interface{}{
+		map[string]interface{}{
+			"api_version":      mksKubeconfigV1ExecAPIVersionV1Beta1,
+			"command":          "kubectl",
+			"args":             []interface{}{"oidc-login", "get-token"},
+			"env":              map[string]interface{}{"B": "2", "A": "1"},
+			"interactive_mode": "Never",
+		},
+	})
+
+	raw, err := kubeconfig.withAuthInfo(mksKubeconfigV1AuthInfo{Exec: exec})
+	assert.NoError(t, err)
+
+	var actual mksKubeconfigV1
+	assert.NoError(t, yaml.Unmarshal([]byte(raw), &actual))
+	assert.Equal(t, "ci", actual.CurrentContext)
+	assert.Equal(t, "cluster-1", actual.Contexts[0].Context.Cluster)
+	assert.Equal(t, "ci", actual.Contexts[0].Context.User)
+	assert.Equal(t, "apps", actual.Contexts[0].Context.Namespace)
+	assert.Equal(t, "https://127.0.0.1:6443", actual.Clusters[0].Cluster.Server)
+	assert.Equal(t, "ci", actual.Users[0].Name)
+	assert.Empty(t, actual.Users[0].User.ClientCertificateData)
+	assert.Equal(t, &mksKubeconfigV1Exec{
+		APIVersion: mksKubeconfigV1ExecAPIVersionV1Beta1,
+		Command:    "kubectl",
+		Args:       []string{"oidc-login", "get-token"},
+		Env:        []mksKubeconfigV1ExecEnv{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+	}, actual.Users[0].User.Exec)
+}
+
+func testAccCheckMKSKubeconfigV1CertExpiry(value string) error {
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return err
+	}
+
+	// The certificate is issued when the cluster is created.
+	expected := time.Now().Add(fakeselectel.MKSClientCertLifetime)
+	if expiresAt.After(expected) || expiresAt.Before(expected.Add(-time.Hour)) {
+		return fmt.Errorf("expected client certificate to expire at about %s, got %s", expected.Format(time.RFC3339), value)
+	}
+
+	return nil
+}
+
 func testAccCheckMKSKubeconfigV1(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -70,3 +156,34 @@ data "selectel_mks_kubeconfig_v1" "kubeconfig_tf_acc_test_1" {
 }
 `, testAccMKSClusterV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart))
 }
+
+func testAccMKSKubeconfigV1Variants(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
+	return fmt.Sprintf(`
+%s
+
+data "selectel_mks_kubeconfig_v1" "kubeconfig_tf_acc_test_1" {
+  cluster_id   = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id   = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region       = "ru-9"
+  context_name = "ci"
+  namespace    = "apps"
+  token        = "secret-token"
+
+  exec {
+    api_version      = "client.authentication.k8s.io/v1"
+    command          = "kubectl-oidc_login"
+    args             = ["get-token"]
+    interactive_mode = "Never"
+    env = {
+      OIDC_ISSUER = "https://example.com"
+    }
+  }
+}
+
+data "selectel_mks_kubeconfig_v1" "kubeconfig_tf_acc_test_2" {
+  cluster_id = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+}
+`, testAccMKSClusterV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart))
+}
@@ -0,0 +1,173 @@
+package selectel
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func dataSourceMKSNodegroupsV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMKSNodegroupsV1Read,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"nodegroups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flavor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_gb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"volume_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"local_volume": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nodes_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"taints": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"effect": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"enable_autoscale": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"autoscale_min_nodes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"autoscale_max_nodes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nodegroup_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nodes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ip": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"hostname": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMKSNodegroupsV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	mksClient, diagErr := getMKSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+
+	mksNodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
+	if err != nil {
+		return diag.FromErr(errGettingObject("all nodegroups in the cluster", clusterID, err))
+	}
+
+	if err := d.Set("nodegroups", flattenMKSNodegroupsV1(mksNodegroups)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(clusterID)
+
+	return nil
+}
+
+func flattenMKSNodegroupsV1(views []*nodegroup.ListView) []interface{} {
+	nodegroups := make([]interface{}, len(views))
+	for i, view := range views {
+		nodegroups[i] = map[string]interface{}{
+			"id":                  view.ID,
+			"flavor_id":           view.FlavorID,
+			"volume_gb":           view.VolumeGB,
+			"volume_type":         view.VolumeType,
+			"local_volume":        view.LocalVolume,
+			"availability_zone":   view.AvailabilityZone,
+			"nodes_count":         len(view.Nodes),
+			"labels":              view.Labels,
+			"taints":              flattenMKSNodegroupV1Taints(view.Taints),
+			"enable_autoscale":    view.EnableAutoscale,
+			"autoscale_min_nodes": view.AutoscaleMinNodes,
+			"autoscale_max_nodes": view.AutoscaleMaxNodes,
+			"nodegroup_type":      view.NodegroupType,
+			"nodes":               flattenMKSNodegroupV1Nodes(view.Nodes),
+		}
+	}
+
+	return nodegroups
+}
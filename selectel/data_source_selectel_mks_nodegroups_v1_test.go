@@ -0,0 +1,94 @@
+package selectel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeselectel"
+)
+
+func TestAccMKSNodegroupsV1Basic(t *testing.T) {
+	var mksNodegroup nodegroup.GetView
+
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	dataSourceName := "data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMKSNodegroupsV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", &mksNodegroup),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.nodes.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "nodegroups.0.nodes.0.ip", "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", "nodes.0.ip"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupsV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksNodegroup nodegroup.GetView
+
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	dataSourceName := "data.selectel_mks_nodegroups_v1.nodegroups_tf_acc_test_1"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupsV1Basic(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &mksNodegroup),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.#", "1"),
+					resource.TestCheckResourceAttrPtr(dataSourceName, "nodegroups.0.id", &mksNodegroup.ID),
+					resource.TestCheckResourceAttrPair(dataSourceName, "nodegroups.0.flavor_id", resourceName, "flavor_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.availability_zone", "ru-9a"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.volume_gb", "10"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.volume_type", "fast.ru-9a"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.nodes_count", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.labels.label-key0", "label-value0"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.taints.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.taints.2.effect", "PreferNoSchedule"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.enable_autoscale", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.autoscale_min_nodes", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.autoscale_max_nodes", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.nodegroup_type", "STANDARD"),
+					resource.TestCheckResourceAttr(dataSourceName, "nodegroups.0.nodes.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "nodegroups.0.nodes.0.id", resourceName, "nodes.0.id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "nodegroups.0.nodes.0.ip", resourceName, "nodes.0.ip"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "nodegroups.0.nodes.1.hostname", resourceName, "nodes.1.hostname"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMKSNodegroupsV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
+	return testAccMKSNodegroupV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart) + `
+
+data "selectel_mks_nodegroups_v1" "nodegroups_tf_acc_test_1" {
+  cluster_id = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region     = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+
+  depends_on = [
+    selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1,
+  ]
+}`
+}
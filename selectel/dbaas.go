@@ -1,10 +1,13 @@
 package selectel
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"math/rand"
@@ -31,33 +34,106 @@ const (
 )
 
 func getDBaaSClient(d *schema.ResourceData, meta interface{}) (*dbaas.API, diag.Diagnostics) {
+	client, err := newDBaaSClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
+}
+
+func newDBaaSClient(meta interface{}, projectID, region string) (*dbaas.API, error) {
 	config := meta.(*Config)
-	projectID := d.Get("project_id").(string)
-	region := d.Get("region").(string)
 
 	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for dbaas: %w", err))
+		return nil, fmt.Errorf("can't get project-scope selvpc client for dbaas: %w", err)
 	}
 
-	err = validateRegion(selvpcClient, DBaaS, region)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't validate region: %w", err))
+	endpoint, ok := config.endpointOverride(DBaaS)
+	if !ok {
+		err = validateRegion(selvpcClient, DBaaS, region)
+		if err != nil {
+			return nil, fmt.Errorf("can't validate region: %w", err)
+		}
+
+		catalogEndpoint, err := selvpcClient.Catalog.GetEndpoint(DBaaS, region)
+		if err != nil {
+			return nil, fmt.Errorf("can't get endpoint to init dbaas client: %w", err)
+		}
+		endpoint = catalogEndpoint.URL
 	}
 
-	endpoint, err := selvpcClient.Catalog.GetEndpoint(DBaaS, region)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init dbaas client: %w", err))
+		return nil, fmt.Errorf("can't get token to init dbaas client: %w", err)
 	}
 
-	client, err := dbaas.NewDBAASClient(selvpcClient.GetXAuthToken(), endpoint.URL)
+	client, err := dbaas.NewDBAASClientV1WithCustomHTTP(config.newHTTPClient(projectID), token, endpoint)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't create dbaas client: %w", err))
+		return nil, fmt.Errorf("can't create dbaas client: %w", err)
 	}
 
 	return client, nil
 }
 
+// doDBaaSV1Request makes a request to the DBaaS API the same way the dbaas-go
+// client does and unmarshals the response body into result if it's not nil.
+func doDBaaSV1Request(ctx context.Context, client *dbaas.API, method, uri string, body, result interface{}) error {
+	var requestBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshalling params to JSON, %w", err)
+		}
+		requestBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, client.Endpoint+uri, requestBody)
+	if err != nil {
+		return fmt.Errorf("HTTP request creation failed, %w", err)
+	}
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.Header.Set("X-Auth-Token", client.Token)
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response body, %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &dbaas.DBaaSAPIError{}
+		if err := json.Unmarshal(respBody, apiErr); err != nil || apiErr.StatusCode() == 0 {
+			return fmt.Errorf("http status %d: %s", resp.StatusCode, respBody)
+		}
+
+		return apiErr
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("error during Unmarshal, %w", err)
+		}
+	}
+
+	return nil
+}
+
+func isDBaaSNotFoundError(err error) bool {
+	var dbaasError *dbaas.DBaaSAPIError
+
+	return errors.As(err, &dbaasError) && dbaasError.StatusCode() == http.StatusNotFound
+}
+
 func stringChecksum(s string) (string, error) {
 	h := md5.New() // #nosec
 	_, err := h.Write([]byte(s))
@@ -96,6 +172,35 @@ func convertFieldToStringByType(field interface{}) string {
 	}
 }
 
+// convertDatastoreConfigValues converts string values of the datastore config
+// to the typed ones like dbaas-go does for the requests it sends itself.
+func convertDatastoreConfigValues(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	converted := make(map[string]interface{}, len(config))
+	for name, value := range config {
+		stringValue, ok := value.(string)
+		if !ok {
+			converted[name] = value
+
+			continue
+		}
+		if intValue, err := strconv.Atoi(stringValue); err == nil {
+			converted[name] = intValue
+		} else if floatValue, err := strconv.ParseFloat(stringValue, 64); err == nil {
+			converted[name] = floatValue
+		} else if boolValue, err := strconv.ParseBool(stringValue); err == nil {
+			converted[name] = boolValue
+		} else {
+			converted[name] = stringValue
+		}
+	}
+
+	return converted
+}
+
 func RandomWithPrefix(name string) string {
 	return fmt.Sprintf("%s_%d", name, rand.New(rand.NewSource(time.Now().UnixNano())).Int())
 }
@@ -125,8 +230,8 @@ func waitForDBaaSDatastoreV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasDatastoreV1StateRefreshFunc(ctx, client, datastoreID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(20 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -225,6 +330,58 @@ func resourceDBaaSDatastoreV1InstancesToList(instances []dbaas.Instances) []inte
 	return flattenedInstances
 }
 
+func resourceDBaaSDatastoreV1PoolerToList(pooler dbaas.Pooler) []interface{} {
+	if pooler.Mode == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"mode": pooler.Mode,
+			"size": pooler.Size,
+		},
+	}
+}
+
+// resourceDBaaSDatastoreV1FirewallToList keeps the configured firewall block
+// without IPs so it doesn't produce a diff.
+func resourceDBaaSDatastoreV1FirewallToList(d *schema.ResourceData, firewall []dbaas.Firewall) []interface{} {
+	if len(firewall) == 0 && d.Get("firewall").(*schema.Set).Len() == 0 {
+		return []interface{}{}
+	}
+
+	ips := make([]interface{}, len(firewall))
+	for i, rule := range firewall {
+		ips[i] = rule.IP
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"ips": ips,
+		},
+	}
+}
+
+// resourceDBaaSDatastoreV1FloatingIPsToList counts floating IPs of the
+// datastore instances. The configured block without floating IPs is kept so
+// it doesn't produce a diff.
+func resourceDBaaSDatastoreV1FloatingIPsToList(d *schema.ResourceData, datastore dbaas.Datastore) ([]interface{}, error) {
+	floatingIPs, err := getFloatingIPsSchemaFromDatastoreInstances(datastore)
+	if err != nil {
+		return nil, err
+	}
+	if floatingIPs.Master == 0 && floatingIPs.Replica == 0 && d.Get("floating_ips").(*schema.Set).Len() == 0 {
+		return []interface{}{}, nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"master":  floatingIPs.Master,
+			"replica": floatingIPs.Replica,
+		},
+	}, nil
+}
+
 func resourceDBaaSDatastoreV1FirewallOptsFromSet(firewallSet *schema.Set) (dbaas.DatastoreFirewallOpts, error) {
 	if firewallSet.Len() == 0 {
 		return dbaas.DatastoreFirewallOpts{IPs: []string{}}, nil
@@ -524,8 +681,8 @@ func waitForDBaaSDatabaseV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasDatabaseV1StateRefreshFunc(ctx, client, databaseID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -592,8 +749,8 @@ func waitForDBaaSUserV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasUserV1StateRefreshFunc(ctx, client, userID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -651,8 +808,8 @@ func waitForDBaaSLogicalReplicationSlotV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasLogicalReplicationSlotV1StateRefreshFunc(ctx, client, slotID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -710,8 +867,8 @@ func waitForDBaaSTopicV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasTopicV1StateRefreshFunc(ctx, client, topicID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(20 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -769,8 +926,8 @@ func waitForDBaaSACLV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasACLV1StateRefreshFunc(ctx, client, aclID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(15 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -0,0 +1,165 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/selectel/dbaas-go"
+)
+
+// dbaasBackupsURI is the DBaaS API path of the datastore backups. The dbaas-go
+// client doesn't cover backups yet, so they are requested directly.
+const dbaasBackupsURI = "/backups"
+
+// dbaasBackup is the API response for the datastore backups.
+type dbaasBackup struct {
+	ID          string       `json:"id"`
+	CreatedAt   string       `json:"created_at"`
+	UpdatedAt   string       `json:"updated_at"`
+	ProjectID   string       `json:"project_id"`
+	DatastoreID string       `json:"datastore_id"`
+	Status      dbaas.Status `json:"status"`
+	Type        string       `json:"type"`
+	Size        int          `json:"size"`
+}
+
+// dbaasBackupCreateOpts represents options for the backup Create request.
+type dbaasBackupCreateOpts struct {
+	DatastoreID string `json:"datastore_id"`
+}
+
+// getDBaaSBackups returns backups of the datastore sorted by the creation time.
+func getDBaaSBackups(ctx context.Context, client *dbaas.API, datastoreID string) ([]dbaasBackup, error) {
+	uri := dbaasBackupsURI + "?" + url.Values{"datastore_id": {datastoreID}}.Encode()
+
+	var result struct {
+		Backups []dbaasBackup `json:"backups"`
+	}
+	if err := doDBaaSV1Request(ctx, client, http.MethodGet, uri, nil, &result); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result.Backups, func(i, j int) bool {
+		return result.Backups[i].CreatedAt < result.Backups[j].CreatedAt
+	})
+
+	return result.Backups, nil
+}
+
+// getDBaaSBackup returns a backup based on the ID.
+func getDBaaSBackup(ctx context.Context, client *dbaas.API, backupID string) (dbaasBackup, error) {
+	var result struct {
+		Backup dbaasBackup `json:"backup"`
+	}
+	uri := fmt.Sprintf("%s/%s", dbaasBackupsURI, backupID)
+	if err := doDBaaSV1Request(ctx, client, http.MethodGet, uri, nil, &result); err != nil {
+		return dbaasBackup{}, err
+	}
+
+	return result.Backup, nil
+}
+
+// createDBaaSBackup requests a manual backup of the datastore.
+func createDBaaSBackup(ctx context.Context, client *dbaas.API, opts dbaasBackupCreateOpts) (dbaasBackup, error) {
+	body := struct {
+		Backup dbaasBackupCreateOpts `json:"backup"`
+	}{
+		Backup: opts,
+	}
+	var result struct {
+		Backup dbaasBackup `json:"backup"`
+	}
+	if err := doDBaaSV1Request(ctx, client, http.MethodPost, dbaasBackupsURI, body, &result); err != nil {
+		return dbaasBackup{}, err
+	}
+
+	return result.Backup, nil
+}
+
+// deleteDBaaSBackup deletes an existing backup.
+func deleteDBaaSBackup(ctx context.Context, client *dbaas.API, backupID string) error {
+	uri := fmt.Sprintf("%s/%s", dbaasBackupsURI, backupID)
+
+	return doDBaaSV1Request(ctx, client, http.MethodDelete, uri, nil, nil)
+}
+
+func waitForDBaaSBackupV1ActiveState(
+	ctx context.Context, client *dbaas.API, backupID string, timeout time.Duration,
+) error {
+	pending := []string{
+		string(dbaas.StatusPendingCreate),
+		string(dbaas.StatusPendingUpdate),
+	}
+	target := []string{
+		string(dbaas.StatusActive),
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    dbaasBackupV1StateRefreshFunc(ctx, client, backupID),
+		Timeout:    timeout,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(20 * time.Second),
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf(
+			"error waiting for the backup %s to become 'ACTIVE': %s",
+			backupID, err)
+	}
+
+	return nil
+}
+
+func dbaasBackupV1StateRefreshFunc(ctx context.Context, client *dbaas.API, backupID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		backup, err := getDBaaSBackup(ctx, client, backupID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return backup, string(backup.Status), nil
+	}
+}
+
+func dbaasBackupV1DeleteStateRefreshFunc(ctx context.Context, client *dbaas.API, backupID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		backup, err := getDBaaSBackup(ctx, client, backupID)
+		if err != nil {
+			var dbaasError *dbaas.DBaaSAPIError
+			if errors.As(err, &dbaasError) {
+				return backup, strconv.Itoa(dbaasError.StatusCode()), nil
+			}
+
+			return nil, "", err
+		}
+
+		return backup, strconv.Itoa(http.StatusOK), nil
+	}
+}
+
+func flattenDBaaSBackups(backups []dbaasBackup) []interface{} {
+	backupsList := make([]interface{}, len(backups))
+	for i, backup := range backups {
+		backupsList[i] = map[string]interface{}{
+			"id":           backup.ID,
+			"created_at":   backup.CreatedAt,
+			"updated_at":   backup.UpdatedAt,
+			"datastore_id": backup.DatastoreID,
+			"status":       string(backup.Status),
+			"type":         backup.Type,
+			"size":         backup.Size,
+		}
+	}
+
+	return backupsList
+}
@@ -0,0 +1,252 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/dbaas-go"
+)
+
+// dbaasDatastoreV1ConfigDiff checks at plan time the parameters of the
+// datastore config that are changed in the plan against the configuration
+// parameters of the datastore type.
+func dbaasDatastoreV1ConfigDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || !d.HasChange("config") {
+		return nil
+	}
+	for _, key := range []string{"project_id", "region", "type_id", "config"} {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] skipping config check of datastore %s until %q is known", d.Id(), key)
+
+			return nil
+		}
+	}
+
+	oldConfig, newConfig := d.GetChange("config")
+	if d.HasChange("type_id") {
+		// The datastore is replaced or upgraded, so all parameters are checked
+		// against the new datastore type.
+		oldConfig = map[string]interface{}{}
+	}
+	changed := changedDatastoreConfigParams(oldConfig.(map[string]interface{}), newConfig.(map[string]interface{}))
+	if len(changed) == 0 {
+		return nil
+	}
+
+	client, err := newDBaaSClient(config, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+	typeID := d.Get("type_id").(string)
+	params, err := getDatastoreConfigurationParameters(ctx, client, typeID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		invalid        []string
+		restartParams  []string
+		newConfigItems = newConfig.(map[string]interface{})
+	)
+	for _, name := range changed {
+		value, set := newConfigItems[name]
+		param, ok := params[name]
+		if !ok {
+			// Removed parameters are reset, so only the set ones must exist.
+			if set {
+				invalid = append(invalid, fmt.Sprintf("unknown parameter %q", name))
+			}
+
+			continue
+		}
+		if set {
+			if err := validateDatastoreConfigParam(param, value.(string)); err != nil {
+				invalid = append(invalid, err.Error())
+
+				continue
+			}
+		}
+		if param.IsRestartRequired {
+			restartParams = append(restartParams, name)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid config of the datastore type %s: %s", typeID, strings.Join(invalid, "; "))
+	}
+	if len(restartParams) > 0 && d.Id() != "" {
+		log.Printf("[WARN] %s", datastoreConfigRestartMessage(d.Id(), restartParams))
+	}
+
+	return nil
+}
+
+// dbaasDatastoreV1ConfigRestartWarnings returns a warning if the applied config
+// change of the datastore requires a restart to take effect.
+func dbaasDatastoreV1ConfigRestartWarnings(ctx context.Context, d *schema.ResourceData, client *dbaas.API) diag.Diagnostics {
+	oldConfig, newConfig := d.GetChange("config")
+	changed := changedDatastoreConfigParams(oldConfig.(map[string]interface{}), newConfig.(map[string]interface{}))
+	if len(changed) == 0 {
+		return nil
+	}
+
+	params, err := getDatastoreConfigurationParameters(ctx, client, d.Get("type_id").(string))
+	if err != nil {
+		log.Printf("[DEBUG] can't check if the config change of datastore %s requires a restart: %s", d.Id(), err)
+
+		return nil
+	}
+
+	var restartParams []string
+	for _, name := range changed {
+		if params[name].IsRestartRequired {
+			restartParams = append(restartParams, name)
+		}
+	}
+	if len(restartParams) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  datastoreConfigRestartMessage(d.Id(), restartParams),
+	}}
+}
+
+func datastoreConfigRestartMessage(datastoreID string, params []string) string {
+	return fmt.Sprintf("changes of the parameters %s of datastore %s take effect only after a restart of the datastore",
+		strings.Join(params, ", "), datastoreID)
+}
+
+// getDatastoreConfigurationParameters returns configuration parameters of the
+// datastore type by their names.
+func getDatastoreConfigurationParameters(ctx context.Context, client *dbaas.API, typeID string) (map[string]dbaas.ConfigurationParameter, error) {
+	log.Print(msgGet(objectConfigurationParameters, typeID))
+	configurationParameters, err := client.ConfigurationParameters(ctx)
+	if err != nil {
+		return nil, errGettingObjects(objectConfigurationParameters, err)
+	}
+
+	params := make(map[string]dbaas.ConfigurationParameter)
+	for _, param := range filterConfigurationParametersByDatastoreTypeID(configurationParameters, typeID) {
+		params[param.Name] = param
+	}
+
+	return params, nil
+}
+
+// changedDatastoreConfigParams returns sorted names of the config parameters
+// that are added, changed or removed.
+func changedDatastoreConfigParams(oldConfig, newConfig map[string]interface{}) []string {
+	var changed []string
+	for name, value := range newConfig {
+		if oldValue, ok := oldConfig[name]; !ok || oldValue != value {
+			changed = append(changed, name)
+		}
+	}
+	for name := range oldConfig {
+		if _, ok := newConfig[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
+
+// validateDatastoreConfigParam checks the config value against the type,
+// the limits and the allowed values of the configuration parameter.
+func validateDatastoreConfigParam(param dbaas.ConfigurationParameter, value string) error {
+	if !param.IsChangeable {
+		return fmt.Errorf("parameter %q can't be changed", param.Name)
+	}
+
+	var (
+		number   float64
+		isNumber bool
+	)
+	switch param.Type {
+	case "int", "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parameter %q must be an integer, got %q", param.Name, value)
+		}
+		number, isNumber = float64(n), true
+	case "float", "real":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("parameter %q must be a number, got %q", param.Name, value)
+		}
+		number, isNumber = n, true
+	case "bool", "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("parameter %q must be a boolean, got %q", param.Name, value)
+		}
+	}
+
+	if isNumber {
+		if minValue, ok := configParamNumber(param.Min); ok && number < minValue {
+			return fmt.Errorf("parameter %q must be at least %s%s, got %q",
+				param.Name, convertFieldToStringByType(param.Min), configParamUnit(param), value)
+		}
+		if maxValue, ok := configParamNumber(param.Max); ok && number > maxValue {
+			return fmt.Errorf("parameter %q must be at most %s%s, got %q",
+				param.Name, convertFieldToStringByType(param.Max), configParamUnit(param), value)
+		}
+	}
+
+	if len(param.Choices) > 0 && !containsConfigParamValue(param.Choices, value) {
+		choices := make([]string, 0, len(param.Choices))
+		for _, choice := range param.Choices {
+			choices = append(choices, convertFieldToStringByType(choice))
+		}
+
+		return fmt.Errorf("parameter %q must be one of %s, got %q", param.Name, strings.Join(choices, ", "), value)
+	}
+	if containsConfigParamValue(param.InvalidValues, value) {
+		return fmt.Errorf("value %q is not allowed for parameter %q", value, param.Name)
+	}
+
+	return nil
+}
+
+// configParamNumber converts the limit of the configuration parameter to
+// float64. It returns false if the parameter has no limit.
+func configParamNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func configParamUnit(param dbaas.ConfigurationParameter) string {
+	if param.Unit == "" {
+		return ""
+	}
+
+	return " " + param.Unit
+}
+
+func containsConfigParamValue(values []interface{}, value string) bool {
+	for _, v := range values {
+		if convertFieldToStringByType(v) == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,256 @@
+package selectel
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/secretsmanager-go/secretsmanagererrors"
+	"github.com/selectel/secretsmanager-go/service/secrets"
+)
+
+const (
+	dbaasGeneratedPasswordLength = 32
+
+	dbaasGeneratedPasswordLowercase = "abcdefghijklmnopqrstuvwxyz"
+	dbaasGeneratedPasswordUppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	dbaasGeneratedPasswordDigits    = "0123456789"
+)
+
+// generateDBaaSPassword returns a random password of letters and digits that
+// has at least one lowercase letter, one uppercase letter and one digit.
+// Special characters are skipped, so the password can be used in connection
+// strings as is.
+func generateDBaaSPassword() (string, error) {
+	chars := dbaasGeneratedPasswordLowercase + dbaasGeneratedPasswordUppercase + dbaasGeneratedPasswordDigits
+	for {
+		password := make([]byte, dbaasGeneratedPasswordLength)
+		for i := range password {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+			if err != nil {
+				return "", fmt.Errorf("can't generate password: %w", err)
+			}
+			password[i] = chars[n.Int64()]
+		}
+
+		if strings.ContainsAny(string(password), dbaasGeneratedPasswordLowercase) &&
+			strings.ContainsAny(string(password), dbaasGeneratedPasswordUppercase) &&
+			strings.ContainsAny(string(password), dbaasGeneratedPasswordDigits) {
+			return string(password), nil
+		}
+	}
+}
+
+// dbaasPasswordConfigDiff returns a CustomizeDiffFunc that checks that the
+// config either sets the password or enables generate_password. Existing
+// resources are checked only when one of them changes.
+func dbaasPasswordConfigDiff(passwordKey string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		// Terraform replaces ignored changes in the config with the state values,
+		// and the state of imported resources has no password.
+		if d.Id() != "" && !d.HasChange(passwordKey) && !d.HasChange("generate_password") {
+			return nil
+		}
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+		generatePassword := config.GetAttr("generate_password")
+		if !generatePassword.IsKnown() {
+			return nil
+		}
+
+		// Unknown passwords are set, they are known on apply.
+		passwordSet := !config.GetAttr(passwordKey).IsNull()
+		generate := !generatePassword.IsNull() && generatePassword.True()
+		switch {
+		case passwordSet && generate:
+			return fmt.Errorf("%q can't be set when \"generate_password\" is enabled", passwordKey)
+		case !passwordSet && !generate:
+			return fmt.Errorf("either %q or \"generate_password\" must be set", passwordKey)
+		}
+
+		return nil
+	}
+}
+
+// dbaasGeneratedPasswordDiff returns a CustomizeDiffFunc that plans a new
+// generated password when it's enabled, when rotation_trigger changes or when
+// rotation_period has passed since the last rotation.
+func dbaasGeneratedPasswordDiff(passwordKey string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.Get("generate_password").(bool) {
+			if d.Get("password_rotated_at").(string) != "" {
+				return d.SetNew("password_rotated_at", "")
+			}
+
+			return nil
+		}
+		if d.Id() == "" {
+			// The password is generated on creation.
+			return nil
+		}
+
+		rotate := d.HasChange("generate_password") || d.HasChange("rotation_trigger") ||
+			dbaasPasswordRotationDue(d.Get("password_rotated_at").(string), d.Get("rotation_period").(string), time.Now())
+		if !rotate {
+			return nil
+		}
+
+		log.Printf("[DEBUG] new password of %s is generated", d.Id())
+		if err := d.SetNewComputed(passwordKey); err != nil {
+			return err
+		}
+
+		return d.SetNewComputed("password_rotated_at")
+	}
+}
+
+// dbaasPasswordRotationDue reports whether the rotation period has passed
+// since the password was rotated.
+func dbaasPasswordRotationDue(rotatedAt, period string, now time.Time) bool {
+	if period == "" {
+		return false
+	}
+	duration, err := time.ParseDuration(period)
+	if err != nil {
+		return false
+	}
+	rotatedAtTime, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		// The time of the last rotation is unknown, for example, after import.
+		return true
+	}
+
+	return !now.Before(rotatedAtTime.Add(duration))
+}
+
+func validateDBaaSPasswordRotationPeriod(v interface{}, k string) ([]string, []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration, for example, 720h: %w", k, err)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("%q must be positive, got %s", k, v)}
+	}
+
+	return nil, nil
+}
+
+// dbaasPasswordChanged reports whether a new password must be set: a new
+// generated one or the changed one from the config.
+func dbaasPasswordChanged(d *schema.ResourceData, passwordKey string) bool {
+	if plan := d.GetRawPlan(); d.Get("generate_password").(bool) && !plan.IsNull() {
+		// The new generated password is planned as unknown.
+		return !plan.GetAttr(passwordKey).IsKnown()
+	}
+
+	return d.HasChange(passwordKey)
+}
+
+// newDBaaSPassword returns a new generated password or the password from the
+// config.
+func newDBaaSPassword(d *schema.ResourceData, passwordKey string) (string, error) {
+	if !d.Get("generate_password").(bool) {
+		return d.Get(passwordKey).(string), nil
+	}
+
+	return generateDBaaSPassword()
+}
+
+// setDBaaSGeneratedPassword saves the applied generated password in the state.
+// Passwords from the config have no rotation time.
+func setDBaaSGeneratedPassword(d *schema.ResourceData, passwordKey, password string) {
+	if !d.Get("generate_password").(bool) {
+		if d.Get("password_rotated_at").(string) != "" {
+			d.Set("password_rotated_at", "")
+		}
+
+		return
+	}
+
+	d.Set(passwordKey, password)
+	d.Set("password_rotated_at", time.Now().UTC().Format(time.RFC3339))
+}
+
+// updateDBaaSPasswordSecret writes the password into the Secrets Manager
+// secret of secret_key. A secret of a new key is created before the old one is
+// deleted. Secrets can't get a new value in place, so the secret of the same key
+// is deleted and created again.
+func updateDBaaSPasswordSecret(ctx context.Context, d *schema.ResourceData, meta interface{}, password, description string) diag.Diagnostics {
+	oldKey, newKey := d.GetChange("secret_key")
+	if oldKey.(string) == "" && newKey.(string) == "" {
+		return nil
+	}
+
+	cl, diagErr := getSecretsManagerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	secret := secrets.UserSecret{
+		Key:         newKey.(string),
+		Description: description,
+		Value:       password,
+	}
+
+	if oldKey.(string) == newKey.(string) {
+		diagErr = deleteDBaaSPasswordSecret(ctx, d, meta, oldKey.(string))
+		if diagErr != nil {
+			return diagErr
+		}
+
+		log.Print(msgCreate(objectSecret, secret.Key))
+		err := cl.Secrets.Create(ctx, secret)
+		if err != nil {
+			// The secret is missing from the state, so the next apply creates it.
+			d.Set("secret_key", "")
+
+			return diag.FromErr(fmt.Errorf(
+				"secret %s was deleted, but the password can't be written to it, the next apply creates it again: %w",
+				secret.Key, err))
+		}
+
+		return nil
+	}
+
+	if newKey.(string) != "" {
+		log.Print(msgCreate(objectSecret, secret.Key))
+		err := cl.Secrets.Create(ctx, secret)
+		if err != nil {
+			// The old secret is kept in the state, so the next apply moves it again.
+			d.Set("secret_key", oldKey)
+
+			return diag.FromErr(errCreatingObject(objectSecret, err))
+		}
+	}
+	if oldKey.(string) != "" {
+		return deleteDBaaSPasswordSecret(ctx, d, meta, oldKey.(string))
+	}
+
+	return nil
+}
+
+// deleteDBaaSPasswordSecret deletes the Secrets Manager secret of the password
+// unless it's already deleted.
+func deleteDBaaSPasswordSecret(ctx context.Context, d *schema.ResourceData, meta interface{}, key string) diag.Diagnostics {
+	cl, diagErr := getSecretsManagerClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgDelete(objectSecret, key))
+	err := cl.Secrets.Delete(ctx, key)
+	if err != nil && !errors.Is(err, secretsmanagererrors.ErrNotFoundStatusText) {
+		return diag.FromErr(errDeletingObject(objectSecret, key, err))
+	}
+
+	return nil
+}
@@ -8,9 +8,20 @@ import (
 	"github.com/selectel/dbaas-go"
 )
 
-func updateRedisDatastorePassword(ctx context.Context, d *schema.ResourceData, client *dbaas.API) error {
+func updateRedisDatastorePassword(ctx context.Context, d *schema.ResourceData, client *dbaas.API, password string) error {
+	err := setRedisDatastorePassword(ctx, d, client, password)
+	if err != nil {
+		return err
+	}
+
+	return waitForRedisDatastorePassword(ctx, d, client)
+}
+
+// setRedisDatastorePassword sends the new password without waiting for the
+// datastore to apply it.
+func setRedisDatastorePassword(ctx context.Context, d *schema.ResourceData, client *dbaas.API, password string) error {
 	passwordOpts := dbaas.DatastorePasswordOpts{
-		RedisPassword: d.Get("redis_password").(string),
+		RedisPassword: password,
 	}
 
 	log.Print(msgUpdate(objectDatastore, d.Id(), passwordOpts))
@@ -19,9 +30,13 @@ func updateRedisDatastorePassword(ctx context.Context, d *schema.ResourceData, c
 		return errUpdatingObject(objectDatastore, d.Id(), err)
 	}
 
+	return nil
+}
+
+func waitForRedisDatastorePassword(ctx context.Context, d *schema.ResourceData, client *dbaas.API) error {
 	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", d.Id())
 	timeout := d.Timeout(schema.TimeoutUpdate)
-	err = waitForDBaaSDatastoreV1ActiveState(ctx, client, d.Id(), timeout)
+	err := waitForDBaaSDatastoreV1ActiveState(ctx, client, d.Id(), timeout)
 	if err != nil {
 		return errUpdatingObject(objectDatastore, d.Id(), err)
 	}
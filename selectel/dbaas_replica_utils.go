@@ -0,0 +1,272 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/dbaas-go"
+)
+
+// dbaasDatastoreReplication represents the source datastore of a replica
+// datastore.
+type dbaasDatastoreReplication struct {
+	DatastoreID string `json:"datastore_id"`
+	Region      string `json:"region,omitempty"`
+}
+
+// dbaasDatastoreV1CreateOpts represents options for the datastore Create
+// request with the replication parameters that dbaas-go doesn't support yet.
+type dbaasDatastoreV1CreateOpts struct {
+	dbaas.DatastoreCreateOpts
+	Replication *dbaasDatastoreReplication `json:"replication,omitempty"`
+}
+
+// dbaasDatastoreV1View is the API response for the datastores with the
+// replication parameters.
+type dbaasDatastoreV1View struct {
+	dbaas.Datastore
+	Replication *dbaasDatastoreReplication `json:"replication"`
+}
+
+// createDBaaSDatastoreV1 creates a new datastore. Replica datastores are
+// requested directly as dbaas-go can't pass the replication parameters.
+func createDBaaSDatastoreV1(ctx context.Context, client *dbaas.API, opts dbaasDatastoreV1CreateOpts) (dbaas.Datastore, error) {
+	if opts.Replication == nil {
+		return client.CreateDatastore(ctx, opts.DatastoreCreateOpts)
+	}
+
+	opts.Config = convertDatastoreConfigValues(opts.Config)
+	body := struct {
+		Datastore dbaasDatastoreV1CreateOpts `json:"datastore"`
+	}{
+		Datastore: opts,
+	}
+	var result struct {
+		Datastore dbaas.Datastore `json:"datastore"`
+	}
+	if err := doDBaaSV1Request(ctx, client, http.MethodPost, dbaas.DatastoresURI, body, &result); err != nil {
+		return dbaas.Datastore{}, err
+	}
+
+	return result.Datastore, nil
+}
+
+// getDBaaSDatastoreV1 returns a datastore based on the ID like
+// client.Datastore does, but with the replication parameters.
+func getDBaaSDatastoreV1(ctx context.Context, client *dbaas.API, datastoreID string) (dbaasDatastoreV1View, error) {
+	var result struct {
+		Datastore dbaasDatastoreV1View `json:"datastore"`
+	}
+	uri := fmt.Sprintf("%s/%s", dbaas.DatastoresURI, datastoreID)
+	if err := doDBaaSV1Request(ctx, client, http.MethodGet, uri, nil, &result); err != nil {
+		return dbaasDatastoreV1View{}, err
+	}
+
+	return result.Datastore, nil
+}
+
+// promoteDBaaSDatastoreV1 stops the replication of a replica datastore and
+// makes it a standalone one.
+func promoteDBaaSDatastoreV1(ctx context.Context, client *dbaas.API, datastoreID string) error {
+	uri := fmt.Sprintf("%s/%s/promote", dbaas.DatastoresURI, datastoreID)
+
+	return doDBaaSV1Request(ctx, client, http.MethodPost, uri, nil, nil)
+}
+
+func updateDatastoreReplication(ctx context.Context, d *schema.ResourceData, client *dbaas.API) error {
+	// Other changes of the source datastore replace the replica.
+	if len(d.Get("replica_of").([]interface{})) != 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] promoting datastore %s", d.Id())
+	err := promoteDBaaSDatastoreV1(ctx, client, d.Id())
+	if err != nil {
+		return errUpdatingObject(objectDatastore, d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", d.Id())
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	err = waitForDBaaSDatastoreV1ActiveState(ctx, client, d.Id(), timeout)
+	if err != nil {
+		return errUpdatingObject(objectDatastore, d.Id(), err)
+	}
+
+	return nil
+}
+
+// expandDBaaSDatastoreV1ReplicaOf returns the source datastore of the
+// replica_of block. The source is in the region of the replica unless the
+// block sets another one.
+func expandDBaaSDatastoreV1ReplicaOf(replicaOf []interface{}, region string) *dbaasDatastoreReplication {
+	if len(replicaOf) == 0 || replicaOf[0] == nil {
+		return nil
+	}
+	replicaOfMap := replicaOf[0].(map[string]interface{})
+
+	replication := &dbaasDatastoreReplication{
+		DatastoreID: replicaOfMap["datastore_id"].(string),
+		Region:      replicaOfMap["region"].(string),
+	}
+	if replication.Region == "" {
+		replication.Region = region
+	}
+
+	return replication
+}
+
+func flattenDBaaSDatastoreV1ReplicaOf(replication *dbaasDatastoreReplication) []interface{} {
+	if replication == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"datastore_id": replication.DatastoreID,
+			"region":       replication.Region,
+		},
+	}
+}
+
+// dbaasDatastoreV1ReplicaOfRegionDiffSuppressFunc suppresses the region of
+// the source datastore that is omitted because it's the region of the replica.
+func dbaasDatastoreV1ReplicaOfRegionDiffSuppressFunc(_, old, new string, d *schema.ResourceData) bool {
+	return new == "" && old == d.Get("region").(string)
+}
+
+// dbaasDatastoreV1ReplicaOfDiff replaces the datastore if it becomes a replica
+// or its source datastore changes, and checks at plan time that the replica
+// is compatible with the source datastore. Removal of the replica_of block
+// promotes the replica in place.
+func dbaasDatastoreV1ReplicaOfDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+
+	oldRegion, newRegion := d.GetChange("region")
+	oldReplicaOf, newReplicaOf := d.GetChange("replica_of")
+	oldSource := expandDBaaSDatastoreV1ReplicaOf(oldReplicaOf.([]interface{}), oldRegion.(string))
+	newSource := expandDBaaSDatastoreV1ReplicaOf(newReplicaOf.([]interface{}), newRegion.(string))
+	if newSource == nil {
+		return nil
+	}
+
+	if d.Id() != "" {
+		switch {
+		case oldSource == nil:
+			log.Printf("[DEBUG] datastore %s becomes a replica of %s and must be replaced", d.Id(), newSource.DatastoreID)
+			if err := d.ForceNew("replica_of"); err != nil {
+				return err
+			}
+		case *oldSource != *newSource:
+			log.Printf("[DEBUG] source datastore of the replica %s changes and it must be replaced", d.Id())
+			if err := d.ForceNew("replica_of"); err != nil {
+				return err
+			}
+		case !d.HasChange("type_id") && !d.HasChange("flavor") && !d.HasChange("flavor_id"):
+			return nil
+		}
+	}
+
+	for _, key := range []string{"project_id", "region", "type_id", "replica_of.0.datastore_id", "replica_of.0.region"} {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] skipping replica check of datastore %s until %q is known", d.Id(), key)
+
+			return nil
+		}
+	}
+
+	projectID := d.Get("project_id").(string)
+	client, err := newDBaaSClient(config, projectID, newRegion.(string))
+	if err != nil {
+		return err
+	}
+	sourceClient := client
+	if newSource.Region != newRegion.(string) {
+		sourceClient, err = newDBaaSClient(config, projectID, newSource.Region)
+		if err != nil {
+			return err
+		}
+	}
+
+	source, err := sourceClient.Datastore(ctx, newSource.DatastoreID)
+	if err != nil {
+		return fmt.Errorf("can't get source datastore %s of the replica in %s: %w", newSource.DatastoreID, newSource.Region, err)
+	}
+	sourceType, err := sourceClient.DatastoreType(ctx, source.TypeID)
+	if err != nil {
+		return errGettingObject(objectDatastoreTypes, source.TypeID, err)
+	}
+	typeID := d.Get("type_id").(string)
+	replicaType, err := client.DatastoreType(ctx, typeID)
+	if err != nil {
+		return errGettingObject(objectDatastoreTypes, typeID, err)
+	}
+
+	replicaDisk, ok, err := dbaasDatastoreV1DiffDisk(ctx, d, client)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// The disk is checked once the flavor is known.
+		replicaDisk = source.Flavor.Disk
+	}
+
+	return checkDatastoreReplicaCompatibility(sourceType, replicaType, source.Flavor.Disk, replicaDisk)
+}
+
+// dbaasDatastoreV1DiffDisk returns the disk size in GB of the datastore
+// flavor in the plan. It returns false if the flavor isn't known yet.
+func dbaasDatastoreV1DiffDisk(ctx context.Context, d *schema.ResourceDiff, client *dbaas.API) (int, bool, error) {
+	flavorID := d.Get("flavor_id").(string)
+	if d.NewValueKnown("flavor_id") && flavorID != "" && (d.Id() == "" || d.HasChange("flavor_id")) {
+		flavor, err := client.Flavor(ctx, flavorID)
+		if err != nil {
+			return 0, false, errGettingObject(objectFlavor, flavorID, err)
+		}
+
+		return flavor.Disk, true, nil
+	}
+
+	if !d.NewValueKnown("flavor") {
+		return 0, false, nil
+	}
+	flavorSet := d.Get("flavor").(*schema.Set)
+	if flavorSet.Len() == 0 {
+		return 0, false, nil
+	}
+	flavor, err := resourceDBaaSDatastoreV1FlavorFromSet(flavorSet)
+	if err != nil {
+		return 0, false, errParseDatastoreV1Flavor(err)
+	}
+
+	return flavor.Disk, true, nil
+}
+
+// checkDatastoreReplicaCompatibility checks that the replica runs the same
+// engine and version as the source datastore and has enough disk for its data.
+func checkDatastoreReplicaCompatibility(sourceType, replicaType dbaas.DatastoreType, sourceDisk, replicaDisk int) error {
+	var incompatible []string
+	if replicaType.Engine != sourceType.Engine {
+		incompatible = append(incompatible, fmt.Sprintf("replica engine %s doesn't match engine %s of the source datastore",
+			replicaType.Engine, sourceType.Engine))
+	} else if replicaType.Version != sourceType.Version {
+		incompatible = append(incompatible, fmt.Sprintf("replica version %s doesn't match version %s of the source datastore",
+			replicaType.Version, sourceType.Version))
+	}
+	if replicaDisk < sourceDisk {
+		incompatible = append(incompatible, fmt.Sprintf("replica flavor disk %d GB is smaller than disk %d GB of the source datastore",
+			replicaDisk, sourceDisk))
+	}
+
+	if len(incompatible) > 0 {
+		return fmt.Errorf("replica is incompatible with the source datastore: %s", strings.Join(incompatible, "; "))
+	}
+
+	return nil
+}
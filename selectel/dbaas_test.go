@@ -3,6 +3,10 @@ package selectel
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -32,10 +36,207 @@ func newTestDBaaSClient(_ context.Context, rs *terraform.ResourceState, testAccP
 		endpoint = dbaasEndpoint.URL
 	}
 
-	dbaasClient, err := dbaas.NewDBAASClient(selvpcClient.GetXAuthToken(), endpoint)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for dbaas acc tests: %w", err)
+	}
+
+	dbaasClient, err := dbaas.NewDBAASClient(token, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("can't get dbaas client for dbaas acc tests: %w", err)
 	}
 
 	return dbaasClient, nil
 }
+
+func TestValidateDatastoreConfigParam(t *testing.T) {
+	workMem := dbaas.ConfigurationParameter{
+		Name: "work_mem", Type: "int", Unit: "kB", Min: float64(64), Max: float64(2147483647), IsChangeable: true,
+	}
+	vacuumCostDelay := dbaas.ConfigurationParameter{
+		Name: "vacuum_cost_delay", Type: "float", Min: float64(0), Max: float64(100), IsChangeable: true,
+	}
+	transformNullEquals := dbaas.ConfigurationParameter{
+		Name: "transform_null_equals", Type: "boolean", IsChangeable: true,
+	}
+	xmloption := dbaas.ConfigurationParameter{
+		Name: "xmloption", Type: "str", Choices: []interface{}{"content", "document"}, IsChangeable: true,
+	}
+	autoIncrement := dbaas.ConfigurationParameter{
+		Name: "auto_increment_increment", Type: "int", InvalidValues: []interface{}{float64(13)}, IsChangeable: true,
+	}
+	lcMessages := dbaas.ConfigurationParameter{
+		Name: "lc_messages", Type: "str",
+	}
+
+	tableTests := []struct {
+		param         dbaas.ConfigurationParameter
+		value         string
+		expectedError string
+	}{
+		{param: workMem, value: "128"},
+		{param: workMem, value: "64"},
+		{param: workMem, value: "32", expectedError: `must be at least 64 kB`},
+		{param: workMem, value: "3000000000", expectedError: `must be at most 2147483647 kB`},
+		{param: workMem, value: "1.5", expectedError: `must be an integer`},
+		{param: vacuumCostDelay, value: "2.5"},
+		{param: vacuumCostDelay, value: "101", expectedError: `must be at most 100`},
+		{param: vacuumCostDelay, value: "fast", expectedError: `must be a number`},
+		{param: transformNullEquals, value: "true"},
+		{param: transformNullEquals, value: "yes", expectedError: `must be a boolean`},
+		{param: xmloption, value: "document"},
+		{param: xmloption, value: "text", expectedError: `must be one of content, document`},
+		{param: autoIncrement, value: "12"},
+		{param: autoIncrement, value: "13", expectedError: `is not allowed`},
+		{param: lcMessages, value: "C", expectedError: `can't be changed`},
+	}
+
+	for _, test := range tableTests {
+		err := validateDatastoreConfigParam(test.param, test.value)
+		if test.expectedError == "" {
+			if err != nil {
+				t.Errorf("Expected %s = %q to be valid, but got: %s", test.param.Name, test.value, err)
+			}
+
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("Expected %s = %q error to contain %q, but got: %v", test.param.Name, test.value, test.expectedError, err)
+		}
+	}
+}
+
+func TestChangedDatastoreConfigParams(t *testing.T) {
+	oldConfig := map[string]interface{}{
+		"work_mem":          "128",
+		"vacuum_cost_delay": "25",
+		"xmloption":         "content",
+	}
+	newConfig := map[string]interface{}{
+		"work_mem":        "128",
+		"xmloption":       "document",
+		"max_connections": "200",
+	}
+	expected := []string{"max_connections", "vacuum_cost_delay", "xmloption"}
+
+	actual := changedDatastoreConfigParams(oldConfig, newConfig)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v changed parameters, but got: %v", expected, actual)
+	}
+}
+
+func TestCheckDatastoreReplicaCompatibility(t *testing.T) {
+	postgreSQL13 := dbaas.DatastoreType{ID: "pg-13", Engine: "postgresql", Version: "13"}
+	postgreSQL14 := dbaas.DatastoreType{ID: "pg-14", Engine: "postgresql", Version: "14"}
+	mySQL8 := dbaas.DatastoreType{ID: "mysql-8", Engine: "mysql", Version: "8"}
+
+	tableTests := []struct {
+		sourceType    dbaas.DatastoreType
+		replicaType   dbaas.DatastoreType
+		sourceDisk    int
+		replicaDisk   int
+		expectedError string
+	}{
+		{sourceType: postgreSQL13, replicaType: postgreSQL13, sourceDisk: 32, replicaDisk: 32},
+		{sourceType: postgreSQL13, replicaType: postgreSQL13, sourceDisk: 32, replicaDisk: 64},
+		{sourceType: postgreSQL13, replicaType: postgreSQL14, sourceDisk: 32, replicaDisk: 32, expectedError: `replica version 14 doesn't match version 13`},
+		{sourceType: postgreSQL13, replicaType: mySQL8, sourceDisk: 32, replicaDisk: 32, expectedError: `replica engine mysql doesn't match engine postgresql`},
+		{sourceType: postgreSQL13, replicaType: postgreSQL13, sourceDisk: 32, replicaDisk: 16, expectedError: `replica flavor disk 16 GB is smaller than disk 32 GB`},
+	}
+
+	for _, test := range tableTests {
+		err := checkDatastoreReplicaCompatibility(test.sourceType, test.replicaType, test.sourceDisk, test.replicaDisk)
+		if test.expectedError == "" {
+			if err != nil {
+				t.Errorf("Expected replica %s to be compatible with %s, but got: %s", test.replicaType.ID, test.sourceType.ID, err)
+			}
+
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("Expected replica %s error to contain %q, but got: %v", test.replicaType.ID, test.expectedError, err)
+		}
+	}
+}
+
+func TestCheckDatastoreTypeUpgrade(t *testing.T) {
+	postgreSQL14 := dbaas.DatastoreType{ID: "pg-14", Engine: "postgresql", Version: "14"}
+	postgreSQL16 := dbaas.DatastoreType{ID: "pg-16", Engine: "postgresql", Version: "16"}
+	redis6 := dbaas.DatastoreType{ID: "redis-6", Engine: "redis", Version: "6"}
+	redis62 := dbaas.DatastoreType{ID: "redis-6.2", Engine: "redis", Version: "6.2"}
+	redis7 := dbaas.DatastoreType{ID: "redis-7", Engine: "redis", Version: "7"}
+
+	tableTests := []struct {
+		oldType       dbaas.DatastoreType
+		newType       dbaas.DatastoreType
+		expectedError string
+	}{
+		{oldType: postgreSQL14, newType: postgreSQL16},
+		{oldType: redis6, newType: redis62},
+		{oldType: redis62, newType: redis7},
+		{oldType: postgreSQL16, newType: postgreSQL14, expectedError: `version 14 isn't newer than version 16`},
+		{oldType: redis7, newType: redis62, expectedError: `version 6.2 isn't newer than version 7`},
+		{oldType: postgreSQL14, newType: redis7, expectedError: `engine changes from postgresql to redis`},
+	}
+
+	for _, test := range tableTests {
+		err := checkDatastoreTypeUpgrade(test.oldType, test.newType)
+		if test.expectedError == "" {
+			if err != nil {
+				t.Errorf("Expected upgrade from %s to %s to be allowed, but got: %s", test.oldType.ID, test.newType.ID, err)
+			}
+
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("Expected upgrade from %s to %s error to contain %q, but got: %v", test.oldType.ID, test.newType.ID, test.expectedError, err)
+		}
+	}
+}
+
+func TestGenerateDBaaSPassword(t *testing.T) {
+	password, err := generateDBaaSPassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != dbaasGeneratedPasswordLength {
+		t.Errorf("Expected password of %d characters, but got %d", dbaasGeneratedPasswordLength, len(password))
+	}
+	for _, chars := range []string{dbaasGeneratedPasswordLowercase, dbaasGeneratedPasswordUppercase, dbaasGeneratedPasswordDigits} {
+		if !strings.ContainsAny(password, chars) {
+			t.Errorf("Expected password to contain one of %q", chars)
+		}
+	}
+
+	another, err := generateDBaaSPassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if another == password {
+		t.Error("Expected different generated passwords")
+	}
+}
+
+func TestDBaaSPasswordRotationDue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tableTests := []struct {
+		rotatedAt string
+		period    string
+		expected  bool
+	}{
+		{rotatedAt: "2024-02-01T12:00:00Z", period: "", expected: false},
+		{rotatedAt: "2024-01-15T12:00:00Z", period: "720h", expected: true},
+		{rotatedAt: "2024-02-15T12:00:00Z", period: "720h", expected: false},
+		{rotatedAt: "2024-03-01T11:00:00Z", period: "1h", expected: true},
+		{rotatedAt: "", period: "720h", expected: true},
+	}
+
+	for _, test := range tableTests {
+		actual := dbaasPasswordRotationDue(test.rotatedAt, test.period, now)
+		if actual != test.expected {
+			t.Errorf("Expected rotation due %t for password rotated at %q with period %q, but got %t",
+				test.expected, test.rotatedAt, test.period, actual)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/dbaas-go"
+)
+
+// dbaasDatastoreUpgradeOpts represents options for the datastore Upgrade
+// request that dbaas-go doesn't support yet.
+type dbaasDatastoreUpgradeOpts struct {
+	TypeID string `json:"type_id"`
+}
+
+// upgradeDBaaSDatastoreV1 upgrades the datastore to the datastore type of
+// a newer version of the same engine.
+func upgradeDBaaSDatastoreV1(ctx context.Context, client *dbaas.API, datastoreID string, opts dbaasDatastoreUpgradeOpts) error {
+	body := struct {
+		Upgrade dbaasDatastoreUpgradeOpts `json:"upgrade"`
+	}{
+		Upgrade: opts,
+	}
+	uri := fmt.Sprintf("%s/%s/upgrade", dbaas.DatastoresURI, datastoreID)
+
+	return doDBaaSV1Request(ctx, client, http.MethodPost, uri, body, nil)
+}
+
+func updateDatastoreType(ctx context.Context, d *schema.ResourceData, client *dbaas.API) error {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.Get("backup_before_upgrade").(bool) {
+		backupCreateOpts := dbaasBackupCreateOpts{
+			DatastoreID: d.Id(),
+		}
+
+		log.Print(msgCreate(objectBackup, backupCreateOpts))
+		backup, err := createDBaaSBackup(ctx, client, backupCreateOpts)
+		if err != nil {
+			return errUpdatingObject(objectDatastore, d.Id(), errCreatingObject(objectBackup, err))
+		}
+
+		log.Printf("[DEBUG] waiting for backup %s to become 'ACTIVE'", backup.ID)
+		err = waitForDBaaSBackupV1ActiveState(ctx, client, backup.ID, timeout)
+		if err != nil {
+			return errUpdatingObject(objectDatastore, d.Id(), err)
+		}
+	}
+
+	upgradeOpts := dbaasDatastoreUpgradeOpts{
+		TypeID: d.Get("type_id").(string),
+	}
+
+	log.Print(msgUpdate(objectDatastore, d.Id(), upgradeOpts))
+	err := upgradeDBaaSDatastoreV1(ctx, client, d.Id(), upgradeOpts)
+	if err != nil {
+		return errUpdatingObject(objectDatastore, d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", d.Id())
+	err = waitForDBaaSDatastoreV1ActiveState(ctx, client, d.Id(), timeout)
+	if err != nil {
+		return errUpdatingObject(objectDatastore, d.Id(), err)
+	}
+
+	return nil
+}
+
+// dbaasDatastoreV1UpgradeDiff replaces the datastore when type_id changes
+// unless the new datastore type is a newer version of the same engine,
+// which is upgraded in place.
+func dbaasDatastoreV1UpgradeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("type_id") {
+		return nil
+	}
+
+	config, ok := meta.(*Config)
+	if !ok {
+		return d.ForceNew("type_id")
+	}
+	if d.HasChange("project_id") || d.HasChange("region") {
+		// The datastore is replaced anyway.
+		return nil
+	}
+	for _, key := range []string{"project_id", "region", "type_id"} {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] datastore %s must be replaced as %q isn't known to check the upgrade", d.Id(), key)
+
+			return d.ForceNew("type_id")
+		}
+	}
+
+	client, err := newDBaaSClient(config, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+	// Retired datastore types aren't returned by the API, so the datastore is
+	// upgraded in place only when both types are found.
+	oldTypeID, newTypeID := d.GetChange("type_id")
+	oldType, err := client.DatastoreType(ctx, oldTypeID.(string))
+	if err != nil {
+		log.Printf("[DEBUG] datastore %s must be replaced: %s", d.Id(), errGettingObject(objectDatastoreTypes, oldTypeID.(string), err))
+
+		return d.ForceNew("type_id")
+	}
+	newType, err := client.DatastoreType(ctx, newTypeID.(string))
+	if err != nil {
+		log.Printf("[DEBUG] datastore %s must be replaced: %s", d.Id(), errGettingObject(objectDatastoreTypes, newTypeID.(string), err))
+
+		return d.ForceNew("type_id")
+	}
+
+	if err := checkDatastoreTypeUpgrade(oldType, newType); err != nil {
+		log.Printf("[DEBUG] datastore %s must be replaced: %s", d.Id(), err)
+
+		return d.ForceNew("type_id")
+	}
+	log.Printf("[DEBUG] datastore %s is upgraded from %s %s to %s %s in place",
+		d.Id(), oldType.Engine, oldType.Version, newType.Engine, newType.Version)
+
+	return nil
+}
+
+// checkDatastoreTypeUpgrade checks that the datastore can be upgraded in place
+// from one datastore type to another.
+func checkDatastoreTypeUpgrade(oldType, newType dbaas.DatastoreType) error {
+	if newType.Engine != oldType.Engine {
+		return fmt.Errorf("engine changes from %s to %s", oldType.Engine, newType.Engine)
+	}
+
+	cmp, err := compareDatastoreVersions(oldType.Version, newType.Version)
+	if err != nil {
+		return err
+	}
+	if cmp >= 0 {
+		return fmt.Errorf("version %s isn't newer than version %s", newType.Version, oldType.Version)
+	}
+
+	return nil
+}
+
+// compareDatastoreVersions compares dot-separated numeric versions. It returns
+// -1 if a is older than b, 1 if a is newer than b and 0 if they are equal.
+func compareDatastoreVersions(a, b string) (int, error) {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aNumber, bNumber int
+		var err error
+		if i < len(aParts) {
+			if aNumber, err = strconv.Atoi(aParts[i]); err != nil {
+				return 0, fmt.Errorf("can't compare versions %q and %q", a, b)
+			}
+		}
+		if i < len(bParts) {
+			if bNumber, err = strconv.Atoi(bParts[i]); err != nil {
+				return 0, fmt.Errorf("can't compare versions %q and %q", a, b)
+			}
+		}
+
+		switch {
+		case aNumber < bNumber:
+			return -1, nil
+		case aNumber > bNumber:
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
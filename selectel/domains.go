@@ -4,34 +4,25 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/hashicorp/go-retryablehttp"
 	domainsV1 "github.com/selectel/domains-go/pkg/v1"
 )
 
-const (
-	domainsV1DefaultRetryWaitMin = time.Second //nolint:revive
-	domainsV1DefaultRetryWaitMax = 5 * time.Second
-	domainsV1DefaultRetry        = 5
-)
-
 func getDomainsClient(meta interface{}) (*domainsV1.ServiceClient, error) {
 	config := meta.(*Config)
 
-	selvpcClient, err := config.GetSelVPCClient()
+	token, err := config.GetXAuthTokenWithProjectScope("")
 	if err != nil {
-		return nil, fmt.Errorf("can't get selvpc client for domains: %w", err)
+		return nil, fmt.Errorf("can't get token for domains: %w", err)
 	}
 
-	domainsClient := domainsV1.NewDomainsClientV1WithDefaultEndpoint(selvpcClient.GetXAuthToken()).WithOSToken()
+	endpoint, ok := config.endpointOverride(DomainsV1)
+	if !ok {
+		endpoint = defaultDomainsV1Endpoint
+	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.Logger = nil // Ignore retyablehttp client logs
-	retryClient.RetryWaitMin = domainsV1DefaultRetryWaitMin
-	retryClient.RetryWaitMax = domainsV1DefaultRetryWaitMax
-	retryClient.RetryMax = domainsV1DefaultRetry
-	domainsClient.HTTPClient = retryClient.StandardClient()
+	domainsClient := domainsV1.NewDomainsClientV1(token, endpoint).WithOSToken()
+	domainsClient.HTTPClient = config.newHTTPClient("")
 
 	return domainsClient, nil
 }
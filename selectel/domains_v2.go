@@ -19,18 +19,21 @@ var ErrProjectIDNotSetupForDNSV2 = errors.New("env variable SEL_PROJECT_ID or va
 func getDomainsV2Client(d *schema.ResourceData, meta interface{}) (domainsV2.DNSClient[domainsV2.Zone, domainsV2.RRSet], error) {
 	config := meta.(*Config)
 	projectID := d.Get("project_id").(string)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, fmt.Errorf("can't get selvpc client for domains v2: %w", err)
+		return nil, fmt.Errorf("can't get token for domains v2: %w", err)
 	}
 
-	httpClient := &http.Client{}
+	httpClient := config.newHTTPClient(projectID)
 	userAgent := "terraform-provider-selectel"
-	defaultAPIURL := "https://api.selectel.ru/domains/v2"
+	endpoint, ok := config.endpointOverride(DNS)
+	if !ok {
+		endpoint = defaultDNSEndpoint
+	}
 	hdrs := http.Header{}
-	hdrs.Add("X-Auth-Token", selvpcClient.GetXAuthToken())
+	hdrs.Add("X-Auth-Token", token)
 	hdrs.Add("User-Agent", userAgent)
-	domainsClient := domainsV2.NewClient(defaultAPIURL, httpClient, hdrs)
+	domainsClient := domainsV2.NewClient(endpoint, httpClient, hdrs)
 
 	return domainsClient, nil
 }
@@ -0,0 +1,64 @@
+package selectel
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultDNSEndpoint       = "https://api.selectel.ru/domains/v2"
+	defaultDomainsV1Endpoint = "https://api.selectel.ru/domains/v1"
+)
+
+// endpointsServiceTypes maps arguments of the provider "endpoints" block to service types.
+var endpointsServiceTypes = map[string]string{
+	"managed_database":    DBaaS,
+	"managed_kubernetes":  MKS,
+	"container_registry":  CRaaS,
+	"secrets_manager":     SecretsManager,
+	"certificate_manager": CertificateManager,
+	"dns":                 DNS,
+	"domains_v1":          DomainsV1,
+	"compute":             Compute,
+}
+
+func endpointsSchema() map[string]*schema.Schema {
+	endpointsSchema := make(map[string]*schema.Schema, len(endpointsServiceTypes))
+	for argument, serviceType := range endpointsServiceTypes {
+		endpointsSchema[argument] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			Description:  fmt.Sprintf("Custom endpoint of the %s API", serviceType),
+		}
+	}
+
+	return endpointsSchema
+}
+
+func expandEndpoints(rawEndpoints []interface{}) map[string]string {
+	endpoints := make(map[string]string)
+	if len(rawEndpoints) == 0 || rawEndpoints[0] == nil {
+		return endpoints
+	}
+
+	for argument, value := range rawEndpoints[0].(map[string]interface{}) {
+		serviceType, ok := endpointsServiceTypes[argument]
+		if !ok || value.(string) == "" {
+			continue
+		}
+		endpoints[serviceType] = value.(string)
+	}
+
+	return endpoints
+}
+
+// endpointOverride returns the endpoint of the service type from the provider
+// "endpoints" block. Such endpoints are used instead of the catalog ones.
+func (c *Config) endpointOverride(serviceType string) (string, bool) {
+	endpoint, ok := c.Endpoints[serviceType]
+
+	return endpoint, ok
+}
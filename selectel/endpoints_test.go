@@ -0,0 +1,148 @@
+package selectel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEndpoints(t *testing.T) {
+	rawEndpoints := []interface{}{
+		map[string]interface{}{
+			"managed_database":   "https://dbaas.example.com/v1",
+			"managed_kubernetes": "https://mks.example.com/v1",
+			"dns":                "http://localhost:8080/domains/v2",
+			"container_registry": "",
+		},
+	}
+
+	expected := map[string]string{
+		DBaaS: "https://dbaas.example.com/v1",
+		MKS:   "https://mks.example.com/v1",
+		DNS:   "http://localhost:8080/domains/v2",
+	}
+
+	assert.Equal(t, expected, expandEndpoints(rawEndpoints))
+	assert.Empty(t, expandEndpoints(nil))
+	assert.Empty(t, expandEndpoints([]interface{}{nil}))
+}
+
+func TestEndpointsSchemaCoversServiceTypes(t *testing.T) {
+	endpointsSchema := endpointsSchema()
+	for argument := range endpointsServiceTypes {
+		assert.Contains(t, endpointsSchema, argument)
+	}
+
+	validateFunc := endpointsSchema["managed_database"].ValidateFunc
+	_, errs := validateFunc("dbaas.example.com", "managed_database")
+	assert.NotEmpty(t, errs)
+	_, errs = validateFunc("https://dbaas.example.com/v1", "managed_database")
+	assert.Empty(t, errs)
+}
+
+func TestGetClientsWithEndpointOverrides(t *testing.T) {
+	ks := newTestKeystone(t)
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"next_offset":0,"result":[]}`))
+	}))
+	defer service.Close()
+
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"username":    "user",
+		"password":    "password",
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"managed_database":   service.URL + "/dbaas/v1",
+				"managed_kubernetes": service.URL + "/mks/v1",
+				"container_registry": service.URL + "/craas/v1",
+				"dns":                service.URL + "/domains/v2",
+				"domains_v1":         service.URL + "/domains/v1",
+				"compute":            service.URL + "/compute/v2.1",
+			},
+		},
+	})
+
+	// The keystone stub catalog has no service endpoints, so the clients can be
+	// created only with the overridden endpoints.
+	dbaasData := schema.TestResourceDataRaw(t, resourceDBaaSDatastoreV1().Schema, map[string]interface{}{
+		"project_id": "project-id",
+		"region":     "ru-3",
+	})
+	dbaasClient, diagErr := getDBaaSClient(dbaasData, config)
+	require.Nil(t, diagErr)
+	assert.Equal(t, service.URL+"/dbaas/v1", dbaasClient.Endpoint)
+
+	mksData := schema.TestResourceDataRaw(t, resourceMKSClusterV1().Schema, map[string]interface{}{
+		"project_id": "project-id",
+		"region":     "ru-3",
+	})
+	mksClient, diagErr := getMKSClient(mksData, config)
+	require.Nil(t, diagErr)
+	assert.Equal(t, service.URL+"/mks/v1", mksClient.Endpoint)
+
+	craasData := schema.TestResourceDataRaw(t, resourceCRaaSRegistryV1().Schema, map[string]interface{}{
+		"project_id": "project-id",
+	})
+	craasClient, diagErr := getCRaaSClient(craasData, config)
+	require.Nil(t, diagErr)
+	assert.Equal(t, service.URL+"/craas/v1", craasClient.Endpoint)
+
+	computeData := schema.TestResourceDataRaw(t, resourceMKSNodegroupV1().Schema, map[string]interface{}{
+		"project_id": "project-id",
+		"region":     "ru-3",
+	})
+	computeClient, diagErr := getComputeClient(computeData, config)
+	require.Nil(t, diagErr)
+	assert.Equal(t, service.URL+"/compute/v2.1/", computeClient.Endpoint)
+
+	domainsClient, err := getDomainsClient(config)
+	require.NoError(t, err)
+	assert.Equal(t, service.URL+"/domains/v1", domainsClient.Endpoint)
+
+	zoneData := schema.TestResourceDataRaw(t, resourceDomainsZoneV2().Schema, map[string]interface{}{
+		"project_id": "project-id",
+	})
+	domainsV2Client, err := getDomainsV2Client(zoneData, config)
+	require.NoError(t, err)
+	_, err = domainsV2Client.ListZones(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/domains/v2/zones"}, paths)
+}
+
+func TestGetClientsWithoutEndpointOverrides(t *testing.T) {
+	ks := newTestKeystone(t)
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"username":    "user",
+		"password":    "password",
+	})
+
+	dbaasData := schema.TestResourceDataRaw(t, resourceDBaaSDatastoreV1().Schema, map[string]interface{}{
+		"project_id": "project-id",
+		"region":     "ru-3",
+	})
+	_, diagErr := getDBaaSClient(dbaasData, config)
+	require.NotNil(t, diagErr)
+
+	domainsClient, err := getDomainsClient(config)
+	require.NoError(t, err)
+	assert.Equal(t, defaultDomainsV1Endpoint, domainsClient.Endpoint)
+}
@@ -44,6 +44,14 @@ func errParseDomainsDomainRecordV1IDsPair(id string) error {
 	return fmt.Errorf("got error parsing domain/record IDs pair: %s", id)
 }
 
+func errImportIDFormat(id, format string) error {
+	return fmt.Errorf("unable to parse import ID '%s', expected format: %s", id, format)
+}
+
+func errImportIDEnvNotSet(env, format string) error {
+	return fmt.Errorf("%s must be set for the resource import or the import ID must be in %s format", env, format)
+}
+
 func errSearchingProjectRole(projectID string, err error) error {
 	return fmt.Errorf("can't find role for project '%s': %s", projectID, err)
 }
@@ -183,6 +183,27 @@ func TestErrParseDomainsDomainRecordV1IDsPair(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestErrImportIDFormat(t *testing.T) {
+	id := "cluster/nodegroup/extra"
+	format := "<cluster_id>/<nodegroup_id>"
+
+	expected := errors.New("unable to parse import ID 'cluster/nodegroup/extra', expected format: <cluster_id>/<nodegroup_id>")
+
+	actual := errImportIDFormat(id, format)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestErrImportIDEnvNotSet(t *testing.T) {
+	format := "<project_id>/<region>/<cluster_id>"
+
+	expected := errors.New("SEL_REGION must be set for the resource import or the import ID must be in <project_id>/<region>/<cluster_id> format")
+
+	actual := errImportIDEnvNotSet("SEL_REGION", format)
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestErrGettingObjects(t *testing.T) {
 	object := "datastore-types"
 	err := errors.New(testErrString)
@@ -0,0 +1,89 @@
+package selectel
+
+import (
+	"fmt"
+	"strings"
+)
+
+const importIDSeparator = "/"
+
+// parseImportIDWithProject parses an import ID of a project-scoped resource.
+// The ID can be in the <id_parts> format, then the project is taken from
+// SEL_PROJECT_ID, or in the <project_id>/<id_parts> format.
+// It returns the project ID and the native resource ID.
+func parseImportIDWithProject(config *Config, id string, idParts ...string) (string, string, error) {
+	format := importIDFormat(append([]string{"project_id"}, idParts...))
+
+	parts := strings.Split(id, importIDSeparator)
+	var projectID string
+	switch len(parts) {
+	case len(idParts):
+		if config.ProjectID == "" {
+			return "", "", errImportIDEnvNotSet("SEL_PROJECT_ID", format)
+		}
+		projectID = config.ProjectID
+	case len(idParts) + 1:
+		projectID, parts = parts[0], parts[1:]
+	default:
+		return "", "", errImportIDFormat(id, format)
+	}
+
+	if projectID == "" || hasEmptyImportIDParts(parts) {
+		return "", "", errImportIDFormat(id, format)
+	}
+
+	return projectID, strings.Join(parts, importIDSeparator), nil
+}
+
+// parseImportIDWithProjectRegion parses an import ID of a resource that is
+// scoped to a project and a region. The ID can be in the <id_parts> format,
+// then the project and the region are taken from SEL_PROJECT_ID and SEL_REGION,
+// or in the <project_id>/<region>/<id_parts> format.
+// It returns the project ID, the region and the native resource ID.
+func parseImportIDWithProjectRegion(config *Config, id string, idParts ...string) (string, string, string, error) {
+	format := importIDFormat(append([]string{"project_id", "region"}, idParts...))
+
+	parts := strings.Split(id, importIDSeparator)
+	var projectID, region string
+	switch len(parts) {
+	case len(idParts):
+		if config.ProjectID == "" {
+			return "", "", "", errImportIDEnvNotSet("SEL_PROJECT_ID", format)
+		}
+		if config.Region == "" {
+			return "", "", "", errImportIDEnvNotSet("SEL_REGION", format)
+		}
+		projectID, region = config.ProjectID, config.Region
+	case len(idParts) + 2:
+		projectID, region, parts = parts[0], parts[1], parts[2:]
+	default:
+		return "", "", "", errImportIDFormat(id, format)
+	}
+
+	if projectID == "" || region == "" || hasEmptyImportIDParts(parts) {
+		return "", "", "", errImportIDFormat(id, format)
+	}
+
+	return projectID, region, strings.Join(parts, importIDSeparator), nil
+}
+
+// importIDFormat builds a human-readable import ID format from its parts,
+// for example: <project_id>/<region>/<cluster_id>.
+func importIDFormat(parts []string) string {
+	formatted := make([]string, len(parts))
+	for i, part := range parts {
+		formatted[i] = fmt.Sprintf("<%s>", part)
+	}
+
+	return strings.Join(formatted, importIDSeparator)
+}
+
+func hasEmptyImportIDParts(parts []string) bool {
+	for _, part := range parts {
+		if part == "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+package selectel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportIDWithProject(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            *Config
+		id                string
+		idParts           []string
+		expectedProjectID string
+		expectedID        string
+		expectedErr       string
+	}{
+		{
+			name:              "short ID with project from config",
+			config:            &Config{ProjectID: "env-project"},
+			id:                "registry",
+			idParts:           []string{"registry_id"},
+			expectedProjectID: "env-project",
+			expectedID:        "registry",
+		},
+		{
+			name:              "composite ID overrides config",
+			config:            &Config{ProjectID: "env-project"},
+			id:                "project/registry",
+			idParts:           []string{"registry_id"},
+			expectedProjectID: "project",
+			expectedID:        "registry",
+		},
+		{
+			name:              "composite ID without config",
+			config:            &Config{},
+			id:                "project/zone/rrset/A",
+			idParts:           []string{"zone_name", "rrset_name", "rrset_type"},
+			expectedProjectID: "project",
+			expectedID:        "zone/rrset/A",
+		},
+		{
+			name:        "short ID without project",
+			config:      &Config{},
+			id:          "registry",
+			idParts:     []string{"registry_id"},
+			expectedErr: "SEL_PROJECT_ID must be set for the resource import or the import ID must be in <project_id>/<registry_id> format",
+		},
+		{
+			name:        "too many parts",
+			config:      &Config{ProjectID: "env-project"},
+			id:          "project/registry/extra",
+			idParts:     []string{"registry_id"},
+			expectedErr: "unable to parse import ID 'project/registry/extra', expected format: <project_id>/<registry_id>",
+		},
+		{
+			name:        "empty part",
+			config:      &Config{ProjectID: "env-project"},
+			id:          "/registry",
+			idParts:     []string{"registry_id"},
+			expectedErr: "unable to parse import ID '/registry', expected format: <project_id>/<registry_id>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID, id, err := parseImportIDWithProject(tc.config, tc.id, tc.idParts...)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedProjectID, projectID)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func TestParseImportIDWithProjectRegion(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            *Config
+		id                string
+		idParts           []string
+		expectedProjectID string
+		expectedRegion    string
+		expectedID        string
+		expectedErr       string
+	}{
+		{
+			name:              "short ID with project and region from config",
+			config:            &Config{ProjectID: "env-project", Region: "ru-3"},
+			id:                "cluster/nodegroup",
+			idParts:           []string{"cluster_id", "nodegroup_id"},
+			expectedProjectID: "env-project",
+			expectedRegion:    "ru-3",
+			expectedID:        "cluster/nodegroup",
+		},
+		{
+			name:              "composite ID overrides config",
+			config:            &Config{ProjectID: "env-project", Region: "ru-3"},
+			id:                "project/ru-7/cluster/nodegroup",
+			idParts:           []string{"cluster_id", "nodegroup_id"},
+			expectedProjectID: "project",
+			expectedRegion:    "ru-7",
+			expectedID:        "cluster/nodegroup",
+		},
+		{
+			name:              "composite ID without config",
+			config:            &Config{},
+			id:                "project/ru-1/datastore",
+			idParts:           []string{"datastore_id"},
+			expectedProjectID: "project",
+			expectedRegion:    "ru-1",
+			expectedID:        "datastore",
+		},
+		{
+			name:        "short ID without project",
+			config:      &Config{Region: "ru-3"},
+			id:          "datastore",
+			idParts:     []string{"datastore_id"},
+			expectedErr: "SEL_PROJECT_ID must be set for the resource import or the import ID must be in <project_id>/<region>/<datastore_id> format",
+		},
+		{
+			name:        "short ID without region",
+			config:      &Config{ProjectID: "env-project"},
+			id:          "cluster/nodegroup",
+			idParts:     []string{"cluster_id", "nodegroup_id"},
+			expectedErr: "SEL_REGION must be set for the resource import or the import ID must be in <project_id>/<region>/<cluster_id>/<nodegroup_id> format",
+		},
+		{
+			name:        "project without region",
+			config:      &Config{ProjectID: "env-project", Region: "ru-3"},
+			id:          "project/cluster/nodegroup",
+			idParts:     []string{"cluster_id", "nodegroup_id"},
+			expectedErr: "unable to parse import ID 'project/cluster/nodegroup', expected format: <project_id>/<region>/<cluster_id>/<nodegroup_id>",
+		},
+		{
+			name:        "empty region",
+			config:      &Config{},
+			id:          "project//datastore",
+			idParts:     []string{"datastore_id"},
+			expectedErr: "unable to parse import ID 'project//datastore', expected format: <project_id>/<region>/<datastore_id>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID, region, id, err := parseImportIDWithProjectRegion(tc.config, tc.id, tc.idParts...)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedProjectID, projectID)
+			assert.Equal(t, tc.expectedRegion, region)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
@@ -20,9 +20,9 @@ func TestAccDomainsRRSetV2ImportBasic(t *testing.T) {
 	testRRSetContent := fmt.Sprintf("\"%[1]s\"", acctest.RandString(16))
 	fullResourceName := fmt.Sprintf("selectel_domains_rrset_v2.%[1]s", resourceRRSetName)
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheckWithProjectID(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2RRSetDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheckWithProjectID(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2RRSetDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRRSetV2WithZoneWithoutProjectBasic(
@@ -20,18 +20,17 @@ func TestAccSecretsManagerCertificateV1ImportBasic(t *testing.T) {
 	certificateName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckSecretsManagerV1CertificateDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSecretsManagerV1CertificateDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSecretsManagerCertificateV1WithoutProjectBasic(certificateName, projectID),
 			},
 			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"certificates", "private_key"},
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
 			},
 		},
 	})
@@ -23,19 +23,18 @@ func TestAccSecretsManagerSecretV1ImportBasic(t *testing.T) {
 	secretDescription := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckSecretsManagerV1SecretDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSecretsManagerV1SecretDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSecretsManagerSecretV1WithoutProjectBasic(secretKey, secretDescription, secretValue, projectID),
 				Check:  testAccCheckSelectelImportEnv(resourceName),
 			},
 			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"value"},
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
 			},
 		},
 	})
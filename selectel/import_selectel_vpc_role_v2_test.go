@@ -14,9 +14,9 @@ func TestAccVPCV2RoleImportBasic(t *testing.T) {
 	userPassword := acctest.RandString(8)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2RoleDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2RoleDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2RoleBasic(projectName, userName, userPassword),
@@ -0,0 +1,50 @@
+package fakeselectel
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// handleCompute serves {region}/flavors/{id} of the OpenStack Compute API.
+// Flavors are created by the fake MKS API for nodegroups with custom CPUs
+// and RAM.
+func (s *Server) handleCompute(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 3 || parts[1] != "flavors" {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+
+		return
+	}
+
+	flavor, ok := s.collection("compute/" + parts[0] + "/flavors").get(parts[2])
+	if !ok {
+		writeJSON(w, http.StatusNotFound, object{
+			"itemNotFound": object{"code": http.StatusNotFound, "message": fmt.Sprintf("Flavor %s could not be found.", parts[2])},
+		})
+
+		return
+	}
+	writeJSON(w, http.StatusOK, object{"flavor": flavor})
+}
+
+// newComputeFlavor creates a private flavor in the region.
+func (s *Server) newComputeFlavor(region string, vcpus, ram, disk int) object {
+	id := s.newID()
+	flavor := object{
+		"id":                         id,
+		"name":                       fmt.Sprintf("mks-%d-%d-%d", vcpus, ram, disk),
+		"vcpus":                      vcpus,
+		"ram":                        ram,
+		"disk":                       disk,
+		"swap":                       "",
+		"rxtx_factor":                1.0,
+		"os-flavor-access:is_public": false,
+	}
+	s.collection("compute/"+region+"/flavors").put(id, flavor)
+
+	return flavor
+}
@@ -0,0 +1,140 @@
+package fakeselectel
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// craasTokenTTLs are token lifetimes accepted by the fake CRaaS API.
+var craasTokenTTLs = map[string]time.Duration{
+	"12h": 12 * time.Hour,
+	"1y":  365 * 24 * time.Hour,
+}
+
+// handleCRaaS serves api/v1/token[/{token}[/refresh]] and
+// api/v1/registries[/{id}] of the CRaaS API. Registries are scoped to the
+// project of the request token.
+func (s *Server) handleCRaaS(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 3 || parts[0] != "api" || parts[1] != "v1" {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	kind, rest := parts[2], parts[3:]
+
+	switch kind {
+	case "token":
+		s.handleCRaaSToken(w, r, rest)
+	case "registries":
+		s.handleCRaaSRegistries(w, r, rest)
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+func (s *Server) handleCRaaSToken(w http.ResponseWriter, r *http.Request, rest []string) {
+	tokens := s.collection("craas/tokens")
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		ttl, ok := craasTokenTTLs[r.URL.Query().Get("ttl")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid token ttl %q", r.URL.Query().Get("ttl")))
+
+			return
+		}
+		token := craasToken(fmt.Sprintf("craas-token-%d", s.newIntID()), ttl)
+		tokens.put(token.string("token"), token)
+		writeJSON(w, http.StatusOK, token)
+	case len(rest) == 0:
+		writeMethodNotAllowed(w, r)
+	default:
+		token, ok := tokens.get(rest[0])
+		if !ok {
+			writeNotFound(w, "token", rest[0])
+
+			return
+		}
+		switch {
+		case len(rest) == 1 && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, token)
+		case len(rest) == 1 && r.Method == http.MethodDelete:
+			tokens.delete(rest[0])
+			w.WriteHeader(http.StatusNoContent)
+		case len(rest) == 2 && rest[1] == "refresh" && r.Method == http.MethodPost:
+			ttl := time.Duration(toInt(token["expireIn"])) * time.Second
+			token = craasToken(rest[0], ttl)
+			tokens.put(rest[0], token)
+			writeJSON(w, http.StatusOK, token)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	}
+}
+
+// craasToken returns a token that expires after the given duration.
+func craasToken(value string, ttl time.Duration) object {
+	return object{
+		"token":    value,
+		"expireAt": time.Now().Add(ttl).Unix(),
+		"expireIn": int64(ttl.Seconds()),
+	}
+}
+
+func (s *Server) handleCRaaSRegistries(w http.ResponseWriter, r *http.Request, rest []string) {
+	registries := s.collection("craas/" + s.tokenProjectID(r) + "/registries")
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, registries.list(nil))
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		var body struct {
+			Name string `json:"name"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.Name == "" {
+			writeError(w, http.StatusBadRequest, "registry name is empty")
+
+			return
+		}
+		if len(registries.list(fieldEquals("name", body.Name))) > 0 {
+			writeError(w, http.StatusConflict, fmt.Sprintf("registry %s already exists", body.Name))
+
+			return
+		}
+		registry := object{
+			"id":        s.newID(),
+			"name":      body.Name,
+			"createdAt": timestamp(),
+			"status":    "ACTIVE",
+			"size":      0,
+			"sizeLimit": 0,
+			"used":      0,
+		}
+		registries.put(registry.string("id"), registry)
+		writeJSON(w, http.StatusCreated, registry)
+	case len(rest) == 0:
+		writeMethodNotAllowed(w, r)
+	case len(rest) == 1:
+		registry, ok := registries.get(rest[0])
+		if !ok {
+			writeNotFound(w, "registry", rest[0])
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, registry)
+		case http.MethodDelete:
+			registries.delete(rest[0])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
@@ -0,0 +1,562 @@
+package fakeselectel
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// dbaasObjectKeys maps stateful collections of the DBaaS API to the JSON key
+// of a single object in requests and responses.
+var dbaasObjectKeys = map[string]string{
+	"datastores":                "datastore",
+	"databases":                 "database",
+	"users":                     "user",
+	"grants":                    "grant",
+	"extensions":                "extension",
+	"acls":                      "acl",
+	"topics":                    "topic",
+	"logical-replication-slots": "logical-replication-slot",
+	"prometheus-metrics-tokens": "prometheus-metrics-token",
+	"backups":                   "backup",
+}
+
+// dbaasUnwrappedKinds are collections that return a single object without
+// the JSON key on get and update.
+var dbaasUnwrappedKinds = map[string]bool{
+	"prometheus-metrics-tokens": true,
+}
+
+// dbaasReferenceKeys maps read-only collections of the DBaaS API to the JSON
+// key of a single object.
+var dbaasReferenceKeys = map[string]string{
+	"datastore-types":          "datastore-type",
+	"flavors":                  "flavor",
+	"configuration-parameters": "configuration-parameter",
+	"available-extensions":     "available-extension",
+}
+
+// dbaasDatastoreTypes are engines and versions served by the fake DBaaS API.
+var dbaasDatastoreTypes = []struct {
+	engine   string
+	versions []string
+}{
+	{"postgresql", []string{"12", "13", "14", "15", "16"}},
+	{"mysql", []string{"8"}},
+	{"mysql_native", []string{"8"}},
+	{"redis", []string{"6", "7"}},
+	{"kafka", []string{"3.5"}},
+}
+
+// dbaasFlavors are flavors of the fake DBaaS API. Redis flavors are bound only
+// to the redis datastore types, all others are bound to the rest of the types.
+var dbaasFlavors = []struct {
+	vcpus, ram, disk int
+	redis            bool
+}{
+	{2, 4096, 32, false},
+	{2, 8192, 32, false},
+	{2, 8192, 64, false},
+	{4, 16384, 64, false},
+	{2, 4096, 0, true},
+	{2, 8192, 0, true},
+}
+
+// dbaasConfigurationParameters are configuration parameters of the fake DBaaS
+// API by engines. Parameters are changeable and don't require a restart unless
+// set otherwise.
+var dbaasConfigurationParameters = map[string][]object{
+	"postgresql": {
+		{"name": "work_mem", "type": "int", "unit": "kB", "min": 64, "max": 2147483647, "default_value": 4096},
+		{"name": "vacuum_cost_delay", "type": "float", "unit": "ms", "min": 0, "max": 100, "default_value": 0},
+		{"name": "xmloption", "type": "str", "choices": []string{"content", "document"}, "default_value": "content"},
+		{"name": "transform_null_equals", "type": "boolean", "default_value": false},
+		{"name": "max_connections", "type": "int", "min": 10, "max": 10000, "default_value": 100, "is_restart_required": true},
+	},
+	"mysql": {
+		{"name": "innodb_checksum_algorithm", "type": "str", "choices": []string{"crc32", "strict_crc32", "innodb", "strict_innodb"}, "default_value": "crc32"},
+		{"name": "auto_increment_increment", "type": "int", "min": 1, "max": 65535, "default_value": 1},
+		{"name": "auto_increment_offset", "type": "int", "min": 1, "max": 65535, "default_value": 1},
+		{"name": "autocommit", "type": "boolean", "default_value": true},
+	},
+	"mysql_native": {
+		{"name": "innodb_checksum_algorithm", "type": "str", "choices": []string{"crc32", "strict_crc32", "innodb", "strict_innodb"}, "default_value": "crc32"},
+		{"name": "auto_increment_increment", "type": "int", "min": 1, "max": 65535, "default_value": 1},
+		{"name": "auto_increment_offset", "type": "int", "min": 1, "max": 65535, "default_value": 1},
+		{"name": "autocommit", "type": "boolean", "default_value": true},
+	},
+	"redis": {
+		{"name": "maxmemory-policy", "type": "str", "choices": []string{"noeviction", "volatile-lru", "allkeys-lru"}, "default_value": "noeviction"},
+	},
+	"kafka": {
+		{"name": "log.retention.ms", "type": "int", "unit": "ms", "min": -1, "max": 9223372036854775807, "default_value": 604800000},
+		{"name": "log.retention.bytes", "type": "int", "unit": "B", "min": -1, "max": 9223372036854775807, "default_value": -1},
+	},
+}
+
+// dbaasBackupSize is the size in bytes of every backup of the fake DBaaS API.
+const dbaasBackupSize = 16 << 20
+
+// dbaasAvailableExtensions are PostgreSQL extensions of the fake DBaaS API.
+var dbaasAvailableExtensions = []string{"hstore", "pg_trgm", "postgis", "uuid-ossp"}
+
+// seedDBaaS fills the read-only collections of the DBaaS API.
+func (s *Server) seedDBaaS() {
+	var postgreSQLTypeIDs, redisTypeIDs, otherTypeIDs []string
+	for _, datastoreType := range dbaasDatastoreTypes {
+		for _, version := range datastoreType.versions {
+			id := s.newID()
+			s.collection("datastore-types").put(id, object{
+				"id":      id,
+				"engine":  datastoreType.engine,
+				"version": version,
+			})
+			switch datastoreType.engine {
+			case "redis":
+				redisTypeIDs = append(redisTypeIDs, id)
+			case "postgresql":
+				postgreSQLTypeIDs = append(postgreSQLTypeIDs, id)
+				otherTypeIDs = append(otherTypeIDs, id)
+			default:
+				otherTypeIDs = append(otherTypeIDs, id)
+			}
+			for _, options := range dbaasConfigurationParameters[datastoreType.engine] {
+				parameterID := s.newID()
+				parameter := object{"is_restart_required": false, "is_changeable": true}
+				parameter.merge(options.clone())
+				parameter.merge(map[string]interface{}{
+					"id":                parameterID,
+					"datastore_type_id": id,
+				})
+				s.collection("configuration-parameters").put(parameterID, parameter)
+			}
+		}
+	}
+
+	for _, flavor := range dbaasFlavors {
+		id := s.newID()
+		typeIDs, name := otherTypeIDs, fmt.Sprintf("%d-%d-%d", flavor.vcpus, flavor.ram, flavor.disk)
+		if flavor.redis {
+			typeIDs, name = redisTypeIDs, fmt.Sprintf("redis-%d-%d", flavor.vcpus, flavor.ram)
+		}
+		s.collection("flavors").put(id, object{
+			"id":                 id,
+			"name":               name,
+			"description":        name,
+			"fl_size":            "standard",
+			"vcpus":              flavor.vcpus,
+			"ram":                flavor.ram,
+			"disk":               flavor.disk,
+			"datastore_type_ids": typeIDs,
+		})
+	}
+
+	for _, name := range dbaasAvailableExtensions {
+		id := s.newID()
+		s.collection("available-extensions").put(id, object{
+			"id":                 id,
+			"name":               name,
+			"datastore_type_ids": postgreSQLTypeIDs,
+			"dependency_ids":     []string{},
+		})
+	}
+}
+
+// handleDBaaS serves {region}/{collection}[/{id}[/{action}]] of the DBaaS API.
+func (s *Server) handleDBaaS(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	kind, rest := parts[1], parts[2:]
+
+	if key, ok := dbaasReferenceKeys[kind]; ok {
+		s.handleDBaaSReference(w, r, kind, key, rest)
+
+		return
+	}
+	if kind == "floating-ips" && len(rest) == 0 {
+		s.handleDBaaSFloatingIPs(w, r, parts[0])
+
+		return
+	}
+	key, ok := dbaasObjectKeys[kind]
+	if !ok {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	objects := s.collection("dbaas/" + parts[0] + "/" + kind)
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		items := objects.list(func(obj object) bool {
+			for param := range r.URL.Query() {
+				if value, ok := obj[param]; ok && fmt.Sprint(value) != r.URL.Query().Get(param) {
+					return false
+				}
+			}
+
+			return true
+		})
+		writeJSON(w, http.StatusOK, object{kind: items})
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		var body map[string]object
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if !s.checkDBaaSDatastoreReference(w, parts[0], kind, body[key]) {
+			return
+		}
+		obj := s.newDBaaSObject(r, kind, body[key])
+		if obj == nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s", key))
+
+			return
+		}
+		objects.put(obj.string("id"), obj)
+		writeJSON(w, http.StatusOK, object{key: obj})
+	case len(rest) == 1:
+		obj, ok := objects.get(rest[0])
+		if !ok {
+			writeNotFound(w, key, rest[0])
+
+			return
+		}
+		view := object{key: obj}
+		if dbaasUnwrappedKinds[kind] {
+			view = obj
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, view)
+		case http.MethodPut:
+			var body map[string]object
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			fields := body[key]
+			// Passwords are write-only.
+			delete(fields, "password")
+			obj.merge(fields)
+			obj["updated_at"] = timestamp()
+			writeJSON(w, http.StatusOK, view)
+		case http.MethodDelete:
+			objects.delete(rest[0])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	case len(rest) == 2 && kind == "datastores":
+		datastore, ok := objects.get(rest[0])
+		if !ok {
+			writeNotFound(w, key, rest[0])
+
+			return
+		}
+		s.handleDBaaSDatastoreAction(w, r, datastore, rest[1])
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+// checkDBaaSDatastoreReference writes an error and returns false if the new
+// object refers to a datastore that doesn't exist. Backups refer to a datastore
+// of their region, replicas can refer to a datastore of another one.
+func (s *Server) checkDBaaSDatastoreReference(w http.ResponseWriter, region, kind string, opts object) bool {
+	var datastoreID string
+	switch kind {
+	case "backups":
+		datastoreID = opts.string("datastore_id")
+	case "datastores":
+		replication, ok := asObject(opts["replication"])
+		if !ok {
+			return true
+		}
+		datastoreID = replication.string("datastore_id")
+		if replicationRegion := replication.string("region"); replicationRegion != "" {
+			region = replicationRegion
+		}
+	default:
+		return true
+	}
+	if _, ok := s.collection("dbaas/" + region + "/datastores").get(datastoreID); !ok {
+		writeNotFound(w, "datastore", datastoreID)
+
+		return false
+	}
+
+	return true
+}
+
+// handleDBaaSReference serves read-only collections of the DBaaS API.
+func (s *Server) handleDBaaSReference(w http.ResponseWriter, r *http.Request, kind, key string, rest []string) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+
+		return
+	}
+	switch len(rest) {
+	case 0:
+		writeJSON(w, http.StatusOK, object{kind: s.collection(kind).list(nil)})
+	case 1:
+		obj, ok := s.collection(kind).get(rest[0])
+		if !ok {
+			writeNotFound(w, key, rest[0])
+
+			return
+		}
+		writeJSON(w, http.StatusOK, object{key: obj})
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+// newDBaaSObject returns a new object of the collection built from the
+// create options or nil if the options are invalid.
+func (s *Server) newDBaaSObject(r *http.Request, kind string, opts object) object {
+	if opts == nil {
+		return nil
+	}
+	obj := opts.clone()
+	obj.merge(map[string]interface{}{
+		"id":         s.newID(),
+		"project_id": s.tokenProjectID(r),
+		"status":     "ACTIVE",
+		"created_at": timestamp(),
+		"updated_at": timestamp(),
+	})
+	delete(obj, "password")
+
+	switch kind {
+	case "datastores":
+		return s.newDBaaSDatastore(obj)
+	case "databases":
+		for _, locale := range []string{"lc_collate", "lc_ctype"} {
+			if obj.string(locale) == "" {
+				obj[locale] = "C"
+			}
+		}
+	case "prometheus-metrics-tokens":
+		obj["value"] = fmt.Sprintf("%032x", s.newIntID())
+	case "backups":
+		obj["type"] = "manual"
+		obj["size"] = dbaasBackupSize
+	}
+
+	return obj
+}
+
+// newDBaaSDatastore completes the datastore built from the create options.
+func (s *Server) newDBaaSDatastore(datastore object) object {
+	if !s.resolveDBaaSFlavor(datastore) {
+		return nil
+	}
+	id := datastore.string("id")
+	datastore.merge(map[string]interface{}{
+		"enabled":        true,
+		"allow_restore":  true,
+		"is_maintenance": false,
+		"is_protected":   false,
+		"firewall":       []object{},
+		"connection": map[string]string{
+			"master": fmt.Sprintf("master.%s.c.dbaas.selcloud.ru", id),
+			"MASTER": fmt.Sprintf("master.%s.c.dbaas.selcloud.ru", id),
+		},
+	})
+	if _, ok := datastore["config"]; !ok {
+		datastore["config"] = object{}
+	}
+	if _, ok := datastore["pooler"]; !ok {
+		datastore["pooler"] = object{}
+	}
+	if _, ok := datastore["backup_retention_days"]; !ok {
+		datastore["backup_retention_days"] = 7
+	}
+	delete(datastore, "redis_password")
+	delete(datastore, "restore")
+
+	floatingIPs, ok := asObject(datastore["floating_ips"])
+	delete(datastore, "floating_ips")
+	s.resizeDBaaSDatastore(datastore, toInt(datastore["node_count"]))
+	if ok {
+		masters, replicas := toInt(floatingIPs["master"]), toInt(floatingIPs["replica"])
+		for _, instance := range datastore["instances"].([]object) {
+			switch {
+			case instance.string("role") == "MASTER" && masters > 0:
+				masters--
+			case instance.string("role") == "REPLICA" && replicas > 0:
+				replicas--
+			default:
+				continue
+			}
+			instance["floating_ip"] = s.newIP()
+		}
+	}
+
+	return datastore
+}
+
+// resolveDBaaSFlavor sets both the flavor and the flavor_id fields of the
+// datastore from the one that is provided.
+func (s *Server) resolveDBaaSFlavor(datastore object) bool {
+	if flavor, ok := asObject(datastore["flavor"]); ok {
+		vcpus, ram, disk := toInt(flavor["vcpus"]), toInt(flavor["ram"]), toInt(flavor["disk"])
+		datastore["flavor"] = object{"vcpus": vcpus, "ram": ram, "disk": disk}
+		for _, existing := range s.collection("flavors").list(nil) {
+			if toInt(existing["vcpus"]) == vcpus && toInt(existing["ram"]) == ram && toInt(existing["disk"]) == disk {
+				datastore["flavor_id"] = existing["id"]
+
+				return true
+			}
+		}
+		datastore["flavor_id"] = s.newID()
+
+		return true
+	}
+
+	flavor, ok := s.collection("flavors").get(datastore.string("flavor_id"))
+	if !ok {
+		return false
+	}
+	datastore["flavor"] = object{"vcpus": flavor["vcpus"], "ram": flavor["ram"], "disk": flavor["disk"]}
+
+	return true
+}
+
+// resizeDBaaSDatastore sets the number of datastore instances keeping the
+// existing ones. The first instance is always the master.
+func (s *Server) resizeDBaaSDatastore(datastore object, nodeCount int) {
+	instances, _ := datastore["instances"].([]object)
+	if len(instances) > nodeCount {
+		instances = instances[:nodeCount]
+	}
+	for len(instances) < nodeCount {
+		role := "REPLICA"
+		if len(instances) == 0 {
+			role = "MASTER"
+		}
+		id := s.newID()
+		instances = append(instances, object{
+			"id":          id,
+			"ip":          fmt.Sprintf("192.168.0.%d", len(instances)+2),
+			"floating_ip": "",
+			"role":        role,
+			"status":      "ACTIVE",
+			"hostname":    fmt.Sprintf("%s.c.dbaas.selcloud.ru", id),
+		})
+	}
+	datastore["instances"] = instances
+	datastore["node_count"] = nodeCount
+}
+
+// handleDBaaSDatastoreAction serves the datastore actions that change its
+// parameters.
+func (s *Server) handleDBaaSDatastoreAction(w http.ResponseWriter, r *http.Request, datastore object, action string) {
+	// The promote action has no body.
+	var body map[string]interface{}
+	if r.ContentLength != 0 && !decodeBody(w, r, &body) {
+		return
+	}
+	opts, _ := asObject(body[action])
+
+	switch {
+	case action == "resize" && r.Method == http.MethodPost:
+		if flavor, ok := asObject(opts["flavor"]); ok {
+			datastore["flavor"] = flavor
+		} else if flavorID := opts.string("flavor_id"); flavorID != "" {
+			datastore["flavor"] = nil
+			datastore["flavor_id"] = flavorID
+		}
+		if !s.resolveDBaaSFlavor(datastore) {
+			writeError(w, http.StatusBadRequest, "invalid flavor")
+
+			return
+		}
+		if nodeCount := toInt(opts["node_count"]); nodeCount > 0 {
+			s.resizeDBaaSDatastore(datastore, nodeCount)
+		}
+	case action == "pooler" && r.Method == http.MethodPut:
+		datastore["pooler"] = opts
+	case action == "firewall" && r.Method == http.MethodPut:
+		ips, _ := opts["ips"].([]interface{})
+		firewall := make([]object, 0, len(ips))
+		for _, ip := range ips {
+			firewall = append(firewall, object{"ip": ip})
+		}
+		datastore["firewall"] = firewall
+	case action == "config" && r.Method == http.MethodPut:
+		config, ok := asObject(datastore["config"])
+		if !ok {
+			config = object{}
+		}
+		for key, value := range opts {
+			if value == nil {
+				delete(config, key)
+
+				continue
+			}
+			config[key] = value
+		}
+		datastore["config"] = config
+	case action == "password" && r.Method == http.MethodPut:
+	case action == "upgrade" && r.Method == http.MethodPost:
+		typeID := opts.string("type_id")
+		if _, ok := s.collection("datastore-types").get(typeID); !ok {
+			writeError(w, http.StatusBadRequest, "invalid datastore type")
+
+			return
+		}
+		datastore["type_id"] = typeID
+	case action == "promote" && r.Method == http.MethodPost:
+		if _, ok := datastore["replication"]; !ok {
+			writeError(w, http.StatusBadRequest, "datastore is not a replica")
+
+			return
+		}
+		delete(datastore, "replication")
+	case action == "backups" && r.Method == http.MethodPut:
+		datastore["backup_retention_days"] = opts["backup_retention_days"]
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	datastore["updated_at"] = timestamp()
+	writeJSON(w, http.StatusOK, object{"datastore": datastore})
+}
+
+// handleDBaaSFloatingIPs attaches and detaches floating IPs of datastore instances.
+func (s *Server) handleDBaaSFloatingIPs(w http.ResponseWriter, r *http.Request, region string) {
+	var body struct {
+		FloatingIP struct {
+			InstanceID string `json:"instance_id"`
+		} `json:"floating_ip"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	for _, datastore := range s.collection("dbaas/" + region + "/datastores").list(nil) {
+		instances, _ := datastore["instances"].([]object)
+		for _, instance := range instances {
+			if instance.string("id") != body.FloatingIP.InstanceID {
+				continue
+			}
+			switch r.Method {
+			case http.MethodPost:
+				instance["floating_ip"] = s.newIP()
+			case http.MethodDelete:
+				instance["floating_ip"] = ""
+			default:
+				writeMethodNotAllowed(w, r)
+
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+	}
+	writeNotFound(w, "instance", body.FloatingIP.InstanceID)
+}
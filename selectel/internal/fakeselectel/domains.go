@@ -0,0 +1,386 @@
+package fakeselectel
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dnsListLimit is the default page size of the Domains v2 list requests.
+const dnsListLimit = 100
+
+// handleDNS serves zones[/{id}[/state]] and zones/{id}/rrset[/{id}] of the
+// Domains v2 API. Zones are scoped to the project of the request token.
+func (s *Server) handleDNS(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] != "zones" {
+		writeDNSError(w, http.StatusNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+
+		return
+	}
+	zones := s.collection("domains/v2/zones")
+	projectID := s.tokenProjectID(r)
+
+	if len(parts) == 1 {
+		s.handleDNSZones(w, r, zones, projectID)
+
+		return
+	}
+
+	zone, ok := zones.get(parts[1])
+	if !ok || zone.string("project_id") != dnsProjectID(projectID) {
+		writeDNSError(w, http.StatusNotFound, fmt.Sprintf("zone %s is not found", parts[1]))
+
+		return
+	}
+	switch {
+	case len(parts) == 2:
+		s.handleDNSZone(w, r, zones, zone)
+	case len(parts) == 3 && parts[2] == "state" && r.Method == http.MethodPatch:
+		var body struct {
+			Disabled bool `json:"disabled"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		zone["disabled"] = body.Disabled
+		zone["updated_at"] = timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	case parts[2] == "rrset":
+		s.handleDNSRRSets(w, r, zone, parts[3:])
+	default:
+		writeDNSError(w, http.StatusNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+	}
+}
+
+func (s *Server) handleDNSZones(w http.ResponseWriter, r *http.Request, zones *collection, projectID string) {
+	switch r.Method {
+	case http.MethodGet:
+		filter := r.URL.Query().Get("filter")
+		items := zones.list(func(zone object) bool {
+			return zone.string("project_id") == dnsProjectID(projectID) && strings.Contains(zone.string("name"), filter)
+		})
+		writeDNSList(w, r, items)
+	case http.MethodPost:
+		var body struct {
+			Name string `json:"name"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.Name == "" {
+			writeDNSError(w, http.StatusBadRequest, "zone name is empty")
+
+			return
+		}
+		if len(zones.list(fieldEquals("name", body.Name))) > 0 {
+			writeDNSError(w, http.StatusConflict, fmt.Sprintf("zone %s already exists", body.Name))
+
+			return
+		}
+		zone := object{
+			"id":                    s.newID(),
+			"project_id":            dnsProjectID(projectID),
+			"name":                  body.Name,
+			"comment":               "",
+			"created_at":            timestamp(),
+			"updated_at":            timestamp(),
+			"disabled":              false,
+			"delegation_checked_at": nil,
+			"last_delegated_at":     nil,
+			"last_check_status":     false,
+		}
+		zones.put(zone.string("id"), zone)
+		writeJSON(w, http.StatusOK, zone)
+	default:
+		writeDNSError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleDNSZone(w http.ResponseWriter, r *http.Request, zones *collection, zone object) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, zone)
+	case http.MethodPatch:
+		var body struct {
+			Comment string `json:"comment"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		zone["comment"] = body.Comment
+		zone["updated_at"] = timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		zones.delete(zone.string("id"))
+		delete(s.collections, "domains/v2/zones/"+zone.string("id")+"/rrsets")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeDNSError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleDNSRRSets(w http.ResponseWriter, r *http.Request, zone object, rest []string) {
+	rrsets := s.collection("domains/v2/zones/" + zone.string("id") + "/rrsets")
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		name := r.URL.Query().Get("name")
+		types := r.URL.Query().Get("rrset_types")
+		items := rrsets.list(func(rrset object) bool {
+			if name != "" && !strings.HasPrefix(rrset.string("name"), name) {
+				return false
+			}
+
+			return types == "" || strings.Contains(","+types+",", ","+rrset.string("type")+",")
+		})
+		writeDNSList(w, r, items)
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		var body object
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		name := body.string("name")
+		if name != zone.string("name") && !strings.HasSuffix(name, "."+zone.string("name")) {
+			writeDNSError(w, http.StatusBadRequest, fmt.Sprintf("rrset %s doesn't belong to zone %s", name, zone.string("name")))
+
+			return
+		}
+		if len(rrsets.list(func(rrset object) bool {
+			return rrset.string("name") == name && rrset.string("type") == body.string("type")
+		})) > 0 {
+			writeDNSError(w, http.StatusConflict, fmt.Sprintf("rrset %s of type %s already exists", name, body.string("type")))
+
+			return
+		}
+		rrset := object{
+			"id":         s.newID(),
+			"zone_id":    zone.string("id"),
+			"comment":    "",
+			"managed_by": "",
+		}
+		rrset.merge(body)
+		rrsets.put(rrset.string("id"), rrset)
+		writeJSON(w, http.StatusOK, rrset)
+	case len(rest) == 0:
+		writeDNSError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	case len(rest) == 1:
+		rrset, ok := rrsets.get(rest[0])
+		if !ok {
+			writeDNSError(w, http.StatusNotFound, fmt.Sprintf("rrset %s is not found", rest[0]))
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, rrset)
+		case http.MethodPatch:
+			var body object
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			delete(body, "name")
+			delete(body, "type")
+			rrset.merge(body)
+			if _, ok := body["comment"]; !ok {
+				rrset["comment"] = ""
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			rrsets.delete(rest[0])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeDNSError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+		}
+	default:
+		writeDNSError(w, http.StatusNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+	}
+}
+
+// dnsProjectID returns the project ID in the UUID format used by the
+// Domains v2 API.
+func dnsProjectID(projectID string) string {
+	if len(projectID) != 32 {
+		return projectID
+	}
+
+	return strings.Join([]string{
+		projectID[:8], projectID[8:12], projectID[12:16], projectID[16:20], projectID[20:],
+	}, "-")
+}
+
+// writeDNSList writes a page of items selected by the limit and offset query
+// parameters.
+func writeDNSList(w http.ResponseWriter, r *http.Request, items []object) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = dnsListLimit
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + limit
+	nextOffset := end
+	if end >= len(items) {
+		end = len(items)
+		nextOffset = 0
+	}
+	writeJSON(w, http.StatusOK, object{
+		"count":       len(items),
+		"next_offset": nextOffset,
+		"result":      items[offset:end],
+	})
+}
+
+func writeDNSError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, object{
+		"error":       strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		"description": description,
+	})
+}
+
+// handleDomainsV1 serves [{id|name}] and {id}/records/[{id}] of the
+// Domains v1 API. Domains are scoped to the account of the request token.
+func (s *Server) handleDomainsV1(w http.ResponseWriter, r *http.Request, parts []string) {
+	domains := s.collection("domains/v1/domains")
+
+	if len(parts) == 0 {
+		s.handleDomainsV1Domains(w, r, domains)
+
+		return
+	}
+
+	domain, ok := domains.get(parts[0])
+	if !ok {
+		// Domains can also be requested by their names.
+		if matched := domains.list(fieldEquals("name", parts[0])); len(parts) == 1 && len(matched) > 0 {
+			domain, ok = matched[0], true
+		}
+	}
+	if !ok {
+		writeDomainsV1Error(w, http.StatusNotFound, fmt.Sprintf("domain %s is not found", parts[0]))
+
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, domain)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		id := strconv.Itoa(toInt(domain["id"]))
+		domains.delete(id)
+		delete(s.collections, "domains/v1/domains/"+id+"/records")
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) >= 2 && parts[1] == "records":
+		s.handleDomainsV1Records(w, r, domain, parts[2:])
+	default:
+		writeDomainsV1Error(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleDomainsV1Domains(w http.ResponseWriter, r *http.Request, domains *collection) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, domains.list(nil))
+	case http.MethodPost:
+		var body struct {
+			Name string `json:"name"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.Name == "" {
+			writeDomainsV1Error(w, http.StatusBadRequest, "domain name is empty")
+
+			return
+		}
+		if len(domains.list(fieldEquals("name", body.Name))) > 0 {
+			writeDomainsV1Error(w, http.StatusConflict, fmt.Sprintf("domain %s already exists", body.Name))
+
+			return
+		}
+		userID, _ := strconv.Atoi(DomainName)
+		now := time.Now().Unix()
+		id := s.newIntID()
+		domain := object{
+			"id":          id,
+			"name":        body.Name,
+			"user_id":     userID,
+			"create_date": now,
+			"change_date": now,
+			"tags":        []string{},
+		}
+		domains.put(strconv.Itoa(id), domain)
+		writeJSON(w, http.StatusOK, domain)
+	default:
+		writeDomainsV1Error(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleDomainsV1Records(w http.ResponseWriter, r *http.Request, domain object, rest []string) {
+	domainID := strconv.Itoa(toInt(domain["id"]))
+	records := s.collection("domains/v1/domains/" + domainID + "/records")
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, records.list(nil))
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		var body object
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if !domainsV1RecordInDomain(body.string("name"), domain.string("name")) {
+			writeDomainsV1Error(w, http.StatusBadRequest, fmt.Sprintf("record %s doesn't belong to domain %s", body.string("name"), domain.string("name")))
+
+			return
+		}
+		id := s.newIntID()
+		body["id"] = id
+		body["change_date"] = time.Now().Unix()
+		records.put(strconv.Itoa(id), body)
+		writeJSON(w, http.StatusOK, body)
+	case len(rest) == 0:
+		writeDomainsV1Error(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	case len(rest) == 1:
+		record, ok := records.get(rest[0])
+		if !ok {
+			writeDomainsV1Error(w, http.StatusNotFound, fmt.Sprintf("record %s is not found", rest[0]))
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, record)
+		case http.MethodPut:
+			var body object
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			if !domainsV1RecordInDomain(body.string("name"), domain.string("name")) {
+				writeDomainsV1Error(w, http.StatusBadRequest, fmt.Sprintf("record %s doesn't belong to domain %s", body.string("name"), domain.string("name")))
+
+				return
+			}
+			body["id"] = record["id"]
+			body["change_date"] = time.Now().Unix()
+			records.put(rest[0], body)
+			writeJSON(w, http.StatusOK, body)
+		case http.MethodDelete:
+			records.delete(rest[0])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeDomainsV1Error(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+		}
+	default:
+		writeDomainsV1Error(w, http.StatusNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+	}
+}
+
+func domainsV1RecordInDomain(name, domainName string) bool {
+	return name == domainName || strings.HasSuffix(name, "."+domainName)
+}
+
+func writeDomainsV1Error(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{"error": message})
+}
@@ -0,0 +1,195 @@
+package fakeselectel
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Service types and URL prefixes of the APIs served by the fake server.
+const (
+	resellServiceType       = "resell"
+	quotaManagerServiceType = "quota-manager"
+	dbaasServiceType        = "managed-database"
+	mksServiceType          = "managed-kubernetes"
+	craasServiceType        = "container-registry"
+	secretsManagerType      = "secrets-manager"
+	certificateManagerType  = "certificate-manager"
+	computeServiceType      = "compute"
+
+	resellPrefix             = "/resell"
+	quotaManagerPrefix       = "/quota-manager"
+	dbaasPrefix              = "/dbaas"
+	mksPrefix                = "/mks"
+	craasPrefix              = "/craas"
+	secretsManagerPrefix     = "/secrets-manager"
+	certificateManagerPrefix = "/certificate-manager"
+	computePrefix            = "/compute"
+	dnsPrefix                = "/domains/v2"
+	domainsV1Prefix          = "/domains/v1"
+)
+
+// tokenTTL is the lifetime of tokens issued by the fake Keystone.
+const tokenTTL = 24 * time.Hour
+
+// DNSURL returns the endpoint of the Domains v2 API that is not registered
+// in the catalog.
+func (s *Server) DNSURL() string {
+	return s.URL + dnsPrefix
+}
+
+// DomainsV1URL returns the endpoint of the Domains v1 API that is not
+// registered in the catalog.
+func (s *Server) DomainsV1URL() string {
+	return s.URL + domainsV1Prefix
+}
+
+// SecretsManagerURL returns the endpoint of the Secrets Manager API.
+func (s *Server) SecretsManagerURL() string {
+	return s.URL + secretsManagerPrefix
+}
+
+// CertificateManagerURL returns the endpoint of the Certificate Manager API.
+func (s *Server) CertificateManagerURL() string {
+	return s.URL + certificateManagerPrefix
+}
+
+// tokenProjectID returns the project the request token is scoped to or an
+// empty string for domain-scoped tokens. It must be called with the lock held.
+func (s *Server) tokenProjectID(r *http.Request) string {
+	return s.tokens[r.Header.Get("X-Auth-Token")]
+}
+
+func (s *Server) validToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.tokens[token]
+
+	return ok
+}
+
+func (s *Server) handleTokens(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Methods  []string `json:"methods"`
+					Password struct {
+						User struct {
+							Name     string `json:"name"`
+							Password string `json:"password"`
+						} `json:"user"`
+					} `json:"password"`
+				} `json:"identity"`
+				Scope struct {
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"scope"`
+			} `json:"auth"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		identity := body.Auth.Identity
+		for _, method := range identity.Methods {
+			if method == "password" &&
+				(identity.Password.User.Name != Username || identity.Password.User.Password != Password) {
+				writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+
+				return
+			}
+		}
+
+		token := fmt.Sprintf("fake-token-%d", s.newIntID())
+		s.tokens[token] = body.Auth.Scope.Project.ID
+		w.Header().Set("X-Subject-Token", token)
+		writeJSON(w, http.StatusCreated, s.tokenBody(identity.Methods))
+	case http.MethodGet:
+		if _, ok := s.tokens[r.Header.Get("X-Subject-Token")]; !ok {
+			writeError(w, http.StatusNotFound, "Could not find token.")
+
+			return
+		}
+		writeJSON(w, http.StatusOK, s.tokenBody([]string{"token"}))
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) tokenBody(methods []string) object {
+	return object{
+		"token": object{
+			"methods":    methods,
+			"issued_at":  time.Now().UTC().Format(time.RFC3339),
+			"expires_at": time.Now().Add(tokenTTL).UTC().Format(time.RFC3339),
+			"catalog":    s.catalog(),
+		},
+	}
+}
+
+// catalog returns the Keystone endpoints catalog with every API of the fake server.
+func (s *Server) catalog() []object {
+	regional := func(prefix string) map[string]string {
+		urls := make(map[string]string, len(Regions))
+		for _, region := range Regions {
+			urls[region] = s.URL + prefix + "/" + region
+		}
+
+		return urls
+	}
+	global := func(url string) map[string]string {
+		urls := make(map[string]string, len(Regions))
+		for _, region := range Regions {
+			urls[region] = url
+		}
+
+		return urls
+	}
+
+	services := []struct {
+		serviceType string
+		urls        map[string]string
+	}{
+		{"identity", map[string]string{"ru-1": s.AuthURL()}},
+		{resellServiceType, global(s.URL + resellPrefix)},
+		{quotaManagerServiceType, regional(quotaManagerPrefix)},
+		{dbaasServiceType, regional(dbaasPrefix)},
+		{mksServiceType, regional(mksPrefix)},
+		{craasServiceType, map[string]string{"ru-1": s.URL + craasPrefix + "/api/v1"}},
+		{secretsManagerType, map[string]string{"ru-1": s.SecretsManagerURL()}},
+		{certificateManagerType, map[string]string{"ru-1": s.CertificateManagerURL()}},
+		{computeServiceType, regional(computePrefix)},
+	}
+
+	catalog := make([]object, 0, len(services))
+	for _, service := range services {
+		endpoints := make([]object, 0, len(service.urls))
+		for _, region := range Regions {
+			url, ok := service.urls[region]
+			if !ok {
+				continue
+			}
+			endpoints = append(endpoints, object{
+				"id":        fmt.Sprintf("%s-%s", service.serviceType, region),
+				"interface": "public",
+				"region":    region,
+				"region_id": region,
+				"url":       url,
+			})
+		}
+		catalog = append(catalog, object{
+			"id":        service.serviceType,
+			"type":      service.serviceType,
+			"name":      service.serviceType,
+			"endpoints": endpoints,
+		})
+	}
+
+	return catalog
+}
@@ -0,0 +1,582 @@
+package fakeselectel
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KubeVersions are Kubernetes versions served by the fake MKS API.
+var KubeVersions = []string{"1.27.10", "1.28.5", "1.28.6", "1.29.2"}
+
+// DefaultKubeVersion is the default Kubernetes version of the fake MKS API.
+const DefaultKubeVersion = "1.28.6"
+
+// FeatureGates are feature gates available for Kubernetes versions of the
+// fake MKS API except the ones listed in RemovedFeatureGates.
+var FeatureGates = []string{"GracefulNodeShutdown", "InPlacePodVerticalScaling", "TopologyAwareHints"}
+
+// RemovedFeatureGates are feature gates that aren't available since the minor
+// Kubernetes version.
+var RemovedFeatureGates = map[string][]string{"1.29": {"TopologyAwareHints"}}
+
+// AdmissionControllers are admission controllers available for every
+// Kubernetes version of the fake MKS API.
+var AdmissionControllers = []string{"AlwaysPullImages", "EventRateLimit", "NamespaceAutoProvision"}
+
+// mksMaintenanceWindow is the duration of the cluster maintenance window.
+const mksMaintenanceWindow = 4 * time.Hour
+
+// handleMKS serves {region}/kubeversions, {region}/feature-gates,
+// {region}/admission-controllers and {region}/clusters[/{id}[/...]] of the
+// MKS API.
+func (s *Server) handleMKS(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	region, kind, rest := parts[0], parts[1], parts[2:]
+
+	switch {
+	case kind == "kubeversions" && len(rest) == 0 && r.Method == http.MethodGet:
+		versions := make([]object, 0, len(KubeVersions))
+		for _, version := range KubeVersions {
+			versions = append(versions, object{"version": version, "is_default": version == DefaultKubeVersion})
+		}
+		writeJSON(w, http.StatusOK, object{"kube_versions": versions})
+	case kind == "feature-gates" && len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"feature_gates": mksKubeOptions(FeatureGates, RemovedFeatureGates)})
+	case kind == "admission-controllers" && len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"admission_controllers": mksKubeOptions(AdmissionControllers, nil)})
+	case kind == "clusters" && len(rest) == 0:
+		s.handleMKSClusters(w, r, region)
+	case kind == "clusters":
+		cluster, ok := s.collection("mks/" + region + "/clusters").get(rest[0])
+		if !ok {
+			writeNotFound(w, "cluster", rest[0])
+
+			return
+		}
+		switch {
+		case len(rest) == 1:
+			s.handleMKSCluster(w, r, region, cluster)
+		case len(rest) == 2:
+			s.handleMKSClusterAction(w, r, cluster, rest[1])
+		case rest[1] == "nodegroups":
+			s.handleMKSNodegroups(w, r, cluster, rest[2:])
+		default:
+			writeNotFound(w, "path", r.URL.Path)
+		}
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+// mksKubeOptions returns the options for every minor Kubernetes version
+// without the ones removed in that or an earlier minor version.
+func mksKubeOptions(names []string, removed map[string][]string) []object {
+	var options []object
+	seen := map[string]bool{}
+	removedNames := map[string]bool{}
+	for _, version := range KubeVersions {
+		minor := mksMinorVersion(version)
+		if seen[minor] {
+			continue
+		}
+		seen[minor] = true
+		for _, name := range removed[minor] {
+			removedNames[name] = true
+		}
+
+		minorNames := make([]string, 0, len(names))
+		for _, name := range names {
+			if !removedNames[name] {
+				minorNames = append(minorNames, name)
+			}
+		}
+		options = append(options, object{"KubeVersionMinor": minor, "Names": minorNames})
+	}
+
+	return options
+}
+
+func (s *Server) handleMKSClusters(w http.ResponseWriter, r *http.Request, region string) {
+	clusters := s.collection("mks/" + region + "/clusters")
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"clusters": clusters.list(fieldEquals("project_id", s.tokenProjectID(r)))})
+	case http.MethodPost:
+		var body struct {
+			Cluster object `json:"cluster"`
+		}
+		if !decodeBody(w, r, &body) || body.Cluster == nil {
+			return
+		}
+		if !mksKubeVersionExists(body.Cluster.string("kube_version")) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported kube version %s", body.Cluster.string("kube_version")))
+
+			return
+		}
+		cluster := s.newMKSCluster(r, region, body.Cluster)
+		clusters.put(cluster.string("id"), cluster)
+		writeJSON(w, http.StatusOK, object{"cluster": cluster})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// newMKSCluster returns a new cluster built from the create options together
+// with the nodegroups that are provided in the options.
+func (s *Server) newMKSCluster(r *http.Request, region string, opts object) object {
+	nodegroups, _ := opts["nodegroups"].([]interface{})
+	delete(opts, "nodegroups")
+
+	cluster := opts.clone()
+	id := s.newID()
+	cluster.merge(map[string]interface{}{
+		"id":                     id,
+		"status":                 "ACTIVE",
+		"project_id":             s.tokenProjectID(r),
+		"region":                 region,
+		"kube_api_ip":            s.newIP(),
+		"created_at":             timestamp(),
+		"updated_at":             timestamp(),
+		"pki_tree_updated_at":    timestamp(),
+		"maintenance_last_start": nil,
+	})
+	for _, field := range []string{"network_id", "subnet_id"} {
+		if cluster.string(field) == "" {
+			cluster[field] = s.newID()
+		}
+	}
+	for _, field := range []string{"enable_autorepair", "enable_patch_version_auto_upgrade"} {
+		if _, ok := cluster[field]; !ok {
+			cluster[field] = true
+		}
+	}
+	for _, field := range []string{"zonal", "private_kube_api"} {
+		if _, ok := cluster[field]; !ok {
+			cluster[field] = false
+		}
+	}
+	if _, ok := cluster["additional_software"]; !ok {
+		cluster["additional_software"] = object{}
+	}
+	if cluster.string("maintenance_window_start") == "" {
+		cluster["maintenance_window_start"] = "00:00:00"
+	}
+	cluster["maintenance_window_end"] = mksMaintenanceWindowEnd(cluster.string("maintenance_window_start"))
+	cluster["kubernetes_options"] = mksKubernetesOptions(nil, cluster["kubernetes_options"])
+
+	for _, nodegroupOpts := range nodegroups {
+		if opts, ok := asObject(nodegroupOpts); ok {
+			nodegroup := s.newMKSNodegroup(region, id, opts)
+			s.collection("mks/nodegroups/"+id).put(nodegroup.string("id"), nodegroup)
+		}
+	}
+
+	return cluster
+}
+
+// mksKubernetesOptions merges the Kubernetes options with the current ones.
+func mksKubernetesOptions(current, opts interface{}) object {
+	options, ok := asObject(current)
+	if !ok {
+		options = object{
+			"enable_pod_security_policy": false,
+			"feature_gates":              []string{},
+			"admission_controllers":      []string{},
+		}
+	}
+	if fields, ok := asObject(opts); ok {
+		options.merge(fields)
+	}
+
+	return options
+}
+
+// mksMaintenanceWindowEnd returns the end of the maintenance window that
+// starts at the given time.
+func mksMaintenanceWindowEnd(start string) string {
+	parsed, err := time.Parse("15:04:05", start)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Add(mksMaintenanceWindow).Format("15:04:05")
+}
+
+func (s *Server) handleMKSCluster(w http.ResponseWriter, r *http.Request, region string, cluster object) {
+	id := cluster.string("id")
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"cluster": cluster})
+	case http.MethodPut:
+		var body struct {
+			Cluster object `json:"cluster"`
+		}
+		if !decodeBody(w, r, &body) || body.Cluster == nil {
+			return
+		}
+		kubernetesOptions := body.Cluster["kubernetes_options"]
+		delete(body.Cluster, "kubernetes_options")
+		cluster.merge(body.Cluster)
+		cluster["kubernetes_options"] = mksKubernetesOptions(cluster["kubernetes_options"], kubernetesOptions)
+		cluster["maintenance_window_end"] = mksMaintenanceWindowEnd(cluster.string("maintenance_window_start"))
+		cluster["updated_at"] = timestamp()
+		writeJSON(w, http.StatusOK, object{"cluster": cluster})
+	case http.MethodDelete:
+		s.collection("mks/" + region + "/clusters").delete(id)
+		delete(s.collections, "mks/nodegroups/"+id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// handleMKSClusterAction serves the cluster kubeconfig and upgrade actions.
+func (s *Server) handleMKSClusterAction(w http.ResponseWriter, r *http.Request, cluster object, action string) {
+	switch {
+	case action == "nodegroups":
+		s.handleMKSNodegroups(w, r, cluster, nil)
+	case action == "kubeconfig" && r.Method == http.MethodGet:
+		kubeconfig, err := mksKubeconfig(cluster)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(kubeconfig))
+	case action == "rotate-certs" && r.Method == http.MethodPost:
+		cluster["pki_tree_updated_at"] = timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	case action == "upgrade-patch-version" && r.Method == http.MethodPost:
+		cluster["kube_version"] = mksLatestPatchVersion(mksMinorVersion(cluster.string("kube_version")))
+		cluster["updated_at"] = timestamp()
+		writeJSON(w, http.StatusOK, object{"cluster": cluster})
+	case action == "upgrade-minor-version" && r.Method == http.MethodPost:
+		next := mksNextMinorVersion(cluster.string("kube_version"))
+		latest := mksLatestPatchVersion(next)
+		if latest == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("minor version %s is not supported", next))
+
+			return
+		}
+		cluster["kube_version"] = latest
+		cluster["updated_at"] = timestamp()
+		writeJSON(w, http.StatusOK, object{"cluster": cluster})
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+// MKSClientCertLifetime is the lifetime of the admin client certificate in
+// kubeconfigs served by the fake MKS API. The certificate is issued when the
+// cluster PKI tree is updated.
+const MKSClientCertLifetime = 365 * 24 * time.Hour
+
+// mksKubeconfig returns a kubeconfig with the fields parsed by the MKS client.
+func mksKubeconfig(cluster object) (string, error) {
+	encode := func(value []byte) string {
+		return base64.StdEncoding.EncodeToString(value)
+	}
+	name := cluster.string("name")
+
+	issuedAt, err := time.Parse(time.RFC3339, cluster.string("pki_tree_updated_at"))
+	if err != nil {
+		return "", err
+	}
+	clientCert, clientKey, err := mksClientCert(issuedAt)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: %s
+    server: https://%s:6443
+  name: %s
+contexts:
+- context:
+    cluster: %s
+    user: admin
+  name: admin@%s
+current-context: admin@%s
+kind: Config
+preferences: {}
+users:
+- name: admin
+  user:
+    client-certificate-data: %s
+    client-key-data: %s
+`, encode([]byte("ca-"+cluster.string("id"))), cluster.string("kube_api_ip"), name, name, name, name,
+		encode(clientCert), encode(clientKey)), nil
+}
+
+// mksClientCert returns a PEM encoded self-signed admin client certificate
+// and its key.
+func mksClientCert(issuedAt time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(issuedAt.Unix()),
+		Subject:      pkix.Name{CommonName: "admin", Organization: []string{"system:masters"}},
+		NotBefore:    issuedAt,
+		NotAfter:     issuedAt.Add(MKSClientCertLifetime),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func (s *Server) handleMKSNodegroups(w http.ResponseWriter, r *http.Request, cluster object, rest []string) {
+	clusterID := cluster.string("id")
+	nodegroups := s.collection("mks/nodegroups/" + clusterID)
+
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, object{"nodegroups": nodegroups.list(nil)})
+		case http.MethodPost:
+			var body struct {
+				Nodegroup object `json:"nodegroup"`
+			}
+			if !decodeBody(w, r, &body) || body.Nodegroup == nil {
+				return
+			}
+			nodegroup := s.newMKSNodegroup(cluster.string("region"), clusterID, body.Nodegroup)
+			nodegroups.put(nodegroup.string("id"), nodegroup)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+
+		return
+	}
+
+	nodegroup, ok := nodegroups.get(rest[0])
+	if !ok {
+		writeNotFound(w, "nodegroup", rest[0])
+
+		return
+	}
+
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"nodegroup": nodegroup})
+	case len(rest) == 1 && r.Method == http.MethodPut:
+		var body struct {
+			Nodegroup object `json:"nodegroup"`
+		}
+		if !decodeBody(w, r, &body) || body.Nodegroup == nil {
+			return
+		}
+		nodegroup.merge(body.Nodegroup)
+		nodegroup["updated_at"] = timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		nodegroups.delete(rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	case len(rest) == 2 && rest[1] == "resize" && r.Method == http.MethodPost:
+		var body struct {
+			Nodegroup struct {
+				Desired int `json:"desired"`
+			} `json:"nodegroup"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		s.resizeMKSNodegroup(nodegroup, body.Nodegroup.Desired)
+		nodegroup["updated_at"] = timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	case len(rest) == 2 && r.Method == http.MethodGet:
+		node, ok := mksNodegroupNode(nodegroup, rest[1])
+		if !ok {
+			writeNotFound(w, "node", rest[1])
+
+			return
+		}
+		writeJSON(w, http.StatusOK, object{"node": node})
+	case len(rest) == 2 && r.Method == http.MethodDelete:
+		if !deleteMKSNodegroupNode(nodegroup, rest[1]) {
+			writeNotFound(w, "node", rest[1])
+
+			return
+		}
+		nodegroup["updated_at"] = timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+// newMKSNodegroup returns a new nodegroup of the cluster built from the
+// create options. Nodegroups without a flavor get a new compute flavor with
+// the requested CPUs and RAM.
+func (s *Server) newMKSNodegroup(region, clusterID string, opts object) object {
+	nodegroup := opts.clone()
+	nodegroup.merge(map[string]interface{}{
+		"id":             s.newID(),
+		"cluster_id":     clusterID,
+		"nodegroup_type": "STANDARD",
+		"created_at":     timestamp(),
+		"updated_at":     timestamp(),
+	})
+	if nodegroup.string("flavor_id") == "" {
+		flavor := s.newComputeFlavor(region, toInt(nodegroup["cpus"]), toInt(nodegroup["ram_mb"]), toInt(nodegroup["volume_gb"]))
+		nodegroup["flavor_id"] = flavor.string("id")
+	}
+	for _, field := range []string{"local_volume", "enable_autoscale"} {
+		if _, ok := nodegroup[field]; !ok {
+			nodegroup[field] = false
+		}
+	}
+	for _, field := range []string{"autoscale_min_nodes", "autoscale_max_nodes"} {
+		if _, ok := nodegroup[field]; !ok {
+			nodegroup[field] = 0
+		}
+	}
+	if nodegroup["labels"] == nil {
+		nodegroup["labels"] = object{}
+	}
+	if nodegroup["taints"] == nil {
+		nodegroup["taints"] = []object{}
+	}
+	count := toInt(nodegroup["count"])
+	delete(nodegroup, "count")
+	s.resizeMKSNodegroup(nodegroup, count)
+
+	return nodegroup
+}
+
+// resizeMKSNodegroup sets the number of nodegroup nodes keeping the existing ones.
+func (s *Server) resizeMKSNodegroup(nodegroup object, count int) {
+	nodes, _ := nodegroup["nodes"].([]object)
+	if len(nodes) > count {
+		nodes = nodes[:count]
+	}
+	for len(nodes) < count {
+		id := s.newID()
+		nodes = append(nodes, object{
+			"id":           id,
+			"created_at":   timestamp(),
+			"updated_at":   timestamp(),
+			"hostname":     fmt.Sprintf("node-%s", id[len(id)-6:]),
+			"ip":           fmt.Sprintf("10.0.0.%d", s.newIntID()%250+2),
+			"nodegroup_id": nodegroup.string("id"),
+			"os_server_id": s.newID(),
+		})
+	}
+	nodegroup["nodes"] = nodes
+}
+
+func mksNodegroupNode(nodegroup object, nodeID string) (object, bool) {
+	nodes, _ := nodegroup["nodes"].([]object)
+	for _, node := range nodes {
+		if node.string("id") == nodeID {
+			return node, true
+		}
+	}
+
+	return nil, false
+}
+
+// deleteMKSNodegroupNode removes a single node from the nodegroup. The real
+// API drains the node before deleting it.
+func deleteMKSNodegroupNode(nodegroup object, nodeID string) bool {
+	nodes, _ := nodegroup["nodes"].([]object)
+	for i, node := range nodes {
+		if node.string("id") == nodeID {
+			nodegroup["nodes"] = append(nodes[:i:i], nodes[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func mksKubeVersionExists(version string) bool {
+	for _, existing := range KubeVersions {
+		if existing == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mksMinorVersion trims the patch part of the Kubernetes version.
+func mksMinorVersion(version string) string {
+	if i := strings.LastIndex(version, "."); i > 0 {
+		return version[:i]
+	}
+
+	return version
+}
+
+// mksNextMinorVersion returns the minor version that follows the minor part
+// of the Kubernetes version.
+func mksNextMinorVersion(version string) string {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s.%d", parts[0], minor+1)
+}
+
+// mksLatestPatchVersion returns the latest Kubernetes version of the minor
+// version or an empty string if there are no such versions.
+func mksLatestPatchVersion(minor string) string {
+	var patches []int
+	for _, version := range KubeVersions {
+		if mksMinorVersion(version) != minor {
+			continue
+		}
+		patch, err := strconv.Atoi(version[len(minor)+1:])
+		if err == nil {
+			patches = append(patches, patch)
+		}
+	}
+	if len(patches) == 0 {
+		return ""
+	}
+	sort.Ints(patches)
+
+	return fmt.Sprintf("%s.%d", minor, patches[len(patches)-1])
+}
@@ -0,0 +1,108 @@
+package fakeselectel
+
+import (
+	"net/http"
+)
+
+// handleQuotaManager serves {region}/projects/{project_id}/quotas and
+// {region}/projects/{project_id}/limits of the Quota Manager API.
+func (s *Server) handleQuotaManager(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 4 || parts[1] != "projects" {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+	region, projectID := parts[0], parts[2]
+	if _, ok := s.collection("projects").get(projectID); !ok {
+		writeNotFound(w, "project", projectID)
+
+		return
+	}
+
+	switch {
+	case parts[3] == "limits" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"quotas": map[string][]object{}})
+	case parts[3] == "quotas" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"quotas": s.projectQuotas(projectID, region)})
+	case parts[3] == "quotas" && r.Method == http.MethodPatch:
+		var body struct {
+			Quotas map[string][]struct {
+				Zone  string `json:"zone"`
+				Value int    `json:"value"`
+			} `json:"quotas"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		quotas := s.storedProjectQuotas(projectID, region)
+		for resource, entities := range body.Quotas {
+			for _, entity := range entities {
+				quotas[resource] = setQuota(quotas[resource], entity.Zone, entity.Value)
+			}
+		}
+		s.collection("quotas").put(projectID+"/"+region, object{"quotas": quotas})
+		writeJSON(w, http.StatusOK, object{"quotas": s.projectQuotas(projectID, region)})
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+// defaultZoneQuota is the value of every zonal quota of a new project.
+const defaultZoneQuota = 1000000
+
+// defaultRegionalQuotas are resources with region-wide quotas of a new project.
+var defaultRegionalQuotas = []string{"mks_cluster_regional", "mks_cluster_zonal"}
+
+// defaultZonalQuotas are resources with zonal quotas of a new project.
+var defaultZonalQuotas = []string{
+	"compute_cores", "compute_ram",
+	"volume_gigabytes_basic", "volume_gigabytes_fast", "volume_gigabytes_universal", "volume_gigabytes_local",
+}
+
+// storedProjectQuotas returns quotas of the project in the region that were
+// set explicitly by resource names.
+func (s *Server) storedProjectQuotas(projectID, region string) map[string][]object {
+	stored, ok := s.collection("quotas").get(projectID + "/" + region)
+	if !ok {
+		return map[string][]object{}
+	}
+
+	return stored["quotas"].(map[string][]object)
+}
+
+// projectQuotas returns quotas of the project in the region by resource
+// names. Resources without explicitly set quotas get the default ones, so
+// MKS quota checks pass without any setup.
+func (s *Server) projectQuotas(projectID, region string) map[string][]object {
+	quotas := map[string][]object{}
+	for _, resource := range defaultRegionalQuotas {
+		quotas[resource] = setQuota(nil, "", 10)
+	}
+	for _, resource := range defaultZonalQuotas {
+		for _, zone := range []string{"a", "b", "c"} {
+			quotas[resource] = setQuota(quotas[resource], region+zone, defaultZoneQuota)
+		}
+	}
+	for resource, entities := range s.storedProjectQuotas(projectID, region) {
+		quotas[resource] = entities
+	}
+
+	return quotas
+}
+
+// setQuota sets the quota value of the zone or the whole region if the zone is empty.
+func setQuota(entities []object, zone string, value int) []object {
+	for _, entity := range entities {
+		if entity.string("zone") == zone {
+			entity["value"] = value
+
+			return entities
+		}
+	}
+	entity := object{"value": value, "used": 0}
+	if zone != "" {
+		entity["zone"] = zone
+	}
+
+	return append(entities, entity)
+}
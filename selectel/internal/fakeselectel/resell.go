@@ -0,0 +1,440 @@
+package fakeselectel
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// handleResell serves the Resell v2 API.
+func (s *Server) handleResell(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 || parts[0] != "v2" {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+
+	switch parts[1] {
+	case "projects":
+		s.handleResellProjects(w, r, parts[2:])
+	case "users":
+		s.handleResellUsers(w, r, parts[2:])
+	case "roles":
+		s.handleResellRoles(w, r, parts[2:])
+	case "keypairs":
+		s.handleResellKeypairs(w, r, parts[2:])
+	case "floatingips":
+		s.handleResellProjectObjects(w, r, parts[2:], "floatingip", s.newFloatingIP)
+	case "licenses":
+		s.handleResellProjectObjects(w, r, parts[2:], "license", s.newLicense)
+	case "subnets":
+		s.handleResellProjectObjects(w, r, parts[2:], "subnet", s.newSubnet)
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+func (s *Server) handleResellProjects(w http.ResponseWriter, r *http.Request, parts []string) {
+	projects := s.collection("projects")
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := projects.list(nil)
+			for i, project := range list {
+				list[i] = s.projectView(project)
+			}
+			writeJSON(w, http.StatusOK, object{"projects": list})
+		case http.MethodPost:
+			var body struct {
+				Project struct {
+					Name           string `json:"name"`
+					SkipQuotasInit bool   `json:"skip_quotas_init"`
+				} `json:"project"`
+			}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			id := fmt.Sprintf("%032x", s.newIntID())
+			project := object{
+				"id":         id,
+				"name":       body.Project.Name,
+				"url":        fmt.Sprintf("https://%s.selvpc.ru", id[26:]),
+				"enabled":    true,
+				"custom_url": "",
+				"theme":      object{"color": "", "logo": ""},
+			}
+			projects.put(id, project)
+			writeJSON(w, http.StatusOK, object{"project": s.projectView(project)})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+
+		return
+	}
+
+	id := parts[0]
+	project, ok := projects.get(id)
+	if !ok {
+		writeNotFound(w, "project", id)
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"project": s.projectView(project)})
+	case http.MethodPatch:
+		var body struct {
+			Project struct {
+				Name      string            `json:"name"`
+				CustomURL *string           `json:"custom_url"`
+				Theme     map[string]string `json:"theme"`
+			} `json:"project"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.Project.Name != "" {
+			project["name"] = body.Project.Name
+		}
+		if body.Project.CustomURL != nil {
+			// The API returns custom URLs with the scheme.
+			customURL := *body.Project.CustomURL
+			if customURL != "" {
+				customURL = "https://" + customURL
+			}
+			project["custom_url"] = customURL
+		}
+		if body.Project.Theme != nil {
+			theme := project["theme"].(object)
+			for key, value := range body.Project.Theme {
+				theme[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, object{"project": s.projectView(project)})
+	case http.MethodDelete:
+		projects.delete(id)
+		for _, name := range []string{"floatingip", "license", "subnet", "roles"} {
+			c := s.collection(name)
+			for _, obj := range c.list(fieldEquals("project_id", id)) {
+				c.delete(resellObjectID(obj))
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// projectView returns the project with explicitly set quotas of every region.
+func (s *Server) projectView(project object) object {
+	view := project.clone()
+	projectQuotas := map[string][]object{}
+	for _, region := range Regions {
+		for resource, entities := range s.storedProjectQuotas(project.string("id"), region) {
+			for _, entity := range entities {
+				withRegion := entity.clone()
+				withRegion["region"] = region
+				projectQuotas[resource] = append(projectQuotas[resource], withRegion)
+			}
+		}
+	}
+	view["quotas"] = projectQuotas
+
+	return view
+}
+
+func (s *Server) handleResellUsers(w http.ResponseWriter, r *http.Request, parts []string) {
+	users := s.collection("users")
+
+	var body struct {
+		User struct {
+			Name     string `json:"name"`
+			Password string `json:"password"`
+			Enabled  *bool  `json:"enabled"`
+		} `json:"user"`
+	}
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, object{"users": users.list(nil)})
+		case http.MethodPost:
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			enabled := true
+			if body.User.Enabled != nil {
+				enabled = *body.User.Enabled
+			}
+			id := fmt.Sprintf("%032x", s.newIntID())
+			user := object{
+				"id":      id,
+				"name":    body.User.Name,
+				"enabled": enabled,
+			}
+			users.put(id, user)
+			writeJSON(w, http.StatusOK, object{"user": user})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+
+		return
+	}
+
+	id := parts[0]
+	user, ok := users.get(id)
+	if !ok {
+		writeNotFound(w, "user", id)
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object{"user": user})
+	case http.MethodPatch:
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.User.Name != "" {
+			user["name"] = body.User.Name
+		}
+		if body.User.Enabled != nil {
+			user["enabled"] = *body.User.Enabled
+		}
+		writeJSON(w, http.StatusOK, object{"user": user})
+	case http.MethodDelete:
+		users.delete(id)
+		roles := s.collection("roles")
+		for _, role := range roles.list(fieldEquals("user_id", id)) {
+			roles.delete(resellObjectID(role))
+		}
+		keypairs := s.collection("keypairs")
+		for _, keypair := range keypairs.list(fieldEquals("user_id", id)) {
+			keypairs.delete(resellObjectID(keypair))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// handleResellRoles serves roles/projects/{project_id} and
+// roles/projects/{project_id}/users/{user_id}.
+func (s *Server) handleResellRoles(w http.ResponseWriter, r *http.Request, parts []string) {
+	roles := s.collection("roles")
+
+	if len(parts) == 2 && parts[0] == "projects" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, object{"roles": roles.list(fieldEquals("project_id", parts[1]))})
+
+		return
+	}
+	if len(parts) != 4 || parts[0] != "projects" || parts[2] != "users" {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+
+	role := object{"project_id": parts[1], "user_id": parts[3]}
+	id := resellObjectID(role)
+
+	switch r.Method {
+	case http.MethodPost:
+		if _, ok := s.collection("projects").get(parts[1]); !ok {
+			writeNotFound(w, "project", parts[1])
+
+			return
+		}
+		if _, ok := s.collection("users").get(parts[3]); !ok {
+			writeNotFound(w, "user", parts[3])
+
+			return
+		}
+		roles.put(id, role)
+		writeJSON(w, http.StatusOK, object{"role": role})
+	case http.MethodDelete:
+		if !roles.delete(id) {
+			writeNotFound(w, "role", id)
+
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// handleResellKeypairs serves keypairs and keypairs/{name}/users/{user_id}.
+func (s *Server) handleResellKeypairs(w http.ResponseWriter, r *http.Request, parts []string) {
+	keypairs := s.collection("keypairs")
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, object{"keypairs": keypairs.list(nil)})
+		case http.MethodPost:
+			var body struct {
+				Keypair struct {
+					Name      string   `json:"name"`
+					PublicKey string   `json:"public_key"`
+					Regions   []string `json:"regions"`
+					UserID    string   `json:"user_id"`
+				} `json:"keypair"`
+			}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			regions := body.Keypair.Regions
+			if len(regions) == 0 {
+				regions = Regions
+			}
+			keypair := object{
+				"name":       body.Keypair.Name,
+				"public_key": body.Keypair.PublicKey,
+				"regions":    regions,
+				"user_id":    body.Keypair.UserID,
+			}
+			keypairs.put(resellObjectID(keypair), keypair)
+			created := []object{keypair}
+			writeJSON(w, http.StatusOK, object{"keypair": created})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+
+		return
+	}
+
+	if len(parts) != 3 || parts[1] != "users" || r.Method != http.MethodDelete {
+		writeNotFound(w, "path", r.URL.Path)
+
+		return
+	}
+
+	if !keypairs.delete(resellObjectID(object{"user_id": parts[2], "name": parts[0]})) {
+		writeNotFound(w, "keypair", parts[0])
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleResellProjectObjects serves regional project objects such as floating
+// IPs, licenses and subnets that are created with {kind}s/projects/{project_id}
+// and managed with {kind}s/{id}.
+func (s *Server) handleResellProjectObjects(
+	w http.ResponseWriter, r *http.Request, parts []string, kind string,
+	newObject func(projectID, region string, opts map[string]interface{}) object,
+) {
+	objects := s.collection(kind)
+	plural := kind + "s"
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, object{plural: objects.list(nil)})
+	case len(parts) == 2 && parts[0] == "projects" && r.Method == http.MethodPost:
+		projectID := parts[1]
+		if _, ok := s.collection("projects").get(projectID); !ok {
+			writeNotFound(w, "project", projectID)
+
+			return
+		}
+		var body map[string][]map[string]interface{}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		created := make([]object, 0)
+		for _, opts := range body[plural] {
+			region, _ := opts["region"].(string)
+			quantity, _ := opts["quantity"].(float64)
+			for i := 0; i < int(quantity); i++ {
+				obj := newObject(projectID, region, opts)
+				objects.put(resellObjectID(obj), obj)
+				created = append(created, obj)
+			}
+		}
+		writeJSON(w, http.StatusOK, object{plural: created})
+	case len(parts) == 1:
+		id := parts[0]
+		obj, ok := objects.get(id)
+		if !ok {
+			writeNotFound(w, kind, id)
+
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, object{kind: obj})
+		case http.MethodDelete:
+			objects.delete(id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	default:
+		writeNotFound(w, "path", r.URL.Path)
+	}
+}
+
+func (s *Server) newFloatingIP(projectID, region string, _ map[string]interface{}) object {
+	return object{
+		"id":                  s.newID(),
+		"floating_ip_address": s.newIP(),
+		"fixed_ip_address":    "",
+		"port_id":             "",
+		"project_id":          projectID,
+		"region":              region,
+		"status":              "DOWN",
+		"servers":             []object{},
+	}
+}
+
+func (s *Server) newLicense(projectID, region string, opts map[string]interface{}) object {
+	return object{
+		"id":         s.newIntID(),
+		"project_id": projectID,
+		"region":     region,
+		"type":       opts["type"],
+		"status":     "DOWN",
+		"network_id": s.newID(),
+		"subnet_id":  s.newID(),
+		"port_id":    "",
+		"servers":    []object{},
+	}
+}
+
+func (s *Server) newSubnet(projectID, region string, opts map[string]interface{}) object {
+	n := s.newIntID()
+	prefixLength, _ := opts["prefix_length"].(float64)
+	cidr := fmt.Sprintf("192.168.%d.0/%d", n%250, int(prefixLength))
+	if opts["type"] == "ipv6" {
+		cidr = fmt.Sprintf("2001:db8:%x::/%d", n, int(prefixLength))
+	}
+
+	return object{
+		"id":              n,
+		"project_id":      projectID,
+		"region":          region,
+		"cidr":            cidr,
+		"network_id":      s.newID(),
+		"subnet_id":       s.newID(),
+		"status":          "DOWN",
+		"vlan_id":         1000 + n,
+		"vtep_ip_address": "",
+		"servers":         []object{},
+	}
+}
+
+// resellObjectID returns the identifier of the Resell API object that is
+// used as a collection key.
+func resellObjectID(obj object) string {
+	switch id := obj["id"].(type) {
+	case string:
+		return id
+	case int:
+		return strconv.Itoa(id)
+	}
+
+	return obj.string("project_id") + "/" + obj.string("user_id") + "/" + obj.string("name")
+}
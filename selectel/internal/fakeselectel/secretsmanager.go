@@ -0,0 +1,330 @@
+package fakeselectel
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Status texts of the Secrets Manager and Certificate Manager errors that are
+// recognized by the secretsmanager-go client.
+const (
+	secretsManagerBadRequest = "INCORRECT_REQUEST"
+	secretsManagerNotFound   = "NOT_FOUND"
+	secretsManagerConflict   = "CONFLICT"
+	secretsManagerNotAllowed = "NOT_ALLOWED"
+)
+
+// handleSecretsManager serves v1[?list] and v1/{key} of the Secrets Manager
+// API. Secrets are scoped to the project of the request token.
+func (s *Server) handleSecretsManager(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] != "v1" || len(parts) > 2 {
+		writeSecretsManagerError(w, http.StatusNotFound, secretsManagerNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+
+		return
+	}
+	secrets := s.collection("secrets-manager/" + s.tokenProjectID(r) + "/secrets")
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			writeSecretsManagerMethodNotAllowed(w, r)
+
+			return
+		}
+		keys := make([]object, 0)
+		for _, secret := range secrets.list(nil) {
+			keys = append(keys, object{
+				"name": secret["name"],
+				"type": "Secret",
+				"metadata": object{
+					"created_at":  secret["created_at"],
+					"description": secret["description"],
+				},
+			})
+		}
+		writeJSON(w, http.StatusOK, object{"keys": keys})
+
+		return
+	}
+
+	key := parts[1]
+	secret, exists := secrets.get(key)
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			// The API doesn't distinguish missing secrets from invalid keys.
+			writeSecretsManagerError(w, http.StatusBadRequest, secretsManagerBadRequest, fmt.Sprintf("secret %s is not found", key))
+
+			return
+		}
+		writeJSON(w, http.StatusOK, object{
+			"name":        secret["name"],
+			"description": secret["description"],
+			"version": object{
+				"created_at": secret["created_at"],
+				"value":      secret["value"],
+				"version_id": secret["version_id"],
+			},
+		})
+	case http.MethodPost:
+		if exists {
+			writeSecretsManagerError(w, http.StatusConflict, secretsManagerConflict, fmt.Sprintf("secret %s already exists", key))
+
+			return
+		}
+		var body struct {
+			Description string `json:"description"`
+			Value       string `json:"value"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if _, err := base64.StdEncoding.DecodeString(body.Value); err != nil || body.Value == "" {
+			writeSecretsManagerError(w, http.StatusBadRequest, secretsManagerBadRequest, "secret value must be a non-empty base64 string")
+
+			return
+		}
+		secrets.put(key, object{
+			"name":        key,
+			"description": body.Description,
+			"value":       body.Value,
+			"version_id":  1,
+			"created_at":  timestamp(),
+		})
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		if !exists {
+			writeSecretsManagerError(w, http.StatusNotFound, secretsManagerNotFound, fmt.Sprintf("secret %s is not found", key))
+
+			return
+		}
+		var body struct {
+			Description string `json:"description"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		secret["description"] = body.Description
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !secrets.delete(key) {
+			writeSecretsManagerError(w, http.StatusNotFound, secretsManagerNotFound, fmt.Sprintf("secret %s is not found", key))
+
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeSecretsManagerMethodNotAllowed(w, r)
+	}
+}
+
+// handleCertificateManager serves v1/certs and v1/cert/{id}[/{action}] of the
+// Certificate Manager API. Certificates are scoped to the project of the
+// request token.
+func (s *Server) handleCertificateManager(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 || parts[0] != "v1" {
+		writeSecretsManagerError(w, http.StatusNotFound, secretsManagerNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+
+		return
+	}
+	certificates := s.collection("certificate-manager/" + s.tokenProjectID(r) + "/certificates")
+
+	switch {
+	case parts[1] == "certs" && len(parts) == 2:
+		s.handleCertificateManagerCerts(w, r, certificates)
+	case parts[1] == "cert" && len(parts) >= 3:
+		cert, ok := certificates.get(parts[2])
+		if !ok {
+			writeSecretsManagerError(w, http.StatusNotFound, secretsManagerNotFound, fmt.Sprintf("certificate %s is not found", parts[2]))
+
+			return
+		}
+		if len(parts) == 3 {
+			s.handleCertificateManagerCert(w, r, certificates, cert)
+		} else {
+			handleCertificateManagerCertAction(w, r, cert, parts[3])
+		}
+	default:
+		writeSecretsManagerError(w, http.StatusNotFound, secretsManagerNotFound, fmt.Sprintf("path %s is not found", r.URL.Path))
+	}
+}
+
+func (s *Server) handleCertificateManagerCerts(w http.ResponseWriter, r *http.Request, certificates *collection) {
+	switch r.Method {
+	case http.MethodGet:
+		views := make([]object, 0)
+		for _, cert := range certificates.list(nil) {
+			views = append(views, certificateView(cert))
+		}
+		writeJSON(w, http.StatusOK, views)
+	case http.MethodPost:
+		var body struct {
+			Name string `json:"name"`
+			Pem  object `json:"pem"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		cert := object{"id": s.newID(), "name": body.Name, "consumers": []interface{}{}}
+		if err := setCertificatePEM(cert, body.Pem); err != nil {
+			writeSecretsManagerError(w, http.StatusBadRequest, secretsManagerBadRequest, err.Error())
+
+			return
+		}
+		cert["version"] = 1
+		certificates.put(cert.string("id"), cert)
+		writeJSON(w, http.StatusOK, certificateView(cert))
+	default:
+		writeSecretsManagerMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleCertificateManagerCert(w http.ResponseWriter, r *http.Request, certificates *collection, cert object) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, certificateView(cert))
+	case http.MethodPut:
+		var body struct {
+			Name string `json:"name"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		cert["name"] = body.Name
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPost:
+		var body struct {
+			Pem object `json:"pem"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if err := setCertificatePEM(cert, body.Pem); err != nil {
+			writeSecretsManagerError(w, http.StatusBadRequest, secretsManagerBadRequest, err.Error())
+
+			return
+		}
+		cert["version"] = toInt(cert["version"]) + 1
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		certificates.delete(cert.string("id"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeSecretsManagerMethodNotAllowed(w, r)
+	}
+}
+
+// handleCertificateManagerCertAction serves consumers, private_key and
+// ca_chain of a certificate.
+func handleCertificateManagerCertAction(w http.ResponseWriter, r *http.Request, cert object, action string) {
+	switch {
+	case action == "consumers" && (r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		var body struct {
+			Consumers []object `json:"consumers"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		consumers, _ := cert["consumers"].([]interface{})
+		for _, consumer := range body.Consumers {
+			filtered := make([]interface{}, 0, len(consumers))
+			for _, existing := range consumers {
+				if obj, ok := asObject(existing); !ok || obj.string("id") != consumer.string("id") {
+					filtered = append(filtered, existing)
+				}
+			}
+			consumers = filtered
+			if r.Method == http.MethodPut {
+				consumers = append(consumers, consumer)
+			}
+		}
+		cert["consumers"] = consumers
+		w.WriteHeader(http.StatusNoContent)
+	case action == "private_key" && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(cert.string("private_key")))
+	case action == "ca_chain" && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(cert.string("ca_chain")))
+	default:
+		writeSecretsManagerMethodNotAllowed(w, r)
+	}
+}
+
+// setCertificatePEM validates the PEM bundle and sets the certificate fields
+// from the leaf certificate of the chain.
+func setCertificatePEM(cert, bundle object) error {
+	if bundle.string("private_key") == "" {
+		return fmt.Errorf("private key is empty")
+	}
+	chain, _ := bundle["certificates"].([]interface{})
+	if len(chain) == 0 {
+		return fmt.Errorf("certificates are empty")
+	}
+
+	var (
+		leaf    *x509.Certificate
+		encoded []string
+	)
+	for i, raw := range chain {
+		value, _ := raw.(string)
+		block, _ := pem.Decode([]byte(value))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return fmt.Errorf("certificate %d is not a PEM encoded certificate", i)
+		}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("certificate %d is invalid: %s", i, err)
+		}
+		if leaf == nil {
+			leaf = parsed
+		}
+		encoded = append(encoded, value)
+	}
+
+	dnsNames := make([]interface{}, 0, len(leaf.DNSNames))
+	for _, name := range leaf.DNSNames {
+		dnsNames = append(dnsNames, name)
+	}
+	cert.merge(map[string]interface{}{
+		"dns_names":   dnsNames,
+		"serial":      fmt.Sprintf("%X", leaf.SerialNumber),
+		"private_key": bundle.string("private_key"),
+		"ca_chain":    strings.Join(encoded, "\n"),
+		"issued_by": object{
+			"country":       leaf.Issuer.Country,
+			"locality":      leaf.Issuer.Locality,
+			"serialNumber":  leaf.Issuer.SerialNumber,
+			"streetAddress": leaf.Issuer.StreetAddress,
+		},
+		"validity": object{
+			"basic_constraints": leaf.BasicConstraintsValid,
+			"notAfter":          leaf.NotAfter.UTC().Format(time.RFC3339),
+			"notBefore":         leaf.NotBefore.UTC().Format(time.RFC3339),
+		},
+	})
+
+	return nil
+}
+
+// certificateView returns the certificate without the private key material.
+func certificateView(cert object) object {
+	view := cert.clone()
+	delete(view, "ca_chain")
+	view["private_key"] = object{"type": "RSA"}
+
+	return view
+}
+
+func writeSecretsManagerError(w http.ResponseWriter, status int, statusText, message string) {
+	writeJSON(w, status, object{"status_text": statusText, "error_text": message})
+}
+
+func writeSecretsManagerMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeSecretsManagerError(w, http.StatusMethodNotAllowed, secretsManagerNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+}
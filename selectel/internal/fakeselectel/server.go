@@ -0,0 +1,374 @@
+// Package fakeselectel implements an in-memory fake of the Selectel APIs that
+// are used by the provider. It serves a Keystone endpoints catalog together
+// with stateful Resell, Quota Manager, DBaaS, MKS, Domains, CRaaS, Secrets
+// Manager and compute flavors APIs, so resources can be tested without a network.
+package fakeselectel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DomainName is the account the fake Keystone issues tokens for.
+	DomainName = "100000"
+	// Username is the service user accepted by the fake Keystone.
+	Username = "fake-user"
+	// Password is the service user password accepted by the fake Keystone.
+	Password = "fake-password"
+)
+
+// Regions are served by every regional API of the fake server.
+var Regions = []string{"ru-1", "ru-2", "ru-3", "ru-7", "ru-8", "ru-9", "uz-1"}
+
+// Server is a fake Selectel API server. All objects are kept in memory and
+// are lost when the server is closed.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	lastID int
+	// tokens maps issued tokens to the project they are scoped to.
+	tokens      map[string]string
+	collections map[string]*collection
+	// failures are the requests the server responds to with an error.
+	failures []failure
+}
+
+// failure matches requests of the method whose path ends with the suffix.
+// A failure with a trigger matches requests only after the trigger request
+// was served.
+type failure struct {
+	method     string
+	pathSuffix string
+	trigger    *failure
+	armed      bool
+}
+
+func (f failure) matches(r *http.Request) bool {
+	return r.Method == f.method && strings.HasSuffix(r.URL.Path, f.pathSuffix)
+}
+
+// NewServer starts a new fake Selectel API server. The caller must call
+// Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		tokens:      map[string]string{},
+		collections: map[string]*collection{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/v3/auth/tokens", s.handleTokens)
+	s.handle(mux, resellPrefix, s.handleResell)
+	s.handle(mux, quotaManagerPrefix, s.handleQuotaManager)
+	s.handle(mux, dbaasPrefix, s.handleDBaaS)
+	s.handle(mux, mksPrefix, s.handleMKS)
+	s.handle(mux, craasPrefix, s.handleCRaaS)
+	s.handle(mux, secretsManagerPrefix, s.handleSecretsManager)
+	s.handle(mux, certificateManagerPrefix, s.handleCertificateManager)
+	s.handle(mux, dnsPrefix, s.handleDNS)
+	s.handle(mux, domainsV1Prefix, s.handleDomainsV1)
+	s.handle(mux, computePrefix, s.handleCompute)
+	s.seedDBaaS()
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// AuthURL returns the Keystone URL of the fake server.
+func (s *Server) AuthURL() string {
+	return s.URL + "/identity/v3/"
+}
+
+// requestHandler handles a request to the service API. Parts contain the
+// request path split by slashes without the service prefix.
+type requestHandler func(w http.ResponseWriter, r *http.Request, parts []string)
+
+// handle registers a service API handler that accepts only requests with
+// a token issued by the fake Keystone.
+func (s *Server) handle(mux *http.ServeMux, prefix string, handler requestHandler) {
+	mux.HandleFunc(prefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		if !s.validToken(r.Header.Get("X-Auth-Token")) {
+			writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, f := range s.failures {
+			if (f.trigger == nil || f.armed) && f.matches(r) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("%s %s failed", r.Method, r.URL.Path))
+
+				return
+			}
+		}
+		for i, f := range s.failures {
+			if f.trigger != nil && f.trigger.matches(r) {
+				s.failures[i].armed = true
+			}
+		}
+
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		var parts []string
+		if path != "" {
+			parts = strings.Split(path, "/")
+		}
+		handler(w, r, parts)
+	})
+}
+
+// FailRequests makes the server respond with an error to requests of the
+// method whose path ends with the suffix until ClearFailures is called.
+func (s *Server) FailRequests(method, pathSuffix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{method: method, pathSuffix: pathSuffix})
+}
+
+// FailRequestsAfter works like FailRequests, but the server starts to respond
+// with an error only after it serves a request of the trigger method whose
+// path ends with the trigger suffix.
+func (s *Server) FailRequestsAfter(triggerMethod, triggerPathSuffix, method, pathSuffix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{
+		method:     method,
+		pathSuffix: pathSuffix,
+		trigger:    &failure{method: triggerMethod, pathSuffix: triggerPathSuffix},
+	})
+}
+
+// ClearFailures makes the server respond to all requests again.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// newID returns a new unique identifier in the UUID format.
+func (s *Server) newID() string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.newIntID())
+}
+
+// newIntID returns a new unique numeric identifier.
+func (s *Server) newIntID() int {
+	s.lastID++
+
+	return s.lastID
+}
+
+// newIP returns a new public IPv4 address from the documentation range.
+func (s *Server) newIP() string {
+	return fmt.Sprintf("203.0.113.%d", s.newIntID()%250+1)
+}
+
+// collection returns the named collection of objects creating it if needed.
+func (s *Server) collection(name string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = &collection{items: map[string]object{}}
+		s.collections[name] = c
+	}
+
+	return c
+}
+
+// Snapshot holds the objects of the fake server at some point in time.
+type Snapshot struct {
+	collections map[string]*collection
+}
+
+// Snapshot returns the current objects of the server. Objects deleted after
+// the snapshot is taken can be brought back with Restore.
+func (s *Server) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collections := make(map[string]*collection, len(s.collections))
+	for name, c := range s.collections {
+		items := make(map[string]object, len(c.items))
+		for id, obj := range c.items {
+			items[id] = obj
+		}
+		collections[name] = &collection{items: items, ids: append([]string(nil), c.ids...)}
+	}
+
+	return Snapshot{collections: collections}
+}
+
+// Restore replaces the objects of the server with the snapshot ones.
+// Identifiers issued after the snapshot is taken are never reused.
+func (s *Server) Restore(snapshot Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collections = snapshot.collections
+}
+
+// object is a JSON object of the API.
+type object map[string]interface{}
+
+// clone returns a deep copy of the object so handlers can't change the stored one.
+func (o object) clone() object {
+	var cloned object
+	body, _ := json.Marshal(o)
+	_ = json.Unmarshal(body, &cloned)
+
+	return cloned
+}
+
+// merge sets fields of the object to the non-nil values of the given fields.
+func (o object) merge(fields map[string]interface{}) {
+	for key, value := range fields {
+		if value != nil {
+			o[key] = value
+		}
+	}
+}
+
+func (o object) string(key string) string {
+	v, _ := o[key].(string)
+
+	return v
+}
+
+// asObject returns the JSON object stored in v.
+func asObject(v interface{}) (object, bool) {
+	switch obj := v.(type) {
+	case object:
+		return obj, obj != nil
+	case map[string]interface{}:
+		return object(obj), obj != nil
+	default:
+		return nil, false
+	}
+}
+
+// toInt converts a JSON number to int.
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// collection keeps objects of the same kind in the creation order.
+type collection struct {
+	items map[string]object
+	ids   []string
+}
+
+func (c *collection) get(id string) (object, bool) {
+	obj, ok := c.items[id]
+
+	return obj, ok
+}
+
+func (c *collection) put(id string, obj object) {
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = obj
+}
+
+func (c *collection) delete(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+
+			break
+		}
+	}
+
+	return true
+}
+
+// list returns objects that match the filter in the creation order.
+func (c *collection) list(filter func(object) bool) []object {
+	objects := make([]object, 0, len(c.ids))
+	for _, id := range c.ids {
+		obj := c.items[id]
+		if filter == nil || filter(obj) {
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects
+}
+
+// fieldEquals returns a collection filter that matches objects with the given
+// field value.
+func fieldEquals(key, value string) func(object) bool {
+	return func(obj object) bool {
+		return obj.string(key) == value
+	}
+}
+
+// decodeBody decodes the JSON request body into v.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"title":   http.StatusText(status),
+			"message": message,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not found", kind, id))
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+}
+
+// timestamp returns the current time in the format used by Selectel APIs.
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
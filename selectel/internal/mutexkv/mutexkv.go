@@ -1,8 +1,11 @@
 package mutexkv
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"sync"
+	"time"
 )
 
 // MutexKV is a simple key/value store for arbitrary mutexes. It can be used to
@@ -10,43 +13,93 @@ import (
 // keys they must serialize on.
 //
 // This implementation is copied from v1 Terraform SDK since it was removed
-// from v2 SDK.
+// from v2 SDK. It's extended to wait for a mutex with a timeout and to keep
+// the current holder of the mutex for diagnostics.
 type MutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*mutex
+}
+
+// mutex is a mutex that can be waited for with a timeout.
+type mutex struct {
+	ch     chan struct{}
+	holder string
 }
 
 // Lock the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key.
 func (m *MutexKV) Lock(key string) {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
+	m.get(key).ch <- struct{}{}
 	log.Printf("[DEBUG] Locked %q", key)
 }
 
+// LockWithTimeout locks the mutex for the given key on behalf of the holder.
+// It returns an error that names the current holder of the mutex if the mutex
+// isn't unlocked within the timeout or the context is done. Caller is
+// responsible for calling Unlock for the same key if there is no error.
+func (m *MutexKV) LockWithTimeout(ctx context.Context, key, holder string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Locking %q for %s", key, holder)
+	mu := m.get(key)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case mu.ch <- struct{}{}:
+	case <-timer.C:
+		return fmt.Errorf("timeout after %s waiting for the lock on %q held by %s", timeout, key, m.holder(key))
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for the lock on %q held by %s: %w", key, m.holder(key), ctx.Err())
+	}
+
+	m.lock.Lock()
+	mu.holder = holder
+	m.lock.Unlock()
+	log.Printf("[DEBUG] Locked %q for %s", key, holder)
+
+	return nil
+}
+
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first.
 func (m *MutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	mu := m.get(key)
+	m.lock.Lock()
+	mu.holder = ""
+	m.lock.Unlock()
+	<-mu.ch
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
+// holder returns the current holder of the mutex for the given key.
+func (m *MutexKV) holder(key string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if mu, ok := m.store[key]; ok && mu.holder != "" {
+		return mu.holder
+	}
+
+	return "another operation"
+}
+
 // Returns a mutex for the given key, no guarantee of its lock status.
-func (m *MutexKV) get(key string) *sync.Mutex {
+func (m *MutexKV) get(key string) *mutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	mutex, ok := m.store[key]
+	mu, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
-		m.store[key] = mutex
+		mu = &mutex{ch: make(chan struct{}, 1)}
+		m.store[key] = mu
 	}
 
-	return mutex
+	return mu
 }
 
 // Returns a properly initialized MutexKV.
 func NewMutexKV() *MutexKV {
 	return &MutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*mutex),
 	}
 }
@@ -1,6 +1,8 @@
 package mutexkv
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -65,3 +67,42 @@ func TestMutexKVDifferentKeys(t *testing.T) {
 		t.Fatal("Second lock on a different key blocked. This shouldn't happen.")
 	}
 }
+
+func TestMutexKVLockWithTimeout(t *testing.T) {
+	mkv := NewMutexKV()
+
+	if err := mkv.LockWithTimeout(context.Background(), "foo", "first", 50*time.Millisecond); err != nil {
+		t.Fatalf("First lock wasn't taken: %s", err)
+	}
+
+	err := mkv.LockWithTimeout(context.Background(), "foo", "second", 50*time.Millisecond)
+	if err == nil {
+		t.Fatal("Second lock was able to be taken. This shouldn't happen.")
+	}
+	if !strings.Contains(err.Error(), "held by first") {
+		t.Fatalf("Expected the error to name the lock holder, got: %s", err)
+	}
+
+	mkv.Unlock("foo")
+
+	if err := mkv.LockWithTimeout(context.Background(), "foo", "second", 50*time.Millisecond); err != nil {
+		t.Fatalf("Second lock wasn't taken after unlock: %s", err)
+	}
+}
+
+func TestMutexKVLockWithTimeoutContextDone(t *testing.T) {
+	mkv := NewMutexKV()
+
+	mkv.Lock("foo")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := mkv.LockWithTimeout(ctx, "foo", "second", time.Minute)
+	if err == nil {
+		t.Fatal("Second lock was able to be taken. This shouldn't happen.")
+	}
+	if !strings.Contains(err.Error(), "held by another operation") {
+		t.Fatalf("Expected the error to name an unknown lock holder, got: %s", err)
+	}
+}
@@ -3,7 +3,7 @@ package selectel
 import "fmt"
 
 func msgCreate(object string, options interface{}) string {
-	return fmt.Sprintf("[DEBUG] Creating %s with options: %+v", object, options)
+	return fmt.Sprintf("[DEBUG] Creating %s with options: %+v", object, redactOptions(options))
 }
 
 func msgGet(object, id string) string {
@@ -11,7 +11,7 @@ func msgGet(object, id string) string {
 }
 
 func msgUpdate(object, id string, options interface{}) string {
-	return fmt.Sprintf("[DEBUG] Updating %s '%s' with options: %+v", object, id, options)
+	return fmt.Sprintf("[DEBUG] Updating %s '%s' with options: %+v", object, id, redactOptions(options))
 }
 
 func msgDelete(object, id string) string {
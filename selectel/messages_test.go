@@ -3,6 +3,7 @@ package selectel
 import (
 	"testing"
 
+	"github.com/selectel/dbaas-go"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/keypairs"
 	"github.com/stretchr/testify/assert"
 )
@@ -50,3 +51,30 @@ func TestMsgDelete(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestMsgCreateRedactsSensitiveFields(t *testing.T) {
+	expected := "[DEBUG] Creating user with options: {Name:user Password:[REDACTED] DatastoreID:123}"
+
+	actual := msgCreate(objectUser, dbaas.UserCreateOpts{
+		Name:        "user",
+		Password:    "secret-password",
+		DatastoreID: "123",
+	})
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestMsgUpdateRedactsSensitiveFields(t *testing.T) {
+	opts := &dbaas.DatastoreCreateOpts{
+		Name:          "redis",
+		RedisPassword: "secret-password",
+		Config:        map[string]interface{}{"maxmemory-policy": "noeviction"},
+	}
+
+	actual := msgUpdate(objectDatastore, "123", opts)
+
+	assert.Contains(t, actual, "RedisPassword:[REDACTED]")
+	assert.Contains(t, actual, "maxmemory-policy:noeviction")
+	assert.NotContains(t, actual, "secret-password")
+	assert.Equal(t, "secret-password", opts.RedisPassword)
+}
@@ -1,17 +1,26 @@
 package selectel
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/go-selvpcclient/v3/selvpcclient"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/quotamanager/quotas"
 	v1 "github.com/selectel/mks-go/pkg/v1"
 	"github.com/selectel/mks-go/pkg/v1/cluster"
@@ -19,6 +28,7 @@ import (
 	"github.com/selectel/mks-go/pkg/v1/kubeversion"
 	"github.com/selectel/mks-go/pkg/v1/node"
 	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"gopkg.in/yaml.v3"
 )
 
 func waitForMKSClusterV1ActiveState(
@@ -41,8 +51,8 @@ func waitForMKSClusterV1ActiveState(
 		Target:     target,
 		Refresh:    mksClusterV1StateRefreshFunc(ctx, client, clusterID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -140,12 +150,9 @@ func mksClusterV1KubeVersionDiffSuppressFunc(_, old, new string, d *schema.Resou
 	return false
 }
 
-func mksClusterV1GetLatestPatchVersions(ctx context.Context, client *v1.ServiceClient) (map[string]string, error) {
-	kubeVersions, _, err := kubeversion.List(ctx, client)
-	if err != nil {
-		return nil, err
-	}
-
+// mksClusterV1LatestPatchVersions returns the latest patch version of every
+// minor version.
+func mksClusterV1LatestPatchVersions(kubeVersions []*kubeversion.View) (map[string]string, error) {
 	result := map[string]string{}
 
 	for _, version := range kubeVersions {
@@ -171,25 +178,6 @@ func mksClusterV1GetLatestPatchVersions(ctx context.Context, client *v1.ServiceC
 	return result, nil
 }
 
-// checkVersionIsSupported check that desired k8s version is supported.
-func checkVersionIsSupported(kubeVersions []*kubeversion.View, desiredMinorVersion string) (bool, error) {
-	versions := map[string]struct{}{}
-	for _, version := range kubeVersions {
-		ver, err := kubeVersionTrimToMinor(version.Version)
-		if err != nil {
-			return false, errors.New("can't get minor version")
-		}
-		versions[ver] = struct{}{}
-	}
-
-	// Check that version is supported.
-	if _, ok := versions[desiredMinorVersion]; ok {
-		return true, nil
-	}
-
-	return false, nil
-}
-
 func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData, client *v1.ServiceClient) error {
 	oldVersion, newVersion := d.GetChange("kube_version")
 	currentVersion := oldVersion.(string)
@@ -203,111 +191,566 @@ func upgradeMKSClusterV1KubeVersion(ctx context.Context, d *schema.ResourceData,
 		return err
 	}
 
-	// Compare current and desired major versions.
+	sequential := d.Get("sequential_minor_upgrade").(bool)
+	upgradePath, err := mksClusterV1KubeVersionUpgradePath(kubeVersions, currentVersion, desiredVersion, sequential)
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if len(upgradePath) == 0 {
+		log.Print("[DEBUG] upgrading patch version")
+		_, _, err = cluster.UpgradePatchVersion(ctx, client, d.Id())
+		if err != nil {
+			return fmt.Errorf("error upgrading patch version: %s", err)
+		}
+
+		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
+		err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), timeout)
+		if err != nil {
+			return fmt.Errorf("error waiting for the patch version upgrade: %s", err)
+		}
+
+		return nil
+	}
+
+	// Every step upgrades the cluster to the latest patch version of the
+	// next minor version.
+	for _, minorVersion := range upgradePath {
+		log.Printf("[DEBUG] upgrading minor version to %s", minorVersion)
+		_, _, err = cluster.UpgradeMinorVersion(ctx, client, d.Id())
+		if err != nil {
+			return fmt.Errorf("error upgrading minor version to %s: %s", minorVersion, err)
+		}
+
+		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
+		err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), timeout)
+		if err != nil {
+			return fmt.Errorf("error waiting for the minor version upgrade to %s: %s", minorVersion, err)
+		}
+	}
+
+	return nil
+}
+
+// mksClusterV1KubeVersionUpgradePath validates the upgrade from the current
+// Kubernetes version to the desired one and returns the minor versions the
+// cluster is upgraded through, the desired one included.
+// The path is empty in case of a patch version upgrade, which is allowed only
+// to the latest patch version of the minor version.
+func mksClusterV1KubeVersionUpgradePath(
+	kubeVersions []*kubeversion.View, currentVersion, desiredVersion string, sequential bool,
+) ([]string, error) {
 	currentMajor, err := kubeVersionToMajor(currentVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a major part of the current version %s: %s", currentVersion, err)
+		return nil, fmt.Errorf("error getting a major part of the current version %s: %s", currentVersion, err)
 	}
 	desiredMajor, err := kubeVersionToMajor(desiredVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a major part of the desired version %s: %s", desiredVersion, err)
+		return nil, fmt.Errorf("error getting a major part of the desired version %s: %s", desiredVersion, err)
 	}
 	if desiredMajor != currentMajor {
-		return fmt.Errorf("current version %s can't be upgraded to version %s", currentVersion, desiredVersion)
+		return nil, fmt.Errorf("current version %s can't be upgraded to version %s", currentVersion, desiredVersion)
 	}
 
-	// Compare current and desired minor versions.
-	currentMinor, err := kubeVersionTrimToMinor(currentVersion)
+	currentMinor, err := kubeVersionToMinor(currentVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a minor part of the current version %s: %s", currentVersion, err)
+		return nil, fmt.Errorf("error getting a minor part of the current version %s: %s", currentVersion, err)
 	}
-	desiredMinor, err := kubeVersionTrimToMinor(desiredVersion)
+	desiredMinor, err := kubeVersionToMinor(desiredVersion)
 	if err != nil {
-		return fmt.Errorf("error getting a minor part of the desired version %s: %s", desiredVersion, err)
+		return nil, fmt.Errorf("error getting a minor part of the desired version %s: %s", desiredVersion, err)
 	}
-	if desiredMinor != currentMinor {
-		log.Print("[DEBUG] upgrading minor version")
 
-		latestMinorVersion, err := parseMKSKubeVersionsV1Latest(kubeVersions)
+	availableVersions := make(map[string]struct{}, len(kubeVersions))
+	availableMinors := make(map[string]struct{}, len(kubeVersions))
+	for _, version := range kubeVersions {
+		minor, err := kubeVersionTrimToMinor(version.Version)
 		if err != nil {
-			return fmt.Errorf("can't find latest minor version: %s", err)
+			return nil, err
 		}
+		availableVersions[version.Version] = struct{}{}
+		availableMinors[minor] = struct{}{}
+	}
+	available := strings.Join(flattenMKSKubeVersionsV1(kubeVersions), ", ")
 
-		if latestMinorVersion == currentMinor {
-			return fmt.Errorf("the cluster is already on the latest available minor version: %s", currentMinor)
+	// The patch part of the desired version is optional.
+	if _, err := kubeVersionToPatch(desiredVersion); err == nil {
+		if _, ok := availableVersions[strings.TrimPrefix(desiredVersion, "v")]; !ok {
+			return nil, fmt.Errorf("kubernetes version %s is not available, available versions: %s", desiredVersion, available)
 		}
+	}
 
-		// Increment minor version.
-		currentMinorNew, err := kubeVersionTrimToMinorIncremented(currentVersion)
+	if desiredMinor > currentMinor+1 && !sequential {
+		return nil, fmt.Errorf("invalid minor version: %d.%d, kubernetes versions must be upgraded one by one, "+
+			"set \"sequential_minor_upgrade\" to upgrade through every minor version in between", desiredMajor, desiredMinor)
+	}
+	if desiredMinor < currentMinor {
+		return nil, fmt.Errorf("current version %s can't be downgraded to version %s", currentVersion, desiredVersion)
+	}
+	if desiredMinor == currentMinor {
+		// Versions without a patch part or with an older patch version, for
+		// example, after the patch version auto-upgrade, keep the current one.
+		desiredPatch, err := kubeVersionToPatch(desiredVersion)
 		if err != nil {
-			return fmt.Errorf("error getting incremented minor part of the current version %s: %s", currentVersion, err)
+			return nil, nil
 		}
-
-		// Check that next minor version is equal to desired version.
-		if currentMinorNew != desiredMinor {
-			return fmt.Errorf("invalid minor version: %s, kubernetes versions must be upgraded one by one", desiredMinor)
+		if currentPatch, err := kubeVersionToPatch(currentVersion); err == nil && desiredPatch <= currentPatch {
+			return nil, nil
 		}
 
-		// Check that new minor version is supported.
-		isSupported, err := checkVersionIsSupported(kubeVersions, desiredVersion)
+		// Patch versions are upgraded only to the latest one of the minor version.
+		latestPatchVersions, err := mksClusterV1LatestPatchVersions(kubeVersions)
 		if err != nil {
-			return fmt.Errorf("can't check support for version: %s", err)
+			return nil, fmt.Errorf("error getting latest patch versions: %s", err)
 		}
+		minorVersion := fmt.Sprintf("%d.%d", desiredMajor, desiredMinor)
+		latestVersion, ok := latestPatchVersions[minorVersion]
+		if !ok {
+			return nil, fmt.Errorf("unable to find the latest patch version for the current minor version %s", minorVersion)
+		}
+		if strings.TrimPrefix(desiredVersion, "v") != latestVersion {
+			return nil, fmt.Errorf(
+				"current version %s can't be upgraded to version %s, the latest available patch version is: %s",
+				currentVersion, desiredVersion, latestVersion)
+		}
+
+		return nil, nil
+	}
 
-		if !isSupported {
-			log.Print("[INFO] cluster will be upgrade to unsupported minor version. Patch version will be selected automatically.")
+	var upgradePath []string
+	for minor := currentMinor + 1; minor <= desiredMinor; minor++ {
+		minorVersion := fmt.Sprintf("%d.%d", desiredMajor, minor)
+		if _, ok := availableMinors[minorVersion]; !ok {
+			return nil, fmt.Errorf("kubernetes version %s is not available, available versions: %s", minorVersion, available)
 		}
+		upgradePath = append(upgradePath, minorVersion)
+	}
 
-		_, _, err = cluster.UpgradeMinorVersion(ctx, client, d.Id())
+	return upgradePath, nil
+}
+
+// mksClusterV1KubeVersionDiff validates a kube_version upgrade of an existing
+// cluster against Kubernetes versions available in MKS.
+func mksClusterV1KubeVersionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("kube_version") || !d.NewValueKnown("kube_version") {
+		return nil
+	}
+
+	// The cluster is recreated in a new project or region, so there is nothing to upgrade.
+	if d.HasChange("project_id") || d.HasChange("region") {
+		return nil
+	}
+
+	mksClient, err := newMKSClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+
+	kubeVersions, _, err := kubeversion.List(ctx, mksClient)
+	if err != nil {
+		return errGettingObjects(objectKubeVersions, err)
+	}
+
+	oldVersion, newVersion := d.GetChange("kube_version")
+	sequential := d.Get("sequential_minor_upgrade").(bool)
+	upgradePath, err := mksClusterV1KubeVersionUpgradePath(kubeVersions, oldVersion.(string), newVersion.(string), sequential)
+	if err != nil || len(upgradePath) == 0 {
+		return err
+	}
+	if !d.NewValueKnown(featureGatesKey) || !d.NewValueKnown(admissionControllersKey) {
+		return nil
+	}
+
+	// Diagnostics can't be returned from CustomizeDiff, so feature gates and
+	// admission controllers unavailable in the new version are logged here
+	// and reported as warnings once the cluster is upgraded.
+	desiredMinor := upgradePath[len(upgradePath)-1]
+	warnings, err := mksClusterV1KubeOptionsWarnings(ctx, mksClient, desiredMinor,
+		expandMKSClusterV1KubeOptionNames(d.Get(featureGatesKey)),
+		expandMKSClusterV1KubeOptionNames(d.Get(admissionControllersKey)))
+	if err != nil {
+		log.Printf("[DEBUG] unable to check kubernetes options of the cluster %s: %s", d.Id(), err)
+
+		return nil
+	}
+	for _, warning := range warnings {
+		log.Printf("[WARN] cluster %s: %s", d.Id(), warning)
+	}
+
+	return nil
+}
+
+// expandMKSClusterV1KubeOptionNames returns names of the feature gates or
+// admission controllers from the set.
+func expandMKSClusterV1KubeOptionNames(v interface{}) []string {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, set.Len())
+	for _, name := range set.List() {
+		names = append(names, name.(string))
+	}
+
+	return names
+}
+
+// mksClusterV1KubeOptionsWarnings returns warnings about feature gates and
+// admission controllers that are not available in the minor Kubernetes version.
+func mksClusterV1KubeOptionsWarnings(
+	ctx context.Context, client *v1.ServiceClient, minorVersion string, featureGates, admissionControllers []string,
+) ([]string, error) {
+	var warnings []string
+
+	if len(featureGates) > 0 {
+		options, _, err := kubeoptions.ListFeatureGates(ctx, client)
+		if err != nil {
+			return nil, errGettingObjects(objectFeatureGates, err)
+		}
+		unavailable, err := mksClusterV1UnavailableKubeOptions(options, minorVersion, featureGates)
 		if err != nil {
-			return fmt.Errorf("error upgrading minor version: %s", err)
+			return nil, err
 		}
+		if len(unavailable) > 0 {
+			warnings = append(warnings, fmt.Sprintf("feature gates %s are not available in kubernetes version %s",
+				strings.Join(unavailable, ", "), minorVersion))
+		}
+	}
 
-		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
-		timeout := d.Timeout(schema.TimeoutUpdate)
-		err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), timeout)
+	if len(admissionControllers) > 0 {
+		options, _, err := kubeoptions.ListAdmissionControllers(ctx, client)
 		if err != nil {
-			return fmt.Errorf("error waiting for the minor version upgrade: %s", err)
+			return nil, errGettingObjects(objectAdmissionControllers, err)
 		}
+		unavailable, err := mksClusterV1UnavailableKubeOptions(options, minorVersion, admissionControllers)
+		if err != nil {
+			return nil, err
+		}
+		if len(unavailable) > 0 {
+			warnings = append(warnings, fmt.Sprintf("admission controllers %s are not available in kubernetes version %s",
+				strings.Join(unavailable, ", "), minorVersion))
+		}
+	}
+
+	return warnings, nil
+}
 
+// mksClusterV1KubeOptionsDiagnostics returns warnings about feature gates and
+// admission controllers of the cluster that are not available in the new
+// minor Kubernetes version.
+func mksClusterV1KubeOptionsDiagnostics(ctx context.Context, d *schema.ResourceData, client *v1.ServiceClient) diag.Diagnostics {
+	oldVersion, newVersion := d.GetChange("kube_version")
+	currentMinor, err := kubeVersionTrimToMinor(oldVersion.(string))
+	if err != nil {
+		return nil
+	}
+	desiredMinor, err := kubeVersionTrimToMinor(newVersion.(string))
+	if err != nil || desiredMinor == currentMinor {
 		return nil
 	}
 
-	log.Print("[DEBUG] upgrading patch version")
+	featureGates, err := getSetAsStrings(d, featureGatesKey)
+	if err != nil {
+		return nil
+	}
+	admissionControllers, err := getSetAsStrings(d, admissionControllersKey)
+	if err != nil {
+		return nil
+	}
 
-	// Get the latest patch versions for every minor version.
-	latestPatchVersions, err := mksClusterV1GetLatestPatchVersions(ctx, client)
+	warnings, err := mksClusterV1KubeOptionsWarnings(ctx, client, desiredMinor, featureGates, admissionControllers)
 	if err != nil {
-		return fmt.Errorf("error getting latest patch versions: %s", err)
+		log.Printf("[DEBUG] unable to check kubernetes options of the cluster %s: %s", d.Id(), err)
+
+		return nil
 	}
 
-	// Find the latest patch version corresponding to the current minor version.
-	latestVersion, ok := latestPatchVersions[currentMinor]
-	if !ok {
-		return fmt.Errorf("unable to find the latest patch version for the current minor version %s", currentMinor)
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  warning,
+		})
 	}
 
-	log.Printf("[DEBUG] latest kube version: %s", latestVersion)
+	return diags
+}
 
-	if desiredVersion != latestVersion {
-		return fmt.Errorf(
-			"current version %s can't be upgraded to version %s, the latest available patch version is: %s",
-			currentVersion, desiredVersion, latestVersion)
+// mksClusterV1UnavailableKubeOptions returns names of the options that are not
+// available in the minor Kubernetes version.
+func mksClusterV1UnavailableKubeOptions(options []*kubeoptions.View, minorVersion string, names []string) ([]string, error) {
+	availableNames, err := filterKubeOptionsByKubeVersion(options, minorVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	_, _, err = cluster.UpgradePatchVersion(ctx, client, d.Id())
+	available := make(map[string]struct{}, len(availableNames))
+	for _, name := range availableNames {
+		available[name] = struct{}{}
+	}
+
+	var unavailable []string
+	for _, name := range names {
+		if _, ok := available[name]; !ok {
+			unavailable = append(unavailable, name)
+		}
+	}
+	sort.Strings(unavailable)
+
+	return unavailable, nil
+}
+
+// mksClusterV1OIDC represents options of the API server OIDC authentication
+// that aren't supported by mks-go yet.
+type mksClusterV1OIDC struct {
+	Enabled       bool   `json:"enabled"`
+	ProviderName  string `json:"provider_name,omitempty"`
+	IssuerURL     string `json:"issuer_url,omitempty"`
+	ClientID      string `json:"client_id,omitempty"`
+	UsernameClaim string `json:"username_claim,omitempty"`
+	GroupsClaim   string `json:"groups_claim,omitempty"`
+}
+
+// mksClusterV1AuditLogs represents options of the API server audit logging
+// that aren't supported by mks-go yet.
+type mksClusterV1AuditLogs struct {
+	Enabled    bool   `json:"enabled"`
+	SecretName string `json:"secret_name,omitempty"`
+}
+
+// mksClusterV1KubernetesOptions represents Kubernetes options of the cluster
+// with OIDC authentication and audit logging.
+type mksClusterV1KubernetesOptions struct {
+	cluster.KubernetesOptions
+	OIDC      *mksClusterV1OIDC      `json:"oidc,omitempty"`
+	AuditLogs *mksClusterV1AuditLogs `json:"audit_logs,omitempty"`
+}
+
+// mksClusterV1CreateOpts represents options for the cluster Create request
+// with all Kubernetes options of the cluster.
+type mksClusterV1CreateOpts struct {
+	cluster.CreateOpts
+	KubernetesOptions *mksClusterV1KubernetesOptions `json:"kubernetes_options,omitempty"`
+}
+
+// mksClusterV1UpdateOpts represents options for the cluster Update request
+// with all Kubernetes options of the cluster.
+type mksClusterV1UpdateOpts struct {
+	cluster.UpdateOpts
+	KubernetesOptions *mksClusterV1KubernetesOptions `json:"kubernetes_options,omitempty"`
+}
+
+// mksClusterV1View represents an unmarshalled cluster body from an API
+// response with all Kubernetes options of the cluster.
+type mksClusterV1View struct {
+	cluster.View
+	KubernetesOptions *mksClusterV1KubernetesOptions `json:"kubernetes_options,omitempty"`
+}
+
+// UnmarshalJSON unmarshals the cluster with cluster.View.UnmarshalJSON, which
+// is promoted to mksClusterV1View and would skip its Kubernetes options.
+func (result *mksClusterV1View) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &result.View); err != nil {
+		return err
+	}
+
+	var s struct {
+		KubernetesOptions *mksClusterV1KubernetesOptions `json:"kubernetes_options"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	result.KubernetesOptions = s.KubernetesOptions
+
+	return nil
+}
+
+// doMKSV1Request sends a request with the JSON body to the MKS API and
+// extracts the response body to the result if it isn't nil. It's used for
+// the options that aren't supported by mks-go yet.
+func doMKSV1Request(ctx context.Context, client *v1.ServiceClient, method, url string, body, result interface{}) (*v1.ResponseResult, error) {
+	var requestBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewReader(b)
+	}
+
+	responseResult, err := client.DoRequest(ctx, method, url, requestBody)
 	if err != nil {
-		return fmt.Errorf("error upgrading patch version: %s", err)
+		return nil, err
+	}
+	if responseResult.Err != nil {
+		return responseResult, responseResult.Err
+	}
+	if result != nil {
+		if err := responseResult.ExtractResult(result); err != nil {
+			return responseResult, err
+		}
 	}
 
-	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
-	timeout := d.Timeout(schema.TimeoutUpdate)
-	err = waitForMKSClusterV1ActiveState(ctx, client, d.Id(), timeout)
+	return responseResult, nil
+}
+
+// createMKSClusterV1Request requests a creation of a new cluster like
+// cluster.Create does, but with all Kubernetes options of the cluster.
+func createMKSClusterV1Request(ctx context.Context, client *v1.ServiceClient, opts *mksClusterV1CreateOpts) (*mksClusterV1View, *v1.ResponseResult, error) {
+	body := struct {
+		Cluster *mksClusterV1CreateOpts `json:"cluster"`
+	}{
+		Cluster: opts,
+	}
+	var result struct {
+		Cluster *mksClusterV1View `json:"cluster"`
+	}
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster}, "/")
+	responseResult, err := doMKSV1Request(ctx, client, http.MethodPost, url, body, &result)
 	if err != nil {
-		return fmt.Errorf("error waiting for the patch version upgrade: %s", err)
+		return nil, responseResult, err
 	}
 
-	return nil
+	return result.Cluster, responseResult, nil
+}
+
+// updateMKSClusterV1Request requests an update of the cluster like
+// cluster.Update does, but with all Kubernetes options of the cluster.
+func updateMKSClusterV1Request(ctx context.Context, client *v1.ServiceClient, clusterID string, opts *mksClusterV1UpdateOpts) (*v1.ResponseResult, error) {
+	body := struct {
+		Cluster *mksClusterV1UpdateOpts `json:"cluster"`
+	}{
+		Cluster: opts,
+	}
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster, clusterID}, "/")
+
+	return doMKSV1Request(ctx, client, http.MethodPut, url, body, nil)
+}
+
+// getMKSClusterV1 returns a cluster by its id like cluster.Get does, but with
+// all Kubernetes options of the cluster.
+func getMKSClusterV1(ctx context.Context, client *v1.ServiceClient, clusterID string) (*mksClusterV1View, *v1.ResponseResult, error) {
+	var result struct {
+		Cluster *mksClusterV1View `json:"cluster"`
+	}
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster, clusterID}, "/")
+	responseResult, err := doMKSV1Request(ctx, client, http.MethodGet, url, nil, &result)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return result.Cluster, responseResult, nil
+}
+
+// expandMKSClusterV1KubernetesOptions returns all Kubernetes options of the
+// cluster. OIDC authentication and audit logging are explicitly disabled if
+// their blocks aren't set, so removing a block disables them on update.
+func expandMKSClusterV1KubernetesOptions(d *schema.ResourceData) (*mksClusterV1KubernetesOptions, error) {
+	featureGates, err := getSetAsStrings(d, featureGatesKey)
+	if err != nil {
+		return nil, err
+	}
+	admissionControllers, err := getSetAsStrings(d, admissionControllersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mksClusterV1KubernetesOptions{
+		KubernetesOptions: cluster.KubernetesOptions{
+			EnablePodSecurityPolicy: d.Get("enable_pod_security_policy").(bool),
+			FeatureGates:            featureGates,
+			AdmissionControllers:    admissionControllers,
+		},
+		OIDC:      expandMKSClusterV1OIDC(d.Get("oidc").([]interface{})),
+		AuditLogs: expandMKSClusterV1AuditLogs(d.Get("audit_logs").([]interface{})),
+	}, nil
+}
+
+func expandMKSClusterV1OIDC(v []interface{}) *mksClusterV1OIDC {
+	if len(v) == 0 || v[0] == nil {
+		return &mksClusterV1OIDC{Enabled: false}
+	}
+	oidc := v[0].(map[string]interface{})
+
+	return &mksClusterV1OIDC{
+		Enabled:       true,
+		ProviderName:  oidc["provider_name"].(string),
+		IssuerURL:     oidc["issuer_url"].(string),
+		ClientID:      oidc["client_id"].(string),
+		UsernameClaim: oidc["username_claim"].(string),
+		GroupsClaim:   oidc["groups_claim"].(string),
+	}
+}
+
+func expandMKSClusterV1AuditLogs(v []interface{}) *mksClusterV1AuditLogs {
+	if len(v) == 0 {
+		return &mksClusterV1AuditLogs{Enabled: false}
+	}
+	auditLogs := &mksClusterV1AuditLogs{Enabled: true}
+	if v[0] != nil {
+		auditLogs.SecretName = v[0].(map[string]interface{})["secret_name"].(string)
+	}
+
+	return auditLogs
+}
+
+func flattenMKSClusterV1OIDC(oidc *mksClusterV1OIDC) []interface{} {
+	if oidc == nil || !oidc.Enabled {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"provider_name":  oidc.ProviderName,
+			"issuer_url":     oidc.IssuerURL,
+			"client_id":      oidc.ClientID,
+			"username_claim": oidc.UsernameClaim,
+			"groups_claim":   oidc.GroupsClaim,
+		},
+	}
+}
+
+func flattenMKSClusterV1AuditLogs(auditLogs *mksClusterV1AuditLogs) []interface{} {
+	if auditLogs == nil || !auditLogs.Enabled {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"secret_name": auditLogs.SecretName,
+		},
+	}
+}
+
+// expandMKSClusterV1UpdateOpts returns update options with the changed fields
+// of the cluster only. Kubernetes options are replaced as a whole by the API,
+// so all of them are sent if any of them has changed.
+func expandMKSClusterV1UpdateOpts(d *schema.ResourceData) (mksClusterV1UpdateOpts, error) {
+	var updateOpts mksClusterV1UpdateOpts
+	if d.HasChange("maintenance_window_start") {
+		updateOpts.MaintenanceWindowStart = d.Get("maintenance_window_start").(string)
+	}
+	if d.HasChange("enable_autorepair") {
+		v := d.Get("enable_autorepair").(bool)
+		updateOpts.EnableAutorepair = &v
+	}
+	if d.HasChange("enable_patch_version_auto_upgrade") {
+		v := d.Get("enable_patch_version_auto_upgrade").(bool)
+		updateOpts.EnablePatchVersionAutoUpgrade = &v
+	}
+
+	if !d.HasChanges("enable_pod_security_policy", featureGatesKey, admissionControllersKey, "oidc", "audit_logs") {
+		return updateOpts, nil
+	}
+
+	kubeOptions, err := expandMKSClusterV1KubernetesOptions(d)
+	if err != nil {
+		return mksClusterV1UpdateOpts{}, err
+	}
+	updateOpts.KubernetesOptions = kubeOptions
+
+	return updateOpts, nil
 }
 
 // kubeVersionToMajor returns given Kubernetes version major part.
@@ -438,6 +881,31 @@ func mksNodegroupV1ParseID(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// setMKSNodegroupV1FlavorResources sets CPUs and RAM of the nodegroup nodes
+// from the compute flavor. Errors are only logged since the flavor can be
+// unavailable for the user while the nodegroup itself is readable.
+func setMKSNodegroupV1FlavorResources(d *schema.ResourceData, meta interface{}, flavorID string) {
+	if flavorID == "" {
+		return
+	}
+
+	computeClient, diagErr := getComputeClient(d, meta)
+	if diagErr != nil {
+		log.Printf("[DEBUG] can't get compute client to read %s %s: %s", objectFlavor, flavorID, diagErr[0].Summary)
+		return
+	}
+
+	log.Print(msgGet(objectFlavor, flavorID))
+	flavor, err := flavors.Get(computeClient, flavorID).Extract()
+	if err != nil {
+		log.Printf("[DEBUG] %s", errGettingObject(objectFlavor, flavorID, err))
+		return
+	}
+
+	d.Set("cpus", flavor.VCPUs)
+	d.Set("ram_mb", flavor.RAM)
+}
+
 func flattenMKSNodegroupV1Nodes(views []*node.View) []map[string]interface{} {
 	nodes := make([]map[string]interface{}, len(views))
 	for i, view := range views {
@@ -541,25 +1009,42 @@ func expandMKSNodegroupV1Labels(labels map[string]interface{}) map[string]string
 }
 
 func getMKSClient(d *schema.ResourceData, meta interface{}) (*v1.ServiceClient, diag.Diagnostics) {
+	mksClient, err := newMKSClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return mksClient, nil
+}
+
+func newMKSClient(meta interface{}, projectID, region string) (*v1.ServiceClient, error) {
 	config := meta.(*Config)
-	projectID := d.Get("project_id").(string)
-	region := d.Get("region").(string)
 
 	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for mks: %w", err))
+		return nil, fmt.Errorf("can't get project-scope selvpc client for mks: %w", err)
 	}
-	err = validateRegion(selvpcClient, MKS, region)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't validate region: %w", err))
+
+	endpoint, ok := config.endpointOverride(MKS)
+	if !ok {
+		err = validateRegion(selvpcClient, MKS, region)
+		if err != nil {
+			return nil, fmt.Errorf("can't validate region: %w", err)
+		}
+
+		catalogEndpoint, err := selvpcClient.Catalog.GetEndpoint(MKS, region)
+		if err != nil {
+			return nil, fmt.Errorf("can't get endpoint to init mks client: %w", err)
+		}
+		endpoint = catalogEndpoint.URL
 	}
 
-	endpoint, err := selvpcClient.Catalog.GetEndpoint(MKS, region)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init mks client: %w", err))
+		return nil, fmt.Errorf("can't get token to init mks client: %w", err)
 	}
 
-	mksClient := v1.NewMKSClientV1(selvpcClient.GetXAuthToken(), endpoint.URL)
+	mksClient := v1.NewMKSClientV1WithCustomHTTP(config.newHTTPClient(projectID), token, endpoint)
 
 	return mksClient, nil
 }
@@ -696,28 +1181,11 @@ func checkQuotasForNodegroup(projectQuotas []*quotas.Quota, nodegroupOpts *nodeg
 		return errors.New("unable to find RAM quota")
 	}
 
-	var (
-		volumeQuota []quotas.ResourceQuotaEntity
-		volumeType  string
-	)
-	if nodegroupOpts.LocalVolume {
-		volumeQuota = findQuota(projectQuotas, "volume_gigabytes_local")
-		volumeType = "local"
-	} else {
-		switch strings.Split(nodegroupOpts.VolumeType, ".")[0] {
-		case "fast":
-			volumeQuota = findQuota(projectQuotas, "volume_gigabytes_fast")
-			volumeType = "fast"
-		case "universal":
-			volumeQuota = findQuota(projectQuotas, "volume_gigabytes_universal")
-			volumeType = "universal"
-		case "basic":
-			volumeQuota = findQuota(projectQuotas, "volume_gigabytes_basic")
-			volumeType = "basic"
-		default:
-			return fmt.Errorf("expected 'fast.<zone>', 'universal.<zone>' or 'basic.<zone>' volume type, got: %s", nodegroupOpts.VolumeType)
-		}
+	volumeType, err := mksNodegroupV1VolumeQuotaType(nodegroupOpts.LocalVolume, nodegroupOpts.VolumeType)
+	if err != nil {
+		return err
 	}
+	volumeQuota := findQuota(projectQuotas, "volume_gigabytes_"+volumeType)
 	if volumeQuota == nil {
 		return errors.New("unable to find volume quota")
 	}
@@ -763,3 +1231,1128 @@ func checkQuotasForNodegroup(projectQuotas []*quotas.Quota, nodegroupOpts *nodeg
 
 	return nil
 }
+
+// mksNodegroupV1VolumeQuotaType returns the type of the volume quota that is
+// used by the nodes with the given volume.
+func mksNodegroupV1VolumeQuotaType(localVolume bool, volumeType string) (string, error) {
+	if localVolume {
+		return "local", nil
+	}
+
+	switch quotaType := strings.Split(volumeType, ".")[0]; quotaType {
+	case "fast", "universal", "basic":
+		return quotaType, nil
+	default:
+		return "", fmt.Errorf("expected 'fast.<zone>', 'universal.<zone>' or 'basic.<zone>' volume type, got: %s", volumeType)
+	}
+}
+
+// mksNodegroupV1QuotaDemand represents the resources that new nodes of the
+// nodegroup take from the project quotas.
+type mksNodegroupV1QuotaDemand struct {
+	AvailabilityZone string
+	VolumeType       string
+	LocalVolume      bool
+	FlavorID         string
+	Count            int
+	CPUs             int
+	RAMMB            int
+	VolumeGB         int
+}
+
+// mksNodegroupV1QuotaReservation is the reservation of new nodes of a
+// nodegroup. Existing nodegroups are identified by their ID. New nodegroups
+// have no identity at plan time, so they are identified by their arguments,
+// and reservations of new nodegroups with the same arguments are
+// interchangeable.
+type mksNodegroupV1QuotaReservation struct {
+	NodegroupID string
+	Arguments   mksNodegroupV1QuotaDemand
+	Demand      mksNodegroupV1QuotaDemand
+}
+
+// mksQuotaReservations keeps the clusters and nodegroups that are planned by
+// the provider instance and not created yet, so the plan-time quota check of
+// one of them counts all of them. Every resource has one reservation that is
+// replaced when the resource is planned again. Create and Update release
+// reservations as soon as the API accepts their requests, so the resources
+// that are already being created during apply are counted by the used quotas
+// only.
+type mksQuotaReservations struct {
+	lock       sync.Mutex
+	clusters   map[string]map[string]bool
+	nodegroups map[string][]mksNodegroupV1QuotaReservation
+}
+
+// reserveCluster reserves a cluster with the name in the project and region
+// and returns the number of reserved clusters of the same type.
+func (r *mksQuotaReservations) reserveCluster(key, name string, zonal bool) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.clusters == nil {
+		r.clusters = make(map[string]map[string]bool)
+	}
+	if r.clusters[key] == nil {
+		r.clusters[key] = make(map[string]bool)
+	}
+	r.clusters[key][name] = zonal
+
+	var count int
+	for _, z := range r.clusters[key] {
+		if z == zonal {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r *mksQuotaReservations) releaseCluster(key, name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.clusters[key], name)
+}
+
+// reserveNodegroup reserves new nodes of a nodegroup in the project and
+// region and returns all reserved nodes there.
+func (r *mksQuotaReservations) reserveNodegroup(key string, reservation mksNodegroupV1QuotaReservation) []mksNodegroupV1QuotaDemand {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.nodegroups == nil {
+		r.nodegroups = make(map[string][]mksNodegroupV1QuotaReservation)
+	}
+	if i := r.findNodegroup(key, reservation); i >= 0 && reservation.NodegroupID != "" {
+		r.nodegroups[key][i] = reservation
+	} else {
+		r.nodegroups[key] = append(r.nodegroups[key], reservation)
+	}
+
+	demands := make([]mksNodegroupV1QuotaDemand, 0, len(r.nodegroups[key]))
+	for _, reserved := range r.nodegroups[key] {
+		demands = append(demands, reserved.Demand)
+	}
+
+	return demands
+}
+
+func (r *mksQuotaReservations) releaseNodegroup(key string, reservation mksNodegroupV1QuotaReservation) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if i := r.findNodegroup(key, reservation); i >= 0 {
+		r.nodegroups[key] = append(r.nodegroups[key][:i], r.nodegroups[key][i+1:]...)
+	}
+}
+
+// findNodegroup returns the index of the reservation of the same existing
+// nodegroup or of a new nodegroup with the same arguments, or -1.
+func (r *mksQuotaReservations) findNodegroup(key string, reservation mksNodegroupV1QuotaReservation) int {
+	for i, reserved := range r.nodegroups[key] {
+		if reserved.NodegroupID != reservation.NodegroupID {
+			continue
+		}
+		if reservation.NodegroupID != "" || reserved.Arguments == reservation.Arguments {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// mksResourceChange is implemented by both schema.ResourceData and
+// schema.ResourceDiff, so quota demands are the same at plan and apply time.
+type mksResourceChange interface {
+	Id() string
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+}
+
+func mksQuotaReservationKey(d mksResourceChange) string {
+	return d.Get("project_id").(string) + "/" + d.Get("region").(string)
+}
+
+// expandMKSNodegroupV1QuotaReservation returns the reservation of the nodes
+// that are added to the nodegroup. Its demand has no CPUs and RAM if the
+// nodegroup sets only the flavor.
+func expandMKSNodegroupV1QuotaReservation(d mksResourceChange) mksNodegroupV1QuotaReservation {
+	oldCount, newCount := d.GetChange("nodes_count")
+	count := newCount.(int)
+	if d.Id() != "" {
+		count -= oldCount.(int)
+	}
+
+	arguments := mksNodegroupV1QuotaDemand{
+		AvailabilityZone: d.Get("availability_zone").(string),
+		VolumeType:       d.Get("volume_type").(string),
+		LocalVolume:      d.Get("local_volume").(bool),
+		FlavorID:         d.Get("flavor_id").(string),
+		Count:            count,
+		CPUs:             d.Get("cpus").(int),
+		RAMMB:            d.Get("ram_mb").(int),
+		VolumeGB:         d.Get("volume_gb").(int),
+	}
+
+	return mksNodegroupV1QuotaReservation{
+		NodegroupID: d.Id(),
+		Arguments:   arguments,
+		Demand:      arguments,
+	}
+}
+
+// mksQuotaCheckSkipped reports if the plan-time quota check can't be done
+// because it's disabled or the project or region isn't known yet.
+func mksQuotaCheckSkipped(d *schema.ResourceDiff, config *Config, keys ...string) bool {
+	if config.SkipQuotaCheck {
+		return true
+	}
+	for _, key := range append([]string{"project_id", "region"}, keys...) {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] skipping quota check of %s until %q is known", d.Id(), key)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func getMKSProjectQuotas(config *Config, projectID, region string) ([]*quotas.Quota, error) {
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get project-scope selvpc client: %w", err)
+	}
+	projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+	if err != nil {
+		return nil, errGettingObject(objectProjectQuotas, projectID, err)
+	}
+
+	return projectQuotas, nil
+}
+
+// mksClusterV1QuotaDiff checks at plan time that the project has quotas for
+// the new cluster and the other new clusters of the same type in the plan.
+func mksClusterV1QuotaDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || d.Id() != "" || mksQuotaCheckSkipped(d, config, "name", "zonal") {
+		return nil
+	}
+
+	zonal := d.Get("zonal").(bool)
+	count := config.mksQuotaReservations.reserveCluster(mksQuotaReservationKey(d), d.Get("name").(string), zonal)
+
+	projectQuotas, err := getMKSProjectQuotas(config, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+
+	return checkQuotasForPendingClusters(projectQuotas, zonal, count)
+}
+
+// mksNodegroupV1QuotaDiff checks at plan time that the project has quotas for
+// the new nodes of the nodegroup and the new nodes of other nodegroups in the
+// plan.
+func mksNodegroupV1QuotaDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || mksQuotaCheckSkipped(d, config, "availability_zone", "nodes_count", "volume_gb", "volume_type", "local_volume") {
+		return nil
+	}
+
+	// The SDK plans a replaced nodegroup once more as a new one, so only
+	// the new one reserves its nodes.
+	if d.Id() != "" && mksNodegroupV1Replaced(d) {
+		return nil
+	}
+
+	reservation := expandMKSNodegroupV1QuotaReservation(d)
+	if reservation.Demand.Count <= 0 {
+		config.mksQuotaReservations.releaseNodegroup(mksQuotaReservationKey(d), reservation)
+
+		return nil
+	}
+
+	// CPUs and RAM of new nodegroups that set only the flavor are known
+	// after the nodegroup is created, so they are taken from the flavor.
+	if !d.NewValueKnown("cpus") || !d.NewValueKnown("ram_mb") {
+		flavor, err := getMKSNodegroupV1QuotaFlavor(d, meta)
+		if err != nil {
+			log.Printf("[WARN] nodegroup %s is excluded from the quota check: %s", d.Id(), err)
+
+			return nil
+		}
+		reservation.Demand.CPUs = flavor.VCPUs
+		reservation.Demand.RAMMB = flavor.RAM
+	}
+
+	demands := config.mksQuotaReservations.reserveNodegroup(mksQuotaReservationKey(d), reservation)
+
+	projectQuotas, err := getMKSProjectQuotas(config, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return err
+	}
+
+	return checkQuotasForPendingNodegroups(projectQuotas, demands)
+}
+
+// mksNodegroupV1Replaced reports whether the planned changes of the existing
+// nodegroup replace it.
+func mksNodegroupV1Replaced(d *schema.ResourceDiff) bool {
+	for key, s := range resourceMKSNodegroupV1().Schema {
+		if s.ForceNew && d.HasChange(key) {
+			return true
+		}
+	}
+	strategy := expandMKSNodegroupV1UpdateStrategy(d.Get("update_strategy").([]interface{}))
+
+	return strategy.Type != mksNodegroupV1UpdateStrategyRolling && d.HasChanges(mksNodegroupV1RollingUpdateKeys...)
+}
+
+// getMKSNodegroupV1QuotaFlavor returns the compute flavor of the nodegroup
+// nodes.
+func getMKSNodegroupV1QuotaFlavor(d *schema.ResourceDiff, meta interface{}) (*flavors.Flavor, error) {
+	flavorID := d.Get("flavor_id").(string)
+	if !d.NewValueKnown("flavor_id") || flavorID == "" {
+		return nil, errors.New("CPUs and RAM of the nodes are unknown until the nodegroup is created")
+	}
+
+	computeClient, err := newComputeClient(meta, d.Get("project_id").(string), d.Get("region").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Print(msgGet(objectFlavor, flavorID))
+	flavor, err := flavors.Get(computeClient, flavorID).Extract()
+	if err != nil {
+		return nil, errGettingObject(objectFlavor, flavorID, err)
+	}
+
+	return flavor, nil
+}
+
+func checkQuotasForPendingClusters(projectQuotas []*quotas.Quota, zonal bool, count int) error {
+	clusterType := "regional"
+	if zonal {
+		clusterType = "zonal"
+	}
+
+	quota := findQuota(projectQuotas, "mks_cluster_"+clusterType)
+	if len(quota) == 0 {
+		return fmt.Errorf("unable to find %s k8s cluster quotas", clusterType)
+	}
+	for _, v := range quota {
+		if v.Value-v.Used < count {
+			return fmt.Errorf("not enough quota to create %d %s k8s clusters pending in the plan, remaining: %d, "+
+				"set \"skip_quota_check\" in the provider to skip the check", count, clusterType, v.Value-v.Used)
+		}
+	}
+
+	return nil
+}
+
+// checkQuotasForPendingNodegroups checks that the project has quotas for all
+// new nodes in their zones. The error lists remaining and required resources
+// of every zone that lacks quotas.
+func checkQuotasForPendingNodegroups(projectQuotas []*quotas.Quota, demands []mksNodegroupV1QuotaDemand) error {
+	type zoneDemand struct {
+		cpus     int
+		ramMB    int
+		volumeGB map[string]int
+	}
+	type resourceDemand struct {
+		quotaName string
+		title     string
+		unit      string
+		required  int
+	}
+	zoneDemands := make(map[string]*zoneDemand)
+	for _, demand := range demands {
+		volumeType, err := mksNodegroupV1VolumeQuotaType(demand.LocalVolume, demand.VolumeType)
+		if err != nil {
+			return err
+		}
+
+		zd, ok := zoneDemands[demand.AvailabilityZone]
+		if !ok {
+			zd = &zoneDemand{volumeGB: make(map[string]int)}
+			zoneDemands[demand.AvailabilityZone] = zd
+		}
+		zd.cpus += demand.CPUs * demand.Count
+		zd.ramMB += demand.RAMMB * demand.Count
+		zd.volumeGB[volumeType] += demand.VolumeGB * demand.Count
+	}
+
+	zones := make([]string, 0, len(zoneDemands))
+	for zone := range zoneDemands {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	var shortages []string
+	for _, zone := range zones {
+		zd := zoneDemands[zone]
+		resources := []resourceDemand{
+			{quotaName: "compute_cores", title: "CPU", required: zd.cpus},
+			{quotaName: "compute_ram", title: "RAM", unit: " MB", required: zd.ramMB},
+		}
+		volumeTypes := make([]string, 0, len(zd.volumeGB))
+		for volumeType := range zd.volumeGB {
+			volumeTypes = append(volumeTypes, volumeType)
+		}
+		sort.Strings(volumeTypes)
+		for _, volumeType := range volumeTypes {
+			resources = append(resources, resourceDemand{
+				quotaName: "volume_gigabytes_" + volumeType,
+				title:     volumeType + " volume",
+				unit:      " GB",
+				required:  zd.volumeGB[volumeType],
+			})
+		}
+
+		var (
+			report  []string
+			lacking bool
+		)
+		for _, resource := range resources {
+			remaining, ok := findZoneQuotaRemaining(projectQuotas, resource.quotaName, zone)
+			if !ok {
+				return fmt.Errorf("unable to find %s quota in zone %s", resource.title, zone)
+			}
+			if remaining < resource.required {
+				lacking = true
+			}
+			report = append(report, fmt.Sprintf("%s %d%s remaining, %d%s required",
+				resource.title, remaining, resource.unit, resource.required, resource.unit))
+		}
+		if lacking {
+			shortages = append(shortages, fmt.Sprintf("%s: %s", zone, strings.Join(report, ", ")))
+		}
+	}
+
+	if len(shortages) > 0 {
+		return fmt.Errorf("not enough quota to create nodes of %d nodegroups pending in the plan, "+
+			"set \"skip_quota_check\" in the provider to skip the check; %s", len(demands), strings.Join(shortages, "; "))
+	}
+
+	return nil
+}
+
+// findZoneQuotaRemaining returns the remaining quota of the resource in the zone.
+func findZoneQuotaRemaining(projectQuotas []*quotas.Quota, resourceName, zone string) (int, bool) {
+	for _, v := range findQuota(projectQuotas, resourceName) {
+		if v.Zone == zone {
+			return v.Value - v.Used, true
+		}
+	}
+
+	return 0, false
+}
+
+const (
+	mksNodegroupV1UpdateStrategyRecreate = "recreate"
+	mksNodegroupV1UpdateStrategyRolling  = "rolling"
+)
+
+// mksNodegroupV1RollingUpdateKeys are the nodegroup arguments that can't be
+// changed for existing nodes. With the rolling update strategy their changes
+// replace the nodes instead of the whole nodegroup resource.
+var mksNodegroupV1RollingUpdateKeys = []string{"cpus", "ram_mb", "flavor_id", "volume_gb", "volume_type"}
+
+type mksNodegroupV1UpdateStrategy struct {
+	Type           string
+	MaxSurge       int
+	MaxUnavailable int
+}
+
+func expandMKSNodegroupV1UpdateStrategy(updateStrategy []interface{}) mksNodegroupV1UpdateStrategy {
+	strategy := mksNodegroupV1UpdateStrategy{
+		Type: mksNodegroupV1UpdateStrategyRecreate,
+	}
+	if len(updateStrategy) == 0 || updateStrategy[0] == nil {
+		return strategy
+	}
+
+	strategyMap := updateStrategy[0].(map[string]interface{})
+	strategy.Type = strategyMap["type"].(string)
+	strategy.MaxSurge = strategyMap["max_surge"].(int)
+	strategy.MaxUnavailable = strategyMap["max_unavailable"].(int)
+
+	return strategy
+}
+
+// mksNodegroupV1UpdateStrategyDiff forces a new nodegroup on changes of the
+// node arguments unless the rolling update strategy is set. With the rolling
+// update the nodegroup is updated in place and gets new nodes.
+func mksNodegroupV1UpdateStrategyDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	strategy := expandMKSNodegroupV1UpdateStrategy(d.Get("update_strategy").([]interface{}))
+	if strategy.Type == mksNodegroupV1UpdateStrategyRolling && strategy.MaxSurge == 0 && strategy.MaxUnavailable == 0 {
+		return errors.New("max_surge and max_unavailable of the rolling update strategy can't be both 0")
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	// The new nodegroup of a failed rolling update is either grown further
+	// or deleted on the next apply.
+	if d.Get("rolling_nodegroup_id").(string) != "" {
+		if err := d.SetNewComputed("rolling_nodegroup_id"); err != nil {
+			return err
+		}
+	}
+
+	if strategy.Type != mksNodegroupV1UpdateStrategyRolling {
+		for _, key := range mksNodegroupV1RollingUpdateKeys {
+			if !d.HasChange(key) {
+				continue
+			}
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !d.HasChanges(mksNodegroupV1RollingUpdateKeys...) {
+		return nil
+	}
+
+	computedKeys := []string{"nodes", "nodegroup_type"}
+	if d.HasChanges("cpus", "ram_mb") {
+		computedKeys = append(computedKeys, "flavor_id")
+	}
+	if d.HasChange("flavor_id") {
+		computedKeys = append(computedKeys, "cpus", "ram_mb")
+	}
+	for _, key := range computedKeys {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mksNodegroupV1InstallOpts represents install-time options of the nodegroup
+// nodes that aren't supported by mks-go yet.
+type mksNodegroupV1InstallOpts struct {
+	// Preemptible reflects if the nodes are preemptible.
+	Preemptible bool `json:"preemptible,omitempty"`
+
+	// ImageID contains a reference to a custom image the nodes are installed from.
+	ImageID string `json:"image_id,omitempty"`
+}
+
+// mksNodegroupV1CreateOpts represents options for the nodegroup Create request
+// with install-time options of the nodes.
+type mksNodegroupV1CreateOpts struct {
+	nodegroup.CreateOpts
+	mksNodegroupV1InstallOpts
+}
+
+// mksNodegroupV1View represents an unmarshalled nodegroup body from the get API
+// response with install-time options of the nodes.
+type mksNodegroupV1View struct {
+	nodegroup.GetView
+	mksNodegroupV1InstallOpts
+}
+
+func expandMKSNodegroupV1CreateOpts(d *schema.ResourceData) *mksNodegroupV1CreateOpts {
+	createOpts := &mksNodegroupV1CreateOpts{
+		CreateOpts: nodegroup.CreateOpts{
+			Count:            d.Get("nodes_count").(int),
+			FlavorID:         d.Get("flavor_id").(string),
+			CPUs:             d.Get("cpus").(int),
+			RAMMB:            d.Get("ram_mb").(int),
+			VolumeGB:         d.Get("volume_gb").(int),
+			VolumeType:       d.Get("volume_type").(string),
+			LocalVolume:      d.Get("local_volume").(bool),
+			KeypairName:      d.Get("keypair_name").(string),
+			AffinityPolicy:   d.Get("affinity_policy").(string),
+			AvailabilityZone: d.Get("availability_zone").(string),
+			UserData:         normalizeMKSNodegroupV1UserData(d.Get("user_data").(string)),
+		},
+		mksNodegroupV1InstallOpts: mksNodegroupV1InstallOpts{
+			Preemptible: d.Get("preemptible").(bool),
+			ImageID:     d.Get("image_id").(string),
+		},
+	}
+
+	// Check nodegroup autoscaling options.
+	if v, ok := d.GetOk("enable_autoscale"); ok {
+		enableAutoscale := v.(bool)
+		createOpts.EnableAutoscale = &enableAutoscale
+	}
+	if v, ok := d.GetOk("autoscale_min_nodes"); ok {
+		autoscaleMinNodes := v.(int)
+		createOpts.AutoscaleMinNodes = &autoscaleMinNodes
+	}
+	if v, ok := d.GetOk("autoscale_max_nodes"); ok {
+		autoscaleMaxNodes := v.(int)
+		createOpts.AutoscaleMaxNodes = &autoscaleMaxNodes
+	}
+
+	labels := d.Get("labels").(map[string]interface{})
+	createOpts.Labels = expandMKSNodegroupV1Labels(labels)
+
+	taints := d.Get("taints").([]interface{})
+	createOpts.Taints = expandMKSNodegroupV1Taints(taints)
+
+	return createOpts
+}
+
+// createMKSNodegroupV1Request requests a creation of a new cluster nodegroup
+// like nodegroup.Create does, but with install-time options of the nodes.
+func createMKSNodegroupV1Request(ctx context.Context, client *v1.ServiceClient, clusterID string, opts *mksNodegroupV1CreateOpts) (*v1.ResponseResult, error) {
+	body := struct {
+		Nodegroup *mksNodegroupV1CreateOpts `json:"nodegroup"`
+	}{
+		Nodegroup: opts,
+	}
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster, clusterID, v1.ResourceURLNodegroup}, "/")
+
+	return doMKSV1Request(ctx, client, http.MethodPost, url, body, nil)
+}
+
+// getMKSNodegroupV1 returns a cluster nodegroup by its id like nodegroup.Get
+// does, but with install-time options of the nodes.
+func getMKSNodegroupV1(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string) (*mksNodegroupV1View, *v1.ResponseResult, error) {
+	var result struct {
+		Nodegroup *mksNodegroupV1View `json:"nodegroup"`
+	}
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster, clusterID, v1.ResourceURLNodegroup, nodegroupID}, "/")
+	responseResult, err := doMKSV1Request(ctx, client, http.MethodGet, url, nil, &result)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return result.Nodegroup, responseResult, nil
+}
+
+// createMKSNodegroupV1 creates a nodegroup and waits for the cluster to become
+// active. The API doesn't return the created nodegroup, so it is found by
+// comparing the cluster nodegroups before and after creating. The ID of the
+// created nodegroup is returned even if the cluster doesn't become active.
+// If requested isn't nil, it's called once the create request is done.
+func createMKSNodegroupV1(
+	ctx context.Context, client *v1.ServiceClient, clusterID string, createOpts *mksNodegroupV1CreateOpts, timeout time.Duration,
+	requested func(),
+) (string, error) {
+	// Get a list of all nodegroups in the cluster.
+	allNodegroups, _, err := nodegroup.List(ctx, client, clusterID)
+	if err != nil {
+		return "", errGettingObject("all nodegroups in the cluster", clusterID, err)
+	}
+
+	// Prepare a map with known nodegroup IDs.
+	nodegroupIDs := make(map[string]struct{})
+	for _, ng := range allNodegroups {
+		if _, ok := nodegroupIDs[ng.ID]; !ok {
+			nodegroupIDs[ng.ID] = struct{}{}
+		}
+	}
+
+	log.Print(msgCreate(objectNodegroup, createOpts))
+	_, err = createMKSNodegroupV1Request(ctx, client, clusterID, createOpts)
+	if requested != nil {
+		requested()
+	}
+	if err != nil {
+		return "", errCreatingObject(objectNodegroup, err)
+	}
+
+	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
+	waitErr := waitForMKSClusterV1ActiveState(ctx, client, clusterID, timeout)
+
+	// Get a list of all nodegroups in the cluster and find a new nodegroup.
+	allNodegroups, _, err = nodegroup.List(ctx, client, clusterID)
+	if err != nil {
+		return "", errGettingObject("all nodegroups in the cluster", clusterID, err)
+	}
+
+	var nodegroupID string
+	for _, ng := range allNodegroups {
+		if _, ok := nodegroupIDs[ng.ID]; !ok {
+			nodegroupID = ng.ID
+		}
+	}
+	if waitErr != nil {
+		return nodegroupID, errCreatingObject(objectNodegroup, waitErr)
+	}
+	if nodegroupID == "" {
+		return "", errCreatingObject(objectNodegroup,
+			errors.New("unable to find new nodegroup by ID after creating"),
+		)
+	}
+
+	return nodegroupID, nil
+}
+
+// rollMKSNodegroupV1 replaces nodes of the nodegroup with new ones built from
+// the resource arguments. The new nodegroup grows while the old one shrinks,
+// so the number of nodes never exceeds nodes_count by more than max_surge and
+// never falls below it by more than max_unavailable. Old nodes are deleted one
+// by one, so each of them is drained before deletion, and the old nodegroup is
+// deleted at the end. A new nodegroup left by a failed update in
+// rolling_nodegroup_id is grown further instead of creating another one.
+// It returns the ID of the new nodegroup, if it was created, and whether the
+// old nodegroup is deleted even on errors.
+func rollMKSNodegroupV1(
+	ctx context.Context, d *schema.ResourceData, client *v1.ServiceClient, selvpcClient *selvpcclient.Client, skipQuotaCheck bool,
+) (string, bool, error) {
+	clusterID, oldNodegroupID, err := mksNodegroupV1ParseID(d.Id())
+	if err != nil {
+		return "", false, err
+	}
+
+	projectID := d.Get("project_id").(string)
+	region := d.Get("region").(string)
+	strategy := expandMKSNodegroupV1UpdateStrategy(d.Get("update_strategy").([]interface{}))
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	desiredCount := d.Get("nodes_count").(int)
+	if desiredCount < 1 {
+		return "", false, errors.New("nodes_count must be positive for the rolling update")
+	}
+
+	var (
+		newNodegroupID      string
+		newCount            int
+		oldNodes            []*node.View
+		oldNodegroupDeleted bool
+	)
+
+	log.Print(msgGet(objectNodegroup, d.Id()))
+	oldNodegroup, response, err := nodegroup.Get(ctx, client, clusterID, oldNodegroupID)
+	switch {
+	case err == nil:
+		oldNodes = oldNodegroup.Nodes
+	case response != nil && response.StatusCode == http.StatusNotFound:
+		// The old nodegroup was deleted by the failed update.
+		oldNodegroupDeleted = true
+	default:
+		return "", false, errGettingObject(objectNodegroup, d.Id(), err)
+	}
+
+	// The planned rolling_nodegroup_id is unknown, so it's read from the state.
+	if rollingNodegroupID, _ := d.GetChange("rolling_nodegroup_id"); rollingNodegroupID.(string) != "" {
+		log.Print(msgGet(objectNodegroup, rollingNodegroupID.(string)))
+		rollingNodegroup, response, err := nodegroup.Get(ctx, client, clusterID, rollingNodegroupID.(string))
+		switch {
+		case err == nil:
+			log.Printf("[DEBUG] resuming rolling update of nodegroup %s with nodegroup %s", d.Id(), rollingNodegroupID.(string))
+			newNodegroupID = rollingNodegroupID.(string)
+			newCount = len(rollingNodegroup.Nodes)
+		case response == nil || response.StatusCode != http.StatusNotFound:
+			return "", oldNodegroupDeleted, errGettingObject(objectNodegroup, rollingNodegroupID.(string), err)
+		}
+	}
+
+	// Autoscaling is enabled after the update, so the autoscaler doesn't change
+	// the number of nodes of the new nodegroup in the middle of it.
+	createOpts := expandMKSNodegroupV1CreateOpts(d)
+	createOpts.EnableAutoscale = nil
+	createOpts.AutoscaleMinNodes = nil
+	createOpts.AutoscaleMaxNodes = nil
+
+	// The state has both the flavor and its CPUs and RAM, so only the
+	// configured ones are passed to get a flavor for the new nodes.
+	if d.GetRawConfig().GetAttr("flavor_id").IsNull() {
+		createOpts.FlavorID = ""
+	} else {
+		createOpts.CPUs = 0
+		createOpts.RAMMB = 0
+	}
+
+	for newCount < desiredCount || !oldNodegroupDeleted {
+		addCount := min(desiredCount-newCount, desiredCount+strategy.MaxSurge-newCount-len(oldNodes))
+		if addCount > 0 && !skipQuotaCheck {
+			projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+			if err != nil {
+				return newNodegroupID, oldNodegroupDeleted, errGettingObject(objectProjectQuotas, projectID, err)
+			}
+
+			quotaOpts := *createOpts
+			quotaOpts.Count = addCount
+			if err := checkQuotasForNodegroup(projectQuotas, &quotaOpts.CreateOpts); err != nil {
+				return newNodegroupID, oldNodegroupDeleted, err
+			}
+		}
+		if addCount > 0 {
+			newCount += addCount
+			if newNodegroupID == "" {
+				createOpts.Count = newCount
+				newNodegroupID, err = createMKSNodegroupV1(ctx, client, clusterID, createOpts, timeout, nil)
+			} else {
+				err = resizeMKSNodegroupV1(ctx, client, clusterID, newNodegroupID, newCount, timeout)
+			}
+			if err != nil {
+				return newNodegroupID, oldNodegroupDeleted, err
+			}
+		}
+
+		removeCount := min(len(oldNodes), newCount+len(oldNodes)-desiredCount+strategy.MaxUnavailable)
+		switch {
+		case oldNodegroupDeleted:
+		case removeCount == len(oldNodes):
+			log.Print(msgDelete(objectNodegroup, d.Id()))
+			_, err := nodegroup.Delete(ctx, client, clusterID, oldNodegroupID)
+			if err != nil {
+				return newNodegroupID, oldNodegroupDeleted, errDeletingObject(objectNodegroup, d.Id(), err)
+			}
+			oldNodes = nil
+			oldNodegroupDeleted = true
+
+			log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
+			err = waitForMKSClusterV1ActiveState(ctx, client, clusterID, timeout)
+			if err != nil {
+				return newNodegroupID, oldNodegroupDeleted, errDeletingObject(objectNodegroup, d.Id(), err)
+			}
+
+			continue
+		case removeCount > 0:
+			for _, oldNode := range oldNodes[:removeCount] {
+				log.Print(msgDelete(objectNode, oldNode.ID))
+				_, err := node.Delete(ctx, client, clusterID, oldNodegroupID, oldNode.ID)
+				if err != nil {
+					return newNodegroupID, oldNodegroupDeleted, errDeletingObject(objectNode, oldNode.ID, err)
+				}
+
+				log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
+				err = waitForMKSClusterV1ActiveState(ctx, client, clusterID, timeout)
+				if err != nil {
+					return newNodegroupID, oldNodegroupDeleted, errDeletingObject(objectNode, oldNode.ID, err)
+				}
+			}
+			oldNodes = oldNodes[removeCount:]
+
+			continue
+		}
+
+		if addCount <= 0 {
+			return newNodegroupID, oldNodegroupDeleted, errors.New("rolling update can't add or remove nodes with the given max_surge and max_unavailable")
+		}
+	}
+
+	if !d.Get("enable_autoscale").(bool) {
+		return newNodegroupID, oldNodegroupDeleted, nil
+	}
+
+	enableAutoscale := true
+	autoscaleMinNodes := d.Get("autoscale_min_nodes").(int)
+	autoscaleMaxNodes := d.Get("autoscale_max_nodes").(int)
+	updateOpts := &nodegroup.UpdateOpts{
+		EnableAutoscale:   &enableAutoscale,
+		AutoscaleMinNodes: &autoscaleMinNodes,
+		AutoscaleMaxNodes: &autoscaleMaxNodes,
+	}
+
+	log.Print(msgUpdate(objectNodegroup, newNodegroupID, updateOpts))
+	_, err = nodegroup.Update(ctx, client, clusterID, newNodegroupID, updateOpts)
+	if err != nil {
+		return newNodegroupID, oldNodegroupDeleted, errUpdatingObject(objectNodegroup, newNodegroupID, err)
+	}
+
+	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
+	err = waitForMKSClusterV1ActiveState(ctx, client, clusterID, timeout)
+	if err != nil {
+		return newNodegroupID, oldNodegroupDeleted, errUpdatingObject(objectNodegroup, newNodegroupID, err)
+	}
+
+	return newNodegroupID, oldNodegroupDeleted, nil
+}
+
+// deleteMKSRollingNodegroupV1 deletes the new nodegroup left by a failed
+// rolling update when the update is abandoned.
+func deleteMKSRollingNodegroupV1(
+	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string, timeout time.Duration,
+) error {
+	log.Print(msgDelete(objectNodegroup, nodegroupID))
+	response, err := nodegroup.Delete(ctx, client, clusterID, nodegroupID)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		return errDeletingObject(objectNodegroup, nodegroupID, err)
+	}
+
+	log.Printf("[DEBUG] waiting for nodegroup %s to become deleted", nodegroupID)
+	err = waitForMKSNodegroupV1Deleted(ctx, client, clusterID, nodegroupID, timeout)
+	if err != nil {
+		return errDeletingObject(objectNodegroup, nodegroupID, err)
+	}
+
+	return nil
+}
+
+func resizeMKSNodegroupV1(
+	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string, count int, timeout time.Duration,
+) error {
+	resizeOpts := &nodegroup.ResizeOpts{
+		Desired: count,
+	}
+
+	log.Print(msgUpdate(objectNodegroup, nodegroupID, resizeOpts))
+	_, err := nodegroup.Resize(ctx, client, clusterID, nodegroupID, resizeOpts)
+	if err != nil {
+		return errUpdatingObject(objectNodegroup, nodegroupID, err)
+	}
+
+	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
+	err = waitForMKSClusterV1ActiveState(ctx, client, clusterID, timeout)
+	if err != nil {
+		return errUpdatingObject(objectNodegroup, nodegroupID, err)
+	}
+
+	return nil
+}
+
+// waitForMKSClusterV1Ready waits for the cluster to become 'ACTIVE' if it's
+// in a pending state and returns at once otherwise. It is used before cluster
+// changes since operations that poll a nodegroup instead of the cluster may
+// leave the cluster in a pending state.
+func waitForMKSClusterV1Ready(ctx context.Context, client *v1.ServiceClient, clusterID string, timeout time.Duration) error {
+	mksCluster, _, err := cluster.Get(ctx, client, clusterID)
+	if err != nil {
+		return errGettingObject(objectCluster, clusterID, err)
+	}
+	if !strings.HasPrefix(string(mksCluster.Status), "PENDING_") {
+		return nil
+	}
+
+	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
+
+	return waitForMKSClusterV1ActiveState(ctx, client, clusterID, timeout)
+}
+
+// waitForMKSNodegroupV1Deleted waits for the nodegroup to be deleted without
+// waiting for the whole cluster.
+func waitForMKSNodegroupV1Deleted(
+	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string, timeout time.Duration,
+) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{strconv.Itoa(http.StatusOK)},
+		Target:  []string{strconv.Itoa(http.StatusNotFound)},
+		Refresh: func() (interface{}, string, error) {
+			result, response, err := nodegroup.Get(ctx, client, clusterID, nodegroupID)
+			if err != nil {
+				if response != nil {
+					return result, strconv.Itoa(response.StatusCode), nil
+				}
+
+				return nil, "", err
+			}
+
+			return result, strconv.Itoa(response.StatusCode), nil
+		},
+		Timeout:    timeout,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for the nodegroup %s to become deleted: %s", nodegroupID, err)
+	}
+
+	return nil
+}
+
+// waitForMKSNodeV1Deleted waits for the node to be drained and deleted.
+func waitForMKSNodeV1Deleted(
+	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID, nodeID string, timeout time.Duration,
+) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{strconv.Itoa(http.StatusOK)},
+		Target:  []string{strconv.Itoa(http.StatusNotFound)},
+		Refresh: func() (interface{}, string, error) {
+			result, response, err := node.Get(ctx, client, clusterID, nodegroupID, nodeID)
+			if err != nil {
+				if response != nil {
+					return result, strconv.Itoa(response.StatusCode), nil
+				}
+
+				return nil, "", err
+			}
+
+			return result, strconv.Itoa(response.StatusCode), nil
+		},
+		Timeout:    timeout,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for the node %s to become deleted: %s", nodeID, err)
+	}
+
+	return nil
+}
+
+// drainMKSNodegroupV1 deletes nodes of the nodegroup one by one so workloads
+// are rescheduled from a single drained node at a time. MKS drains a node
+// before it is deleted. The last node is kept since it's deleted together
+// with the nodegroup.
+func drainMKSNodegroupV1(
+	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string, timeout time.Duration,
+) error {
+	mksNodegroup, _, err := nodegroup.Get(ctx, client, clusterID, nodegroupID)
+	if err != nil {
+		return errGettingObject(objectNodegroup, nodegroupID, err)
+	}
+	if len(mksNodegroup.Nodes) < 2 {
+		return nil
+	}
+
+	for _, mksNode := range mksNodegroup.Nodes[1:] {
+		log.Print(msgDelete(objectNode, mksNode.ID))
+		_, err := node.Delete(ctx, client, clusterID, nodegroupID, mksNode.ID)
+		if err != nil {
+			return errDeletingObject(objectNode, mksNode.ID, err)
+		}
+
+		log.Printf("[DEBUG] waiting for node %s to be drained and deleted", mksNode.ID)
+		err = waitForMKSNodeV1Deleted(ctx, client, clusterID, nodegroupID, mksNode.ID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockMKSClusterV1ForNodegroup locks the cluster for the nodegroup operation.
+// The lock is waited for up to cluster_lock_timeout, if it's set, or the
+// operation timeout. Caller is responsible for unlocking the cluster if there
+// is no error.
+func lockMKSClusterV1ForNodegroup(
+	ctx context.Context, d *schema.ResourceData, clusterID, operation string, timeout time.Duration,
+) error {
+	if v := d.Get("cluster_lock_timeout").(string); v != "" {
+		lockTimeout, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		timeout = lockTimeout
+	}
+
+	holder := fmt.Sprintf("%s of nodegroup %s", operation, d.Id())
+	if d.Id() == "" {
+		holder = fmt.Sprintf("%s of a nodegroup in %s", operation, d.Get("availability_zone").(string))
+	}
+
+	return selMutexKV.LockWithTimeout(ctx, clusterID, holder, timeout)
+}
+
+func validateMKSNodegroupV1LockTimeout(v interface{}, k string) ([]string, []error) {
+	timeout, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration, for example, \"10m\": %s", k, err)}
+	}
+	if timeout <= 0 {
+		return nil, []error{fmt.Errorf("%q must be positive, got %s", k, timeout)}
+	}
+
+	return nil, nil
+}
+
+// mksNodegroupV1UserDataMaxLen is the maximum length of the base64-encoded
+// user data of the nodegroup.
+const mksNodegroupV1UserDataMaxLen = 65535
+
+// mksNodegroupV1UserDataHeaders are the headers of the user data formats
+// that cloud-init supports besides the cloud config.
+var mksNodegroupV1UserDataHeaders = []string{
+	"#!",
+	"#include",
+	"#cloud-boothook",
+	"#part-handler",
+	"## template: jinja",
+	"Content-Type:",
+	"\x1f\x8b",
+}
+
+// decodeMKSNodegroupV1UserData returns the user data decoded from base64.
+// User data that isn't base64-encoded is returned as is.
+func decodeMKSNodegroupV1UserData(userData string) string {
+	if decoded, ok := decodeMKSNodegroupV1EncodedUserData(userData); ok {
+		return decoded
+	}
+
+	return userData
+}
+
+// decodeMKSNodegroupV1EncodedUserData decodes the user data from base64 and
+// reports whether it was encoded. Short strings, for example, "true", are
+// valid base64 too, so only decoded data in a format that cloud-init supports
+// is treated as encoded.
+func decodeMKSNodegroupV1EncodedUserData(userData string) (string, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return "", false
+	}
+
+	return string(decoded), isMKSNodegroupV1UserDataFormat(string(decoded))
+}
+
+// isMKSNodegroupV1UserDataFormat reports whether the user data starts with
+// the header of a format that cloud-init supports.
+func isMKSNodegroupV1UserDataFormat(userData string) bool {
+	if strings.HasPrefix(userData, "#cloud-config") {
+		return true
+	}
+	for _, header := range mksNodegroupV1UserDataHeaders {
+		if strings.HasPrefix(userData, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeMKSNodegroupV1UserData returns the user data encoded to base64 as
+// the API expects. User data that is already base64-encoded is returned as is.
+func normalizeMKSNodegroupV1UserData(userData string) string {
+	if userData == "" {
+		return ""
+	}
+	if _, ok := decodeMKSNodegroupV1EncodedUserData(userData); ok {
+		return userData
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(userData))
+}
+
+// validateMKSNodegroupV1UserData checks that the user data fits the API limit
+// and is in a format that cloud-init supports, so broken user data is reported
+// at plan time instead of the nodes failing to join the cluster.
+func validateMKSNodegroupV1UserData(v interface{}, k string) ([]string, []error) {
+	userData := v.(string)
+	if userData == "" {
+		return nil, nil
+	}
+
+	if encodedLen := len(normalizeMKSNodegroupV1UserData(userData)); encodedLen > mksNodegroupV1UserDataMaxLen {
+		return nil, []error{fmt.Errorf("%q must be at most %d bytes when base64-encoded, got %d", k, mksNodegroupV1UserDataMaxLen, encodedLen)}
+	}
+
+	decoded := decodeMKSNodegroupV1UserData(userData)
+	if strings.HasPrefix(decoded, "#cloud-config") {
+		var cloudConfig map[string]interface{}
+		if err := yaml.Unmarshal([]byte(decoded), &cloudConfig); err != nil {
+			return nil, []error{fmt.Errorf("%q is not a valid cloud-config YAML: %s", k, err)}
+		}
+
+		return nil, nil
+	}
+
+	if isMKSNodegroupV1UserDataFormat(decoded) {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("%q must be a cloud-config starting with \"#cloud-config\", a script starting with \"#!\" "+
+		"or another format that cloud-init supports", k)}
+}
@@ -1,13 +1,18 @@
 package selectel
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/quotamanager/quotas"
 	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/selectel/mks-go/pkg/v1/kubeoptions"
 	"github.com/selectel/mks-go/pkg/v1/kubeversion"
 	"github.com/selectel/mks-go/pkg/v1/node"
 	"github.com/selectel/mks-go/pkg/v1/nodegroup"
@@ -37,7 +42,12 @@ func newTestMKSClient(rs *terraform.ResourceState, testAccProvider *schema.Provi
 		endpoint = mksEndpoint.URL
 	}
 
-	mksClient := v1.NewMKSClientV1(selvpcClient.GetXAuthToken(), endpoint)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for mks acc tests: %w", err)
+	}
+
+	mksClient := v1.NewMKSClientV1(token, endpoint)
 
 	return mksClient, nil
 }
@@ -686,6 +696,131 @@ func TestParseMKSKubeVersionsV1Default(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestMKSClusterV1KubeVersionUpgradePath(t *testing.T) {
+	versions := []*kubeversion.View{
+		{Version: "1.27.10"},
+		{Version: "1.28.5"},
+		{Version: "1.28.6"},
+		{Version: "1.29.2"},
+	}
+
+	tests := []struct {
+		name           string
+		currentVersion string
+		desiredVersion string
+		sequential     bool
+		expected       []string
+		expectedErr    string
+	}{
+		{
+			name:           "patch",
+			currentVersion: "1.28.5",
+			desiredVersion: "1.28.6",
+		},
+		{
+			name:           "next minor",
+			currentVersion: "1.28.6",
+			desiredVersion: "1.29",
+			expected:       []string{"1.29"},
+		},
+		{
+			name:           "next minor with patch",
+			currentVersion: "1.28.6",
+			desiredVersion: "v1.29.2",
+			expected:       []string{"1.29"},
+		},
+		{
+			name:           "sequential minors",
+			currentVersion: "1.27.10",
+			desiredVersion: "1.29",
+			sequential:     true,
+			expected:       []string{"1.28", "1.29"},
+		},
+		{
+			name:           "skipped minor",
+			currentVersion: "1.27.10",
+			desiredVersion: "1.29",
+			expectedErr:    "invalid minor version: 1.29, kubernetes versions must be upgraded one by one",
+		},
+		{
+			name:           "unavailable minor",
+			currentVersion: "1.29.2",
+			desiredVersion: "1.30",
+			expectedErr:    "kubernetes version 1.30 is not available, available versions: 1.27.10, 1.28.5, 1.28.6, 1.29.2",
+		},
+		{
+			name:           "unavailable patch",
+			currentVersion: "1.28.5",
+			desiredVersion: "1.28.7",
+			expectedErr:    "kubernetes version 1.28.7 is not available",
+		},
+		{
+			name:           "not latest patch",
+			currentVersion: "1.28.4",
+			desiredVersion: "1.28.5",
+			expectedErr:    "current version 1.28.4 can't be upgraded to version 1.28.5, the latest available patch version is: 1.28.6",
+		},
+		{
+			name:           "older patch",
+			currentVersion: "1.28.6",
+			desiredVersion: "1.28.5",
+		},
+		{
+			name:           "same minor without patch",
+			currentVersion: "1.29.2",
+			desiredVersion: "1.29",
+		},
+		{
+			name:           "minor downgrade",
+			currentVersion: "1.28.5",
+			desiredVersion: "1.27",
+			expectedErr:    "current version 1.28.5 can't be downgraded to version 1.27",
+		},
+		{
+			name:           "minor downgrade with patch",
+			currentVersion: "1.28.5",
+			desiredVersion: "1.27.10",
+			expectedErr:    "current version 1.28.5 can't be downgraded to version 1.27.10",
+		},
+		{
+			name:           "major",
+			currentVersion: "1.29.2",
+			desiredVersion: "2.0",
+			expectedErr:    "current version 1.29.2 can't be upgraded to version 2.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mksClusterV1KubeVersionUpgradePath(versions, tc.currentVersion, tc.desiredVersion, tc.sequential)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestMKSClusterV1UnavailableKubeOptions(t *testing.T) {
+	options := []*kubeoptions.View{
+		{KubeVersion: "1.28", Names: []string{"GracefulNodeShutdown", "TopologyAwareHints"}},
+		{KubeVersion: "1.29", Names: []string{"GracefulNodeShutdown"}},
+	}
+
+	actual, err := mksClusterV1UnavailableKubeOptions(options, "1.29", []string{"TopologyAwareHints", "GracefulNodeShutdown", "Foo"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Foo", "TopologyAwareHints"}, actual)
+
+	_, err = mksClusterV1UnavailableKubeOptions(options, "1.30", []string{"GracefulNodeShutdown"})
+
+	assert.Error(t, err)
+}
+
 func TestCheckQuotasForClusterErrRegional(t *testing.T) {
 	testQuotas := []*quotas.Quota{
 		{
@@ -1285,3 +1420,135 @@ func TestCheckQuotasForNodegroupOk(t *testing.T) {
 
 	assert.NoError(t, checkQuotasForNodegroup(testQuotas, &testNodegroupOpts))
 }
+
+func TestCheckQuotasForPendingNodegroups(t *testing.T) {
+	testQuotas := []*quotas.Quota{
+		{
+			Name: "compute_cores",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Zone: "ru-9a", Value: 10, Used: 4},
+				{Zone: "ru-9b", Value: 10, Used: 0},
+			},
+		},
+		{
+			Name: "compute_ram",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Zone: "ru-9a", Value: 16384, Used: 0},
+				{Zone: "ru-9b", Value: 16384, Used: 0},
+			},
+		},
+		{
+			Name: "volume_gigabytes_fast",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Zone: "ru-9a", Value: 100, Used: 0},
+				{Zone: "ru-9b", Value: 100, Used: 0},
+			},
+		},
+	}
+	nodegroupA := mksNodegroupV1QuotaDemand{AvailabilityZone: "ru-9a", VolumeType: "fast.ru-9a", Count: 2, CPUs: 2, RAMMB: 4096, VolumeGB: 20}
+	nodegroupB := mksNodegroupV1QuotaDemand{AvailabilityZone: "ru-9b", VolumeType: "fast.ru-9b", Count: 3, CPUs: 2, RAMMB: 4096, VolumeGB: 20}
+
+	assert.NoError(t, checkQuotasForPendingNodegroups(testQuotas, []mksNodegroupV1QuotaDemand{nodegroupA, nodegroupB}))
+
+	err := checkQuotasForPendingNodegroups(testQuotas, []mksNodegroupV1QuotaDemand{nodegroupA, nodegroupA, nodegroupB})
+	assert.EqualError(t, err, "not enough quota to create nodes of 3 nodegroups pending in the plan, "+
+		"set \"skip_quota_check\" in the provider to skip the check; "+
+		"ru-9a: CPU 6 remaining, 8 required, RAM 16384 MB remaining, 16384 MB required, fast volume 100 GB remaining, 80 GB required")
+
+	err = checkQuotasForPendingNodegroups(testQuotas, []mksNodegroupV1QuotaDemand{{AvailabilityZone: "ru-9a", LocalVolume: true, Count: 1}})
+	assert.EqualError(t, err, "unable to find local volume quota in zone ru-9a")
+}
+
+func TestMKSQuotaReservations(t *testing.T) {
+	var reservations mksQuotaReservations
+	demand := mksNodegroupV1QuotaDemand{AvailabilityZone: "ru-9a", Count: 1, CPUs: 2}
+	newNodegroup := mksNodegroupV1QuotaReservation{Arguments: demand, Demand: demand}
+	existingNodegroup := mksNodegroupV1QuotaReservation{NodegroupID: "cluster/nodegroup", Arguments: demand, Demand: demand}
+
+	assert.Len(t, reservations.reserveNodegroup("project/ru-9", newNodegroup), 1)
+	assert.Len(t, reservations.reserveNodegroup("project/ru-9", newNodegroup), 2)
+	assert.Len(t, reservations.reserveNodegroup("project/ru-3", newNodegroup), 1)
+	assert.Len(t, reservations.reserveNodegroup("project/ru-9", existingNodegroup), 3)
+	assert.Len(t, reservations.reserveNodegroup("project/ru-9", existingNodegroup), 3)
+	reservations.releaseNodegroup("project/ru-9", newNodegroup)
+	assert.Len(t, reservations.reserveNodegroup("project/ru-9", existingNodegroup), 2)
+	reservations.releaseNodegroup("project/ru-9", existingNodegroup)
+	assert.Len(t, reservations.nodegroups["project/ru-9"], 1)
+	reservations.releaseNodegroup("project/ru-9", newNodegroup)
+	assert.Len(t, reservations.nodegroups["project/ru-9"], 0)
+
+	assert.Equal(t, 1, reservations.reserveCluster("project/ru-9", "first", true))
+	assert.Equal(t, 1, reservations.reserveCluster("project/ru-9", "second", false))
+	assert.Equal(t, 2, reservations.reserveCluster("project/ru-9", "third", true))
+	assert.Equal(t, 2, reservations.reserveCluster("project/ru-9", "third", true))
+	reservations.releaseCluster("project/ru-9", "first")
+	assert.Equal(t, 1, reservations.reserveCluster("project/ru-9", "third", true))
+}
+
+func TestValidateMKSNodegroupV1UserData(t *testing.T) {
+	tests := []struct {
+		name     string
+		userData string
+		errMatch string
+	}{
+		{name: "empty"},
+		{name: "base64 script", userData: "IyEvYmluL2Jhc2ggLXYKYXB0IC15IHVwZGF0ZQphcHQgLXkgaW5zdGFsbCBtdHI="},
+		{name: "plain cloud-config", userData: "#cloud-config\npackages:\n  - mtr\n"},
+		{name: "base64 cloud-config", userData: base64.StdEncoding.EncodeToString([]byte("#cloud-config\nruncmd: [ls]\n"))},
+		{name: "multipart", userData: "Content-Type: multipart/mixed; boundary=\"==BOUNDARY==\"\n"},
+		{name: "invalid cloud-config", userData: "#cloud-config\n- mtr\n", errMatch: "is not a valid cloud-config YAML"},
+		{name: "unknown format", userData: base64.StdEncoding.EncodeToString([]byte("apt -y install mtr")), errMatch: "must be a cloud-config"},
+		{name: "plain text that is valid base64", userData: "abcd", errMatch: "must be a cloud-config"},
+		{name: "too long", userData: "#!/bin/sh\n" + strings.Repeat("#", 50000), errMatch: "must be at most 65535 bytes when base64-encoded"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := validateMKSNodegroupV1UserData(tc.userData, "user_data")
+			if tc.errMatch == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Error(), tc.errMatch)
+			}
+		})
+	}
+}
+
+func TestNormalizeMKSNodegroupV1UserData(t *testing.T) {
+	encoded := "IyEvYmluL2Jhc2ggLXYKYXB0IC15IHVwZGF0ZQphcHQgLXkgaW5zdGFsbCBtdHI="
+
+	assert.Equal(t, "", normalizeMKSNodegroupV1UserData(""))
+	assert.Equal(t, encoded, normalizeMKSNodegroupV1UserData(encoded))
+	assert.Equal(t, encoded, normalizeMKSNodegroupV1UserData("#!/bin/bash -v\napt -y update\napt -y install mtr"))
+	assert.Equal(t, "dHJ1ZQ==", normalizeMKSNodegroupV1UserData("true"))
+	assert.Equal(t, "YWJjZA==", normalizeMKSNodegroupV1UserData("abcd"))
+	gzipped := base64.StdEncoding.EncodeToString([]byte("\x1f\x8b\x08\x00"))
+	assert.Equal(t, gzipped, normalizeMKSNodegroupV1UserData(gzipped))
+}
+
+func TestMKSClusterV1ViewUnmarshalJSON(t *testing.T) {
+	var view mksClusterV1View
+	err := json.Unmarshal([]byte(`{
+  "id": "a8ad6ec2-0d6c-4f66-8a5a-7ff0c6bd7bc7",
+  "status": "ACTIVE",
+  "kubernetes_options": {
+    "enable_pod_security_policy": true,
+    "feature_gates": ["GracefulNodeShutdown"],
+    "oidc": {"enabled": true, "issuer_url": "https://sso.example.com", "client_id": "kubernetes"},
+    "audit_logs": {"enabled": true}
+  }
+}`), &view)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a8ad6ec2-0d6c-4f66-8a5a-7ff0c6bd7bc7", view.ID)
+	assert.Equal(t, cluster.StatusActive, view.Status)
+	if assert.NotNil(t, view.KubernetesOptions) {
+		assert.True(t, view.KubernetesOptions.EnablePodSecurityPolicy)
+		assert.Equal(t, []string{"GracefulNodeShutdown"}, view.KubernetesOptions.FeatureGates)
+		assert.Equal(t, &mksClusterV1OIDC{Enabled: true, IssuerURL: "https://sso.example.com", ClientID: "kubernetes"}, view.KubernetesOptions.OIDC)
+		assert.Equal(t, &mksClusterV1AuditLogs{Enabled: true}, view.KubernetesOptions.AuditLogs)
+	}
+}
@@ -2,9 +2,11 @@ package selectel
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/mutexkv"
 )
 
@@ -21,9 +23,11 @@ const (
 	objectTopic                   = "topic"
 	objectUser                    = "user"
 	objectCluster                 = "cluster"
+	objectClusters                = "clusters"
 	objectKubeConfig              = "kubeconfig"
 	objectKubeVersions            = "kube-versions"
 	objectNodegroup               = "nodegroup"
+	objectNode                    = "node"
 	objectDomain                  = "domain"
 	objectRecord                  = "record"
 	objectZone                    = "zone"
@@ -35,11 +39,14 @@ const (
 	objectDatastoreTypes          = "datastore-types"
 	objectAvailableExtensions     = "available-extensions"
 	objectFlavors                 = "flavors"
+	objectFlavor                  = "flavor"
 	objectConfigurationParameters = "configuration-parameters"
 	objectPrometheusMetricToken   = "prometheus-metric-token"
 	objectFeatureGates            = "feature-gates"
 	objectAdmissionControllers    = "admission-controllers"
 	objectLogicalReplicationSlot  = "logical-replication-slot"
+	objectBackup                  = "backup"
+	objectBackups                 = "backups"
 	objectRegistry                = "registry"
 	objectRegistryToken           = "registry token"
 	objectSecret                  = "secret"
@@ -84,13 +91,13 @@ func Provider() *schema.Provider {
 			},
 			"domain_name": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_DOMAIN_NAME", nil),
 				Description: "Your domain name i.e. your account id",
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_USERNAME", nil),
 				Description: "Service user username",
 			},
@@ -102,10 +109,66 @@ func Provider() *schema.Provider {
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_PASSWORD", nil),
 				Description: "Service user password",
 			},
+			"auth_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_TOKEN", nil),
+				Description: "Pre-issued Keystone token to authenticate with instead of the service user password",
+			},
+			"application_credential_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_APPLICATION_CREDENTIAL_ID", nil),
+				Description: "Application credential ID to authenticate with instead of the service user password",
+			},
+			"application_credential_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_APPLICATION_CREDENTIAL_SECRET", nil),
+				Description: "Application credential secret",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEL_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries of failed API requests",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEL_RETRY_WAIT_MIN", int(defaultRetryWaitMin/time.Second)),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time in seconds to wait before retrying a failed API request",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEL_RETRY_WAIT_MAX", int(defaultRetryWaitMax/time.Second)),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds to wait before retrying a failed API request",
+			},
+			"skip_quota_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SKIP_QUOTA_CHECK", false),
+				Description: "Skip checks of project quotas before creating Managed Kubernetes clusters and nodes",
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Custom endpoints of Selectel APIs that are used instead of the catalog ones",
+				Elem: &schema.Resource{
+					Schema: endpointsSchema(),
+				},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"selectel_domains_domain_v1":                dataSourceDomainsDomainV1(),
@@ -116,13 +179,15 @@ func Provider() *schema.Provider {
 			"selectel_dbaas_flavor_v1":                  dataSourceDBaaSFlavorV1(),
 			"selectel_dbaas_configuration_parameter_v1": dataSourceDBaaSConfigurationParameterV1(),
 			"selectel_dbaas_prometheus_metric_token_v1": dataSourceDBaaSPrometheusMetricTokenV1(),
+			"selectel_dbaas_backups_v1":                 dataSourceDBaaSBackupsV1(),
 			"selectel_mks_kubeconfig_v1":                dataSourceMKSKubeconfigV1(),
 			"selectel_mks_kube_versions_v1":             dataSourceMKSKubeVersionsV1(),
+			"selectel_mks_clusters_v1":                  dataSourceMKSClustersV1(),
+			"selectel_mks_nodegroups_v1":                dataSourceMKSNodegroupsV1(),
 			"selectel_mks_feature_gates_v1":             dataSourceMKSFeatureGatesV1(),
 			"selectel_mks_admission_controllers_v1":     dataSourceMKSAdmissionControllersV1(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"selectel_vpc_floatingip_v2":                            resourceVPCFloatingIPV2(),
 			"selectel_vpc_keypair_v2":                               resourceVPCKeypairV2(),
 			"selectel_vpc_license_v2":                               resourceVPCLicenseV2(),
 			"selectel_vpc_project_v2":                               resourceVPCProjectV2(),
@@ -154,6 +219,7 @@ func Provider() *schema.Provider {
 			"selectel_dbaas_kafka_acl_v1":                           resourceDBaaSKafkaACLV1(),
 			"selectel_dbaas_kafka_datastore_v1":                     resourceDBaaSKafkaDatastoreV1(),
 			"selectel_dbaas_kafka_topic_v1":                         resourceDBaaSKafkaTopicV1(),
+			"selectel_dbaas_backup_v1":                              resourceDBaaSBackupV1(),
 			"selectel_craas_registry_v1":                            resourceCRaaSRegistryV1(),
 			"selectel_craas_token_v1":                               resourceCRaaSTokenV1(),
 			"selectel_secretsmanager_secret_v1":                     resourceSecretsManagerSecretV1(),
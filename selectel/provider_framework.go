@@ -0,0 +1,150 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewProviderServer returns the server that serves resources of both the SDKv2
+// provider and the terraform-plugin-framework provider. The framework provider
+// reuses the configuration of the SDKv2 provider, so both providers must have
+// the same schema.
+func NewProviderServer(ctx context.Context, primary *sdkschema.Provider) (tfprotov6.ProviderServer, error) {
+	upgradedPrimary, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return primary.GRPCProvider()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't upgrade SDKv2 provider server: %w", err)
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		func() tfprotov6.ProviderServer { return upgradedPrimary },
+		providerserver.NewProtocol6(newFrameworkProvider(primary)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't create provider mux server: %w", err)
+	}
+
+	return muxServer.ProviderServer(), nil
+}
+
+var _ provider.Provider = &frameworkProvider{}
+
+// frameworkProvider serves resources that are written with
+// terraform-plugin-framework.
+type frameworkProvider struct {
+	primary *sdkschema.Provider
+}
+
+func newFrameworkProvider(primary *sdkschema.Provider) provider.Provider {
+	return &frameworkProvider{primary: primary}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "selectel"
+}
+
+// Schema converts the schema of the SDKv2 provider from Provider, so both
+// providers always have the same schema.
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes, blocks := frameworkProviderAttributes(p.primary.Schema)
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+}
+
+// Configure passes the configuration of the SDKv2 provider to the framework
+// resources. The mux server configures the SDKv2 provider first.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	config, ok := p.primary.Meta().(*Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unconfigured provider",
+			"The SDKv2 provider must be configured before the framework provider. This is a bug in the provider.",
+		)
+
+		return
+	}
+
+	resp.DataSourceData = config
+	resp.ResourceData = config
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newVPCFloatingIPV2Resource,
+	}
+}
+
+// frameworkProviderAttributes converts arguments of the SDKv2 provider schema
+// into attributes and blocks of the framework provider schema.
+func frameworkProviderAttributes(sdkSchema map[string]*sdkschema.Schema) (map[string]schema.Attribute, map[string]schema.Block) {
+	attributes := make(map[string]schema.Attribute)
+	blocks := make(map[string]schema.Block)
+	for name, s := range sdkSchema {
+		switch s.Type {
+		case sdkschema.TypeString:
+			attributes[name] = schema.StringAttribute{
+				Required:    s.Required,
+				Optional:    s.Optional,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case sdkschema.TypeInt:
+			attributes[name] = schema.Int64Attribute{
+				Required:    s.Required,
+				Optional:    s.Optional,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case sdkschema.TypeBool:
+			attributes[name] = schema.BoolAttribute{
+				Required:    s.Required,
+				Optional:    s.Optional,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case sdkschema.TypeList:
+			elem, ok := s.Elem.(*sdkschema.Resource)
+			if !ok {
+				panic(fmt.Sprintf("provider argument %s isn't a block", name))
+			}
+			nestedAttributes, nestedBlocks := frameworkProviderAttributes(elem.Schema)
+			block := schema.ListNestedBlock{
+				Description: s.Description,
+				NestedObject: schema.NestedBlockObject{
+					Attributes: nestedAttributes,
+					Blocks:     nestedBlocks,
+				},
+			}
+			if s.MaxItems > 0 {
+				block.Validators = []validator.List{
+					listvalidator.SizeAtMost(s.MaxItems),
+				}
+			}
+			blocks[name] = block
+		default:
+			panic(fmt.Sprintf("provider argument %s has unsupported type %s", name, s.Type))
+		}
+	}
+
+	return attributes, blocks
+}
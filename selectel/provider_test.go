@@ -1,26 +1,34 @@
 package selectel
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 
+	frameworkprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeselectel"
 )
 
 var (
-	testAccProviders map[string]func() (*schema.Provider, error)
-	testAccProvider  *schema.Provider
+	testAccProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+	testAccProvider                 *schema.Provider
 )
 
 func init() {
 	testAccProvider = Provider()
-	testAccProviders = map[string]func() (*schema.Provider, error){
-		"selectel": func() (*schema.Provider, error) {
-			return testAccProvider, nil
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"selectel": func() (tfprotov6.ProviderServer, error) {
+			return NewProviderServer(context.Background(), testAccProvider)
 		},
 	}
 }
@@ -31,6 +39,40 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestNewProviderServer(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewProviderServer(ctx, Provider())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The mux server returns an error diagnostic if the provider schemas of
+	// the SDKv2 and framework providers differ.
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		t.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+	for _, resourceType := range []string{"selectel_vpc_floatingip_v2", "selectel_vpc_project_v2"} {
+		if _, ok := resp.ResourceSchemas[resourceType]; !ok {
+			t.Errorf("resource %s isn't served", resourceType)
+		}
+	}
+}
+
+func TestFrameworkProviderConfigureUnconfigured(t *testing.T) {
+	p := newFrameworkProvider(Provider())
+	resp := &frameworkprovider.ConfigureResponse{}
+
+	p.Configure(context.Background(), frameworkprovider.ConfigureRequest{}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unconfigured provider" {
+		t.Errorf("Expected unconfigured provider error, but got: %v", resp.Diagnostics)
+	}
+}
+
 func testAccSelectelPreCheck(t *testing.T) {
 	if v := os.Getenv("OS_DOMAIN_NAME"); v == "" {
 		t.Fatal("OS_DOMAIN_NAME must be set for acceptance tests")
@@ -52,6 +94,56 @@ func testAccSelectelPreCheckWithProjectID(t *testing.T) {
 	}
 }
 
+// testUnitPreCheck starts the fake Selectel API for unit tests that run
+// resource lifecycles with resource.UnitTest. Such tests need the Terraform CLI
+// and are skipped if it is not installed.
+func testUnitPreCheck(t *testing.T) *fakeselectel.Server {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform must be installed or TF_ACC_TERRAFORM_PATH must be set for unit tests")
+		}
+	}
+
+	// Credentials and import settings from the environment must not leak into
+	// the fake provider configuration.
+	for _, env := range []string{
+		"OS_AUTH_URL", "OS_DOMAIN_NAME", "OS_USERNAME", "OS_USER_DOMAIN_NAME", "OS_PASSWORD", "OS_TOKEN",
+		"OS_APPLICATION_CREDENTIAL_ID", "OS_APPLICATION_CREDENTIAL_SECRET", "SEL_PROJECT_ID", "SEL_REGION",
+	} {
+		t.Setenv(env, "")
+	}
+
+	// The fake API changes states immediately, so there is no need to wait.
+	waitTimeScale = 0
+	t.Cleanup(func() { waitTimeScale = 1 })
+
+	backend := fakeselectel.NewServer()
+	t.Cleanup(backend.Close)
+
+	return backend
+}
+
+// testUnitProviderConfig returns the provider block that points at the fake Selectel API.
+func testUnitProviderConfig(backend *fakeselectel.Server) string {
+	return fmt.Sprintf(`
+provider "selectel" {
+  auth_url    = "%s"
+  domain_name = "%s"
+  username    = "%s"
+  password    = "%s"
+  endpoints {
+    dns                 = "%s"
+    domains_v1          = "%s"
+    secrets_manager     = "%s"
+    certificate_manager = "%s"
+  }
+}
+`, backend.AuthURL(), fakeselectel.DomainName, fakeselectel.Username, fakeselectel.Password,
+		backend.DNSURL(), backend.DomainsV1URL(), backend.SecretsManagerURL(), backend.CertificateManagerURL())
+}
+
 func testAccCheckSelectelImportEnv(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -104,3 +196,146 @@ func testAccCheckSelectelCRaaSImportEnv(resourceName string) resource.TestCheckF
 		return nil
 	}
 }
+
+// testAccSelectelImportStateIDFunc builds a composite import ID that prefixes
+// the resource ID with the values of the given attributes, for example:
+// <project_id>/<region>/<id>.
+func testAccSelectelImportStateIDFunc(resourceName string, attrs ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+
+		parts := make([]string, 0, len(attrs)+1)
+		for _, attr := range attrs {
+			parts = append(parts, rs.Primary.Attributes[attr])
+		}
+
+		return strings.Join(append(parts, rs.Primary.ID), "/"), nil
+	}
+}
+
+// testAccUnsetSelectelImportEnv unsets the variables set by
+// testAccCheckSelectelImportEnv so that the import relies on the ID only.
+func testAccUnsetSelectelImportEnv() {
+	os.Unsetenv("SEL_PROJECT_ID")
+	os.Unsetenv("SEL_REGION")
+}
+
+// testUnitWriteOnlyAttributes are arguments that can't be read from the API,
+// so the imported resources ignore their changes as the import docs suggest.
+var testUnitWriteOnlyAttributes = map[string][]string{
+	"selectel_dbaas_backup_v1":          {"triggers"},
+	"selectel_dbaas_datastore_v1":       {"redis_password"},
+	"selectel_dbaas_redis_datastore_v1": {"redis_password"},
+	"selectel_dbaas_user_v1":            {"password"},
+	"selectel_vpc_user_v2":              {"password"},
+}
+
+// testUnitCheckImportPlanEmpty creates the resources of the config, forgets
+// them and checks that Terraform import blocks for all of them followed by
+// the same config produce an empty plan. The config must contain importable
+// resources only.
+func testUnitCheckImportPlanEmpty(t *testing.T, backend *fakeselectel.Server, config string) {
+	t.Helper()
+
+	var (
+		imports  string
+		snapshot fakeselectel.Snapshot
+	)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					var err error
+					imports, err = testUnitImportBlocks(s)
+					snapshot = backend.Snapshot()
+
+					return err
+				},
+			},
+		},
+	})
+	if t.Failed() {
+		return
+	}
+
+	// Bring back the destroyed objects so they can be imported.
+	backend.Restore(snapshot)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:   testUnitIgnoreWriteOnlyChanges(config) + imports,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// testUnitImportBlocks returns import blocks for the managed resources of
+// the state.
+func testUnitImportBlocks(s *terraform.State) (string, error) {
+	addresses := make([]string, 0, len(s.RootModule().Resources))
+	for address := range s.RootModule().Resources {
+		if !strings.HasPrefix(address, "data.") {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	var blocks strings.Builder
+	for _, address := range addresses {
+		id, err := testUnitImportID(s, s.RootModule().Resources[address])
+		if err != nil {
+			return "", fmt.Errorf("error building import ID of %s: %w", address, err)
+		}
+		fmt.Fprintf(&blocks, "\nimport {\n  to = %s\n  id = %q\n}\n", address, id)
+	}
+
+	return blocks.String(), nil
+}
+
+// testUnitImportID builds the composite import ID of the resource so the
+// import doesn't depend on SEL_PROJECT_ID and SEL_REGION.
+func testUnitImportID(s *terraform.State, rs *terraform.ResourceState) (string, error) {
+	attrs := rs.Primary.Attributes
+	switch {
+	case strings.HasPrefix(rs.Type, "selectel_mks_"), strings.HasPrefix(rs.Type, "selectel_dbaas_"):
+		return strings.Join([]string{attrs["project_id"], attrs["region"], rs.Primary.ID}, importIDSeparator), nil
+	case rs.Type == "selectel_domains_zone_v2":
+		return strings.Join([]string{attrs["project_id"], attrs["name"]}, importIDSeparator), nil
+	case rs.Type == "selectel_domains_rrset_v2":
+		for _, zone := range s.RootModule().Resources {
+			if zone.Type == "selectel_domains_zone_v2" && zone.Primary.ID == attrs["zone_id"] {
+				return strings.Join([]string{
+					attrs["project_id"], zone.Primary.Attributes["name"], attrs["name"], attrs["type"],
+				}, importIDSeparator), nil
+			}
+		}
+
+		return "", fmt.Errorf("zone %s is not found", attrs["zone_id"])
+	case rs.Type == "selectel_craas_registry_v1", rs.Type == "selectel_vpc_floatingip_v2",
+		rs.Type == "selectel_vpc_license_v2", rs.Type == "selectel_vpc_subnet_v2",
+		rs.Type == "selectel_secretsmanager_certificate_v1":
+		return strings.Join([]string{attrs["project_id"], rs.Primary.ID}, importIDSeparator), nil
+	default:
+		return rs.Primary.ID, nil
+	}
+}
+
+// testUnitIgnoreWriteOnlyChanges adds the lifecycle block that ignores
+// changes of write-only arguments to the resources of the config.
+func testUnitIgnoreWriteOnlyChanges(config string) string {
+	for resourceType, attrs := range testUnitWriteOnlyAttributes {
+		header := regexp.MustCompile(`(resource "` + resourceType + `" "[^"]+" \{)`)
+		lifecycle := fmt.Sprintf("\n  lifecycle {\n    ignore_changes = [%s]\n  }\n", strings.Join(attrs, ", "))
+		config = header.ReplaceAllString(config, "${1}"+lifecycle)
+	}
+
+	return config
+}
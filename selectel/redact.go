@@ -0,0 +1,211 @@
+package selectel
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+var (
+	// sensitiveKeyFragments are parts of normalized field names, JSON keys
+	// and headers whose values must never be logged.
+	sensitiveKeyFragments = []string{
+		"password",
+		"secret",
+		"token",
+		"privatekey",
+		"keydata",
+		"kubeconfig",
+	}
+
+	// sensitiveKeys are normalized names that are sensitive only as a whole,
+	// for example, the value of a Secrets Manager secret.
+	sensitiveKeys = []string{
+		"value",
+	}
+
+	pemPrivateKeyRe = regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----.*?-----END ([A-Z ]*)PRIVATE KEY-----`)
+
+	// sensitiveLineRe matches "key: value" and "key=value" lines of plain text
+	// and YAML bodies such as kubeconfig files.
+	sensitiveLineRe = regexp.MustCompile(`(?im)^(\s*"?[\w-]*(?:password|secret|token|private-key|key-data)[\w-]*"?\s*[:=]\s*).+$`)
+)
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(normalized, fragment) {
+			return true
+		}
+	}
+	for _, sensitiveKey := range sensitiveKeys {
+		if normalized == sensitiveKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactHeaders returns a copy of headers with values of sensitive headers,
+// such as X-Auth-Token, replaced.
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for name := range redacted {
+		if isSensitiveKey(name) || strings.EqualFold(name, "Authorization") {
+			redacted.Set(name, redactedValue)
+		}
+	}
+
+	return redacted
+}
+
+// redactBody replaces sensitive values in JSON or plain text request and response bodies.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		redacted, err := json.Marshal(redactJSON(v))
+		if err == nil {
+			return string(redacted)
+		}
+	}
+
+	text := pemPrivateKeyRe.ReplaceAllString(string(body), redactedValue)
+
+	return sensitiveLineRe.ReplaceAllString(text, "${1}"+redactedValue)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			if isSensitiveKey(key) && item != nil && item != "" {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = redactJSON(item)
+		}
+
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(value))
+		for i, item := range value {
+			redacted[i] = redactJSON(item)
+		}
+
+		return redacted
+	case string:
+		return pemPrivateKeyRe.ReplaceAllString(value, redactedValue)
+	default:
+		return value
+	}
+}
+
+// redactOptions returns a copy of API request options with sensitive fields
+// replaced, so the options can be logged.
+func redactOptions(options interface{}) interface{} {
+	if options == nil {
+		return nil
+	}
+
+	return redactValue(reflect.ValueOf(options)).Interface()
+}
+
+func redactValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		redacted := reflect.New(v.Elem().Type())
+		redacted.Elem().Set(redactValue(v.Elem()))
+
+		return redacted
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		redacted := reflect.New(v.Type()).Elem()
+		redacted.Set(redactValue(v.Elem()))
+
+		return redacted
+	case reflect.Struct:
+		redacted := reflect.New(v.Type()).Elem()
+		redacted.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := redacted.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if isSensitiveKey(v.Type().Field(i).Name) {
+				redactField(field)
+				continue
+			}
+			field.Set(redactValue(v.Field(i)))
+		}
+
+		return redacted
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		redacted := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			redacted.Index(i).Set(redactValue(v.Index(i)))
+		}
+
+		return redacted
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		redacted := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			item := reflect.New(v.Type().Elem()).Elem()
+			item.Set(redactValue(iter.Value()))
+			if iter.Key().Kind() == reflect.String && isSensitiveKey(iter.Key().String()) {
+				redactField(item)
+			}
+			redacted.SetMapIndex(iter.Key(), item)
+		}
+
+		return redacted
+	default:
+		return v
+	}
+}
+
+// redactField replaces a non-empty sensitive value in place.
+func redactField(field reflect.Value) {
+	switch field.Kind() {
+	case reflect.String:
+		if field.Len() > 0 {
+			field.SetString(redactedValue)
+		}
+	case reflect.Ptr:
+		if !field.IsNil() && field.Elem().Kind() == reflect.String {
+			redacted := reflect.New(field.Elem().Type())
+			redacted.Elem().SetString(redactedValue)
+			field.Set(redacted)
+
+			return
+		}
+		field.Set(reflect.Zero(field.Type()))
+	case reflect.Interface:
+		if !field.IsNil() && reflect.TypeOf(redactedValue).AssignableTo(field.Type()) {
+			field.Set(reflect.ValueOf(redactedValue))
+		}
+	default:
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
@@ -2,7 +2,6 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -135,8 +134,8 @@ func resourceCRaaSRegistryV1Delete(ctx context.Context, d *schema.ResourceData,
 
 			return result, strconv.Itoa(response.StatusCode), err
 		},
-		Delay:        1 * time.Second,
-		PollInterval: 1 * time.Second,
+		Delay:        waitTime(1 * time.Second),
+		PollInterval: waitTime(1 * time.Second),
 	}
 
 	log.Printf("[DEBUG] Waiting for registry %s to become deleted", d.Id())
@@ -149,11 +148,13 @@ func resourceCRaaSRegistryV1Delete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCRaaSRegistryV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the CRaaS registry resource import")
+	projectID, id, err := parseImportIDWithProject(meta.(*Config), d.Id(), "registry_id")
+	if err != nil {
+		return nil, err
 	}
-	d.Set("project_id", config.ProjectID)
+
+	d.SetId(id)
+	d.Set("project_id", projectID)
 
 	return []*schema.ResourceData{d}, nil
 }
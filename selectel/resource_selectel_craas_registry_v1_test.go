@@ -26,9 +26,9 @@ func TestAccCRaaSRegistryV1Basic(t *testing.T) {
 	registryEndpoint := fmt.Sprintf("%s/%s", craasV1RegistryHostName, registryName)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCRaaSRegistryV1Basic(projectName, registryName),
@@ -44,6 +44,45 @@ func TestAccCRaaSRegistryV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitCRaaSRegistryV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var craasRegistry registry.Registry
+	resourceName := "selectel_craas_registry_v1.registry_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	registryName := acctest.RandomWithPrefix("tf-acc-reg")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccCRaaSRegistryV1Basic(projectName, registryName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCRaaSRegistryV1Exists(resourceName, &craasRegistry),
+					resource.TestCheckResourceAttr(resourceName, "name", registryName),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint", backend.URL+"/"+registryName),
+					testAccCheckSelectelCRaaSImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig:         testAccUnsetSelectelImportEnv,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccSelectelImportStateIDFunc(resourceName, "project_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccCRaaSRegistryV1Basic(projectName, registryName))
+}
+
 func testAccCheckCRaaSRegistryV1Exists(n string, craasRegistry *registry.Registry) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -22,9 +22,9 @@ func TestAccCRaaSTokenV1Basic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCRaaSTokenV1Basic(projectName),
@@ -39,6 +39,28 @@ func TestAccCRaaSTokenV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitCRaaSTokenV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var craasToken token.Token
+	resourceName := "selectel_craas_token_v1.token_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccCRaaSTokenV1Basic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCRaaSTokenV1Exists(resourceName, &craasToken),
+					resource.TestCheckResourceAttr(resourceName, "token_ttl", "1y"),
+					resource.TestCheckResourceAttr(resourceName, "username", "token"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCRaaSTokenV1Exists(n string, craasToken *token.Token) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -0,0 +1,181 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDBaaSBackupV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDBaaSBackupV1Create,
+		ReadContext:   resourceDBaaSBackupV1Read,
+		DeleteContext: resourceDBaaSBackupV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDBaaSBackupV1ImportState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"datastore_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDBaaSBackupV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dbaasClient, diagErr := getDBaaSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	datastoreID := d.Get("datastore_id").(string)
+
+	// A backup can't be started while the datastore is being changed.
+	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", datastoreID)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	err := waitForDBaaSDatastoreV1ActiveState(ctx, dbaasClient, datastoreID, timeout)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectBackup, err))
+	}
+
+	backupCreateOpts := dbaasBackupCreateOpts{
+		DatastoreID: datastoreID,
+	}
+
+	log.Print(msgCreate(objectBackup, backupCreateOpts))
+	backup, err := createDBaaSBackup(ctx, dbaasClient, backupCreateOpts)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectBackup, err))
+	}
+
+	d.SetId(backup.ID)
+
+	log.Printf("[DEBUG] waiting for backup %s to become 'ACTIVE'", backup.ID)
+	err = waitForDBaaSBackupV1ActiveState(ctx, dbaasClient, backup.ID, timeout)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectBackup, err))
+	}
+
+	return resourceDBaaSBackupV1Read(ctx, d, meta)
+}
+
+func resourceDBaaSBackupV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dbaasClient, diagErr := getDBaaSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgGet(objectBackup, d.Id()))
+	backup, err := getDBaaSBackup(ctx, dbaasClient, d.Id())
+	if err != nil {
+		// Backups are removed by the service once the retention period is over.
+		if isDBaaSNotFoundError(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(errGettingObject(objectBackup, d.Id(), err))
+	}
+	d.Set("datastore_id", backup.DatastoreID)
+	d.Set("created_at", backup.CreatedAt)
+	d.Set("status", backup.Status)
+	d.Set("type", backup.Type)
+	d.Set("size", backup.Size)
+
+	return nil
+}
+
+func resourceDBaaSBackupV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dbaasClient, diagErr := getDBaaSClient(d, meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	log.Print(msgDelete(objectBackup, d.Id()))
+	err := deleteDBaaSBackup(ctx, dbaasClient, d.Id())
+	if err != nil {
+		if isDBaaSNotFoundError(err) {
+			return nil
+		}
+
+		return diag.FromErr(errDeletingObject(objectBackup, d.Id(), err))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.Itoa(http.StatusOK)},
+		Target:     []string{strconv.Itoa(http.StatusNotFound)},
+		Refresh:    dbaasBackupV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(5 * time.Second),
+	}
+
+	log.Printf("[DEBUG] waiting for backup %s to become deleted", d.Id())
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for the backup %s to become deleted: %s", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceDBaaSBackupV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "backup_id")
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
+
+	return []*schema.ResourceData{d}, nil
+}
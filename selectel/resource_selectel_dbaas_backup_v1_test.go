@@ -0,0 +1,169 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/dbaas-go"
+	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/projects"
+)
+
+func TestAccDBaaSBackupV1Basic(t *testing.T) {
+	var (
+		dbaasBackup dbaasBackup
+		project     projects.Project
+	)
+
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDBaaSBackupV1Basic(projectName, datastoreName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2ProjectExists("selectel_vpc_project_v2.project_tf_acc_test_1", &project),
+					testAccCheckDBaaSBackupV1Exists("selectel_dbaas_backup_v1.backup_tf_acc_test_1", &dbaasBackup),
+					resource.TestCheckResourceAttr("selectel_dbaas_backup_v1.backup_tf_acc_test_1", "status", string(dbaas.StatusActive)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDBaaSBackupV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var firstBackup, secondBackup dbaasBackup
+	resourceName := "selectel_dbaas_backup_v1.backup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDBaaSBackupV1Basic(projectName, datastoreName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSBackupV1Exists(resourceName, &firstBackup),
+					resource.TestCheckResourceAttrPair(resourceName, "datastore_id", "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttr(resourceName, "type", "manual"),
+					resource.TestCheckResourceAttrSet(resourceName, "size"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			{
+				// A change of the triggers takes a new backup.
+				Config: providerConfig + testAccDBaaSBackupV1Basic(projectName, datastoreName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSBackupV1Exists(resourceName, &secondBackup),
+					testAccCheckDBaaSBackupV1Recreated(&firstBackup, &secondBackup),
+					testAccCheckDBaaSBackupV1Deleted(resourceName, &firstBackup),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"triggers"},
+			},
+			{
+				PreConfig:               testAccUnsetSelectelImportEnv,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       testAccSelectelImportStateIDFunc(resourceName, "project_id", "region"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"triggers"},
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccDBaaSBackupV1Basic(projectName, datastoreName, "v1"))
+}
+
+func testAccCheckDBaaSBackupV1Exists(n string, backup *dbaasBackup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		ctx := context.Background()
+
+		dbaasClient, err := newTestDBaaSClient(ctx, rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		b, err := getDBaaSBackup(ctx, dbaasClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if b.ID != rs.Primary.ID {
+			return errors.New("backup not found")
+		}
+
+		*backup = b
+
+		return nil
+	}
+}
+
+func testAccCheckDBaaSBackupV1Recreated(before, after *dbaasBackup) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if before.ID == after.ID {
+			return fmt.Errorf("expected a new backup, got the same backup %s", after.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDBaaSBackupV1Deleted(n string, backup *dbaasBackup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		ctx := context.Background()
+
+		dbaasClient, err := newTestDBaaSClient(ctx, rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		_, err = getDBaaSBackup(ctx, dbaasClient, backup.ID)
+		if !isDBaaSNotFoundError(err) {
+			return fmt.Errorf("expected backup %s to be deleted, got: %v", backup.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccDBaaSBackupV1Basic(projectName, datastoreName, migration string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "selectel_dbaas_backup_v1" "backup_tf_acc_test_1" {
+  project_id   = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region       = "ru-3"
+  datastore_id = "${selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1.id}"
+  triggers = {
+    migration = "%s"
+  }
+}`, testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName, 1), migration)
+}
@@ -2,7 +2,6 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -206,8 +205,8 @@ func resourceDBaaSDatabaseV1Delete(ctx context.Context, d *schema.ResourceData,
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasDatabaseV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for database %s to become deleted", d.Id())
@@ -220,16 +219,14 @@ func resourceDBaaSDatabaseV1Delete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceDBaaSDatabaseV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "database_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -28,9 +28,9 @@ func TestAccDBaaSDatabaseV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
@@ -69,6 +69,60 @@ func TestAccDBaaSDatabaseV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSDatabaseV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var dbaasDatabase dbaas.Database
+	resourceName := "selectel_dbaas_database_v1.database_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	newUserName := RandomWithPrefix("tf_acc_new_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDBaaSDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttr(resourceName, "name", databaseName),
+					resource.TestCheckResourceAttr(resourceName, "lc_collate", "C"),
+					resource.TestCheckResourceAttr(resourceName, "lc_ctype", "C"),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSDatabaseV1UpdateLocale(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttr(resourceName, "lc_collate", "ru_RU.utf8"),
+					resource.TestCheckResourceAttr(resourceName, "lc_ctype", "ru_RU.utf8"),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSDatabaseV1UpdateOwnerID(projectName, datastoreName, userName, userPassword, newUserName, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttrPair(resourceName, "owner_id", "selectel_dbaas_user_v1.new_user_tf_acc_test_1", "id"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccDBaaSDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount))
+}
+
 func testAccCheckDBaaSDatabaseV1Exists(n string, dbaasDatabase *dbaas.Database) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -310,6 +310,14 @@ func resourceDBaaSDatastoreV1Create(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(datastore.ID)
 
+	// Firewall rules can't be passed in the create request.
+	if d.Get("firewall").(*schema.Set).Len() != 0 {
+		err = updateDatastoreFirewall(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDBaaSDatastoreV1Read(ctx, d, meta)
 }
 
@@ -356,6 +364,22 @@ func resourceDBaaSDatastoreV1Read(ctx context.Context, d *schema.ResourceData, m
 		log.Print(errSettingComplexAttr("config", err))
 	}
 
+	if err := d.Set("pooler", resourceDBaaSDatastoreV1PoolerToList(datastore.Pooler)); err != nil {
+		log.Print(errSettingComplexAttr("pooler", err))
+	}
+
+	if err := d.Set("firewall", resourceDBaaSDatastoreV1FirewallToList(d, datastore.Firewall)); err != nil {
+		log.Print(errSettingComplexAttr("firewall", err))
+	}
+
+	floatingIPs, err := resourceDBaaSDatastoreV1FloatingIPsToList(d, datastore)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDatastore, d.Id(), err))
+	}
+	if err := d.Set("floating_ips", floatingIPs); err != nil {
+		log.Print(errSettingComplexAttr("floating_ips", err))
+	}
+
 	return nil
 }
 
@@ -396,7 +420,7 @@ func resourceDBaaSDatastoreV1Update(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 	if d.HasChange("redis_password") {
-		err := updateRedisDatastorePassword(ctx, d, dbaasClient)
+		err := updateRedisDatastorePassword(ctx, d, dbaasClient, d.Get("redis_password").(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -434,8 +458,8 @@ func resourceDBaaSDatastoreV1Delete(ctx context.Context, d *schema.ResourceData,
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasDatastoreV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become deleted", d.Id())
@@ -448,16 +472,14 @@ func resourceDBaaSDatastoreV1Delete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceDBaaSDatastoreV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "datastore_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -27,9 +27,9 @@ func TestAccDBaaSDatastoreV1Basic(t *testing.T) {
 	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -183,9 +183,9 @@ func TestAccDBaaSDatastoreV1RedisBasic(t *testing.T) {
 	resizeNodeCount := 2
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSDatastoreV1RedisBasic(projectName, datastoreName, nodeCount),
@@ -266,9 +266,9 @@ func TestAccDBaaSMultiNodeDatastoreV1Basic(t *testing.T) {
 	nodeCount := 3
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -297,6 +297,100 @@ func TestAccDBaaSMultiNodeDatastoreV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSDatastoreV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	resourceName := "selectel_dbaas_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1Basic(projectName, datastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "name", datastoreName),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttrSet(resourceName, "connections.master"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1UpdateName(projectName, updatedDatastoreName, nodeCount),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", updatedDatastoreName),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1UpdatePooler(projectName, updatedDatastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1UpdateFirewall(projectName, updatedDatastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1Resize(projectName, updatedDatastoreName, nodeCount),
+				Check:  resource.TestCheckResourceAttr(resourceName, "flavor.0.ram", strconv.Itoa(8192)),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1UpdateConfig(projectName, updatedDatastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSDatastoreV1Basic(projectName, datastoreName, nodeCount))
+}
+
+func TestUnitDBaaSDatastoreV1RedisBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	resourceName := "selectel_dbaas_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	nodeCount := 1
+	resizeNodeCount := 2
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1RedisBasic(projectName, datastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1UpdateRedisConfig(projectName, datastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1UpdateRedisPassword(projectName, datastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSDatastoreV1RedisResize(projectName, datastoreName, resizeNodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "node_count", strconv.Itoa(resizeNodeCount)),
+				),
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSDatastoreV1RedisBasic(projectName, datastoreName, nodeCount))
+}
+
 func testAccCheckDBaaSDatastoreV1Exists(n string, dbaasDatastore *dbaas.Datastore) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -148,8 +148,8 @@ func resourceDBaaSExtensionV1Delete(ctx context.Context, d *schema.ResourceData,
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasExtensionV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for extension %s to become deleted", d.Id())
@@ -162,16 +162,14 @@ func resourceDBaaSExtensionV1Delete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceDBaaSExtensionV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "extension_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -192,8 +190,8 @@ func waitForDBaaSExtensionV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasExtensionV1StateRefreshFunc(ctx, client, extensionID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
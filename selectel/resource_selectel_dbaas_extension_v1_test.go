@@ -29,9 +29,9 @@ func TestAccDBaaSExtensionV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, extensionName, nodeCount),
@@ -47,6 +47,43 @@ func TestAccDBaaSExtensionV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSExtensionV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasExtension dbaas.Extension
+	resourceName := "selectel_dbaas_extension_v1.extension_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, "hstore", nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSExtensionV1Exists(resourceName, &dbaasExtension),
+					resource.TestCheckResourceAttrPair(resourceName, "available_extension_id",
+						"data.selectel_dbaas_available_extension_v1.ae", "available_extensions.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "datastore_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "database_id"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, "hstore", nodeCount))
+}
+
 func testAccCheckDBaaSExtensionV1Exists(n string, dbaasExtension *dbaas.Extension) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -154,8 +154,8 @@ func resourceDBaaSGrantV1Delete(ctx context.Context, d *schema.ResourceData, met
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasGrantV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for grant %s to become deleted", d.Id())
@@ -168,16 +168,14 @@ func resourceDBaaSGrantV1Delete(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceDBaaSGrantV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "grant_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -198,8 +196,8 @@ func waitForDBaaSGrantV1ActiveState(
 		Target:     target,
 		Refresh:    dbaasGrantV1StateRefreshFunc(ctx, client, grantID),
 		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	_, err := stateConf.WaitForState()
@@ -27,9 +27,9 @@ func TestAccDBaaSGrantV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSGrantV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
@@ -46,6 +46,42 @@ func TestAccDBaaSGrantV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSGrantV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasGrant dbaas.Grant
+	resourceName := "selectel_dbaas_grant_v1.grant_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSGrantV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSGrantV1Exists(resourceName, &dbaasGrant),
+					resource.TestCheckResourceAttrPair(resourceName, "user_id", "selectel_dbaas_user_v1.user_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "database_id", "selectel_dbaas_database_v1.database_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSGrantV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount))
+}
+
 func testAccCheckDBaaSGrantV1Exists(n string, dbaasGrant *dbaas.Grant) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
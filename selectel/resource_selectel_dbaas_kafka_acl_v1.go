@@ -2,7 +2,6 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -127,6 +126,7 @@ func resourceDBaaSACLV1Read(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(errGettingObject(objectACL, d.Id(), err))
 	}
 	d.Set("datastore_id", acl.DatastoreID)
+	d.Set("user_id", acl.UserID)
 	if acl.Pattern != "" {
 		d.Set("pattern", acl.Pattern)
 	}
@@ -186,8 +186,8 @@ func resourceDBaaSACLV1Delete(ctx context.Context, d *schema.ResourceData, meta
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasACLV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(20 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for acl %s to become deleted", d.Id())
@@ -200,16 +200,14 @@ func resourceDBaaSACLV1Delete(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceDBaaSACLV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "acl_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -26,9 +26,9 @@ func TestAccDBaaSKafkaACLV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSKafkaACLV1Basic(projectName, datastoreName, userName, userPassword, nodeCount),
@@ -58,6 +58,52 @@ func TestAccDBaaSKafkaACLV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSKafkaACLV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var dbaasACL dbaas.ACL
+	resourceName := "selectel_dbaas_kafka_acl_v1.acl_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDBaaSKafkaACLV1Basic(projectName, datastoreName, userName, userPassword, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSKafkaACLV1Exists(resourceName, &dbaasACL),
+					resource.TestCheckResourceAttr(resourceName, "pattern_type", "prefixed"),
+					resource.TestCheckResourceAttr(resourceName, "pattern", "topic"),
+					resource.TestCheckResourceAttr(resourceName, "allow_read", "true"),
+					resource.TestCheckResourceAttr(resourceName, "allow_write", "false"),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSKafkaACLV1Update(projectName, datastoreName, userName, userPassword, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSKafkaACLV1Exists(resourceName, &dbaasACL),
+					resource.TestCheckResourceAttr(resourceName, "allow_read", "false"),
+					resource.TestCheckResourceAttr(resourceName, "allow_write", "true"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccDBaaSKafkaACLV1Basic(projectName, datastoreName, userName, userPassword, nodeCount))
+}
+
 func testAccCheckDBaaSKafkaACLV1Exists(n string, dbaasACL *dbaas.ACL) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
@@ -24,6 +25,10 @@ func resourceDBaaSKafkaDatastoreV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDBaaSKafkaDatastoreV1ImportState,
 		},
+		CustomizeDiff: customdiff.All(
+			dbaasDatastoreV1UpgradeDiff,
+			dbaasDatastoreV1ConfigDiff,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -52,7 +57,12 @@ func resourceDBaaSKafkaDatastoreV1() *schema.Resource {
 			"type_id": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+				ForceNew: false,
+			},
+			"backup_before_upgrade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Take a backup of the datastore before the upgrade to a newer version.",
 			},
 			"flavor_id": {
 				Type:          schema.TypeString,
@@ -200,6 +210,14 @@ func resourceDBaaSKafkaDatastoreV1Create(ctx context.Context, d *schema.Resource
 
 	d.SetId(datastore.ID)
 
+	// Firewall rules can't be passed in the create request.
+	if d.Get("firewall").(*schema.Set).Len() != 0 {
+		err = updateDatastoreFirewall(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDBaaSKafkaDatastoreV1Read(ctx, d, meta)
 }
 
@@ -245,6 +263,10 @@ func resourceDBaaSKafkaDatastoreV1Read(ctx context.Context, d *schema.ResourceDa
 		log.Print(errSettingComplexAttr("config", err))
 	}
 
+	if err := d.Set("firewall", resourceDBaaSDatastoreV1FirewallToList(d, datastore.Firewall)); err != nil {
+		log.Print(errSettingComplexAttr("firewall", err))
+	}
+
 	return nil
 }
 
@@ -254,6 +276,14 @@ func resourceDBaaSKafkaDatastoreV1Update(ctx context.Context, d *schema.Resource
 		return diagErr
 	}
 
+	var diags diag.Diagnostics
+
+	if d.HasChange("type_id") {
+		err := updateDatastoreType(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if d.HasChange("name") {
 		err := updateDatastoreName(ctx, d, dbaasClient)
 		if err != nil {
@@ -277,9 +307,10 @@ func resourceDBaaSKafkaDatastoreV1Update(ctx context.Context, d *schema.Resource
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		diags = append(diags, dbaasDatastoreV1ConfigRestartWarnings(ctx, d, dbaasClient)...)
 	}
 
-	return resourceDBaaSKafkaDatastoreV1Read(ctx, d, meta)
+	return append(diags, resourceDBaaSKafkaDatastoreV1Read(ctx, d, meta)...)
 }
 
 func resourceDBaaSKafkaDatastoreV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -299,8 +330,8 @@ func resourceDBaaSKafkaDatastoreV1Delete(ctx context.Context, d *schema.Resource
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasDatastoreV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(15 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become deleted", d.Id())
@@ -313,16 +344,14 @@ func resourceDBaaSKafkaDatastoreV1Delete(ctx context.Context, d *schema.Resource
 }
 
 func resourceDBaaSKafkaDatastoreV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "datastore_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
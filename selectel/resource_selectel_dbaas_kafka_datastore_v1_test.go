@@ -22,9 +22,9 @@ func TestAccDBaaSKafkaDatastoreV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSKafkaDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -86,6 +86,57 @@ func TestAccDBaaSKafkaDatastoreV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSKafkaDatastoreV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	resourceName := "selectel_dbaas_kafka_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSKafkaDatastoreV1Basic(projectName, datastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "name", datastoreName),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttr(resourceName, "flavor.0.ram", strconv.Itoa(8192)),
+					resource.TestCheckResourceAttr(resourceName, "config.log.retention.ms", "1000"),
+					resource.TestCheckResourceAttrSet(resourceName, "connections.master"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSKafkaDatastoreV1UpdateConfig(projectName, datastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "config.log.retention.ms", "10000"),
+					resource.TestCheckResourceAttr(resourceName, "config.log.retention.bytes", "1024"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSKafkaDatastoreV1Resize(projectName, datastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "flavor.0.disk", strconv.Itoa(64)),
+					resource.TestCheckResourceAttr(resourceName, "config.log.retention.ms", "10000"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSKafkaDatastoreV1Basic(projectName, datastoreName, nodeCount))
+}
+
 func testAccDBaaSKafkaDatastoreV1Basic(projectName, datastoreName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
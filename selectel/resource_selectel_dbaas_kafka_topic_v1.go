@@ -2,7 +2,6 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -157,8 +156,8 @@ func resourceDBaaSTopicV1Delete(ctx context.Context, d *schema.ResourceData, met
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasTopicV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(20 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for topic %s to become deleted", d.Id())
@@ -171,16 +170,14 @@ func resourceDBaaSTopicV1Delete(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceDBaaSTopicV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "topic_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -27,9 +27,9 @@ func TestAccDBaaSKafkaTopicV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSKafkaTopicV1Basic(projectName, datastoreName, topicName, strconv.Itoa(topicPartitions), nodeCount),
@@ -55,6 +55,49 @@ func TestAccDBaaSKafkaTopicV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSKafkaTopicV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var dbaasTopic dbaas.Topic
+	resourceName := "selectel_dbaas_kafka_topic_v1.topic_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	topicName := RandomWithPrefix("tf_acc_topic")
+	topicPartitions := 1
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDBaaSKafkaTopicV1Basic(projectName, datastoreName, topicName, strconv.Itoa(topicPartitions), nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSKafkaTopicV1Exists(resourceName, &dbaasTopic),
+					resource.TestCheckResourceAttr(resourceName, "name", topicName),
+					resource.TestCheckResourceAttr(resourceName, "partitions", strconv.Itoa(topicPartitions)),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSKafkaTopicV1Update(projectName, datastoreName, topicName, strconv.Itoa(topicPartitions+1), nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSKafkaTopicV1Exists(resourceName, &dbaasTopic),
+					resource.TestCheckResourceAttr(resourceName, "partitions", strconv.Itoa(topicPartitions+1)),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccDBaaSKafkaTopicV1Basic(projectName, datastoreName, topicName, strconv.Itoa(topicPartitions), nodeCount))
+}
+
 func testAccCheckDBaaSKafkaTopicV1Exists(n string, dbaasTopic *dbaas.Topic) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -23,9 +23,9 @@ func TestAccDBaaSMySQLDatabaseV1Basic(t *testing.T) {
 	datastoreTypeEngine := mySQLDatastoreType
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, datastoreTypeEngine, databaseName, nodeCount),
@@ -53,9 +53,9 @@ func TestAccDBaaSMySQLNativeDatabaseV1Basic(t *testing.T) {
 	datastoreTypeEngine := mySQLNativeDatastoreType
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, datastoreTypeEngine, databaseName, nodeCount),
@@ -70,6 +70,39 @@ func TestAccDBaaSMySQLNativeDatabaseV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSMySQLDatabaseV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatabase dbaas.Database
+	resourceName := "selectel_dbaas_mysql_database_v1.database_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, mySQLNativeDatastoreType, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttr(resourceName, "name", databaseName),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, mySQLNativeDatastoreType, databaseName, nodeCount))
+}
+
 func testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, datastoreTypeEngine, databaseName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -58,9 +58,9 @@ func TestAccDBaaSMySQLDatastoreV1Basic(t *testing.T) {
 	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSMySQLDatastoreV1Basic(projectName, datastoreName, datastoreTypeEngine, nodeCount),
@@ -101,9 +101,9 @@ func TestAccDBaaSMySQLNativeDatastoreV1Basic(t *testing.T) {
 	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSMySQLDatastoreV1Basic(projectName, datastoreName, datastoreTypeEngine, nodeCount),
@@ -129,6 +129,58 @@ func TestAccDBaaSMySQLNativeDatastoreV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSMySQLNativeDatastoreV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var (
+		dbaasDatastore dbaas.Datastore
+		project        projects.Project
+	)
+	resourceName := "selectel_dbaas_mysql_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
+	datastoreTypeEngine := mySQLNativeDatastoreType
+	nodeCount := 1
+	resizeNodeCount := 2
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSMySQLDatastoreV1Basic(projectName, datastoreName, datastoreTypeEngine, nodeCount),
+				Check:  getCheckSteps(project, dbaasDatastore, datastoreName, nodeCount, 0, Flavor{2, 4096, 32}, MySQLConfig{"strict_innodb", 2, false}),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSMySQLDatastoreV1UpdateName(projectName, updatedDatastoreName, datastoreTypeEngine, nodeCount),
+				Check:  getCheckSteps(project, dbaasDatastore, updatedDatastoreName, nodeCount, 0, Flavor{2, 4096, 32}, MySQLConfig{"strict_innodb", 2, false}),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSMySQLDatastoreV1UpdateFirewall(projectName, updatedDatastoreName, datastoreTypeEngine, nodeCount),
+				Check:  getCheckSteps(project, dbaasDatastore, updatedDatastoreName, nodeCount, 2, Flavor{2, 4096, 32}, MySQLConfig{"strict_innodb", 2, false}),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSMySQLDatastoreV1Resize(projectName, updatedDatastoreName, datastoreTypeEngine, resizeNodeCount),
+				Check:  getCheckSteps(project, dbaasDatastore, updatedDatastoreName, resizeNodeCount, 2, Flavor{2, 8192, 32}, MySQLConfig{"strict_innodb", 2, false}),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSMySQLDatastoreV1UpdateConfig(projectName, updatedDatastoreName, datastoreTypeEngine, resizeNodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					getCheckSteps(project, dbaasDatastore, updatedDatastoreName, resizeNodeCount, 2, Flavor{2, 8192, 32}, MySQLConfig{"strict_innodb", 4, true}),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSMySQLDatastoreV1Basic(projectName, datastoreName, datastoreTypeEngine, nodeCount))
+}
+
 func testAccDBaaSMySQLDatastoreV1Basic(projectName, datastoreName, datastoreTypeEngine string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -25,9 +25,9 @@ func TestAccDBaaSPostgreSQLDatabaseV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPostgreSQLDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
@@ -66,6 +66,60 @@ func TestAccDBaaSPostgreSQLDatabaseV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSPostgreSQLDatabaseV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var dbaasDatabase dbaas.Database
+	resourceName := "selectel_dbaas_postgresql_database_v1.database_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	newUserName := RandomWithPrefix("tf_acc_new_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDBaaSPostgreSQLDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttr(resourceName, "name", databaseName),
+					resource.TestCheckResourceAttr(resourceName, "lc_collate", "C"),
+					resource.TestCheckResourceAttr(resourceName, "lc_ctype", "C"),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSPostgreSQLDatabaseV1UpdateLocale(projectName, datastoreName, userName, userPassword, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttr(resourceName, "lc_collate", "ru_RU.utf8"),
+					resource.TestCheckResourceAttr(resourceName, "lc_ctype", "ru_RU.utf8"),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSPostgreSQLDatabaseV1UpdateOwnerID(projectName, datastoreName, userName, userPassword, newUserName, databaseName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatabaseV1Exists(resourceName, &dbaasDatabase),
+					resource.TestCheckResourceAttrPair(resourceName, "owner_id", "selectel_dbaas_user_v1.new_user_tf_acc_test_1", "id"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccDBaaSPostgreSQLDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName, nodeCount))
+}
+
 func testAccDBaaSPostgreSQLDatabaseV1Basic(projectName, datastoreName, userName, userPassword, databaseName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
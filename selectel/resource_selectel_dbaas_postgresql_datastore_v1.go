@@ -27,7 +27,10 @@ func resourceDBaaSPostgreSQLDatastoreV1() *schema.Resource {
 			StateContext: resourceDBaaSPostgreSQLDatastoreV1ImportState,
 		},
 		CustomizeDiff: customdiff.All(
+			dbaasDatastoreV1UpgradeDiff,
 			refreshDatastoreInstancesOutputsDiff,
+			dbaasDatastoreV1ConfigDiff,
+			dbaasDatastoreV1ReplicaOfDiff,
 		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -58,7 +61,12 @@ func resourceDBaaSPostgreSQLDatastoreV1() *schema.Resource {
 			"type_id": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+				ForceNew: false,
+			},
+			"backup_before_upgrade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Take a backup of the datastore before the upgrade to a newer version.",
 			},
 			"flavor_id": {
 				Type:          schema.TypeString,
@@ -195,6 +203,25 @@ func resourceDBaaSPostgreSQLDatastoreV1() *schema.Resource {
 					},
 				},
 			},
+			"replica_of": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"restore"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datastore_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"region": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: dbaasDatastoreV1ReplicaOfRegionDiffSuppressFunc,
+						},
+					},
+				},
+			},
 			"config": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -291,8 +318,13 @@ func resourceDBaaSPostgreSQLDatastoreV1Create(ctx context.Context, d *schema.Res
 		datastoreCreateOpts.BackupRetentionDays = backupRetentionDays.(int)
 	}
 
-	log.Print(msgCreate(objectDatastore, datastoreCreateOpts))
-	datastore, err := dbaasClient.CreateDatastore(ctx, datastoreCreateOpts)
+	createOpts := dbaasDatastoreV1CreateOpts{
+		DatastoreCreateOpts: datastoreCreateOpts,
+		Replication:         expandDBaaSDatastoreV1ReplicaOf(d.Get("replica_of").([]interface{}), d.Get("region").(string)),
+	}
+
+	log.Print(msgCreate(objectDatastore, createOpts))
+	datastore, err := createDBaaSDatastoreV1(ctx, dbaasClient, createOpts)
 	if err != nil {
 		return diag.FromErr(errCreatingObject(objectDatastore, err))
 	}
@@ -306,6 +338,14 @@ func resourceDBaaSPostgreSQLDatastoreV1Create(ctx context.Context, d *schema.Res
 
 	d.SetId(datastore.ID)
 
+	// Firewall rules can't be passed in the create request.
+	if d.Get("firewall").(*schema.Set).Len() != 0 {
+		err = updateDatastoreFirewall(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDBaaSPostgreSQLDatastoreV1Read(ctx, d, meta)
 }
 
@@ -316,7 +356,7 @@ func resourceDBaaSPostgreSQLDatastoreV1Read(ctx context.Context, d *schema.Resou
 	}
 
 	log.Print(msgGet(objectDatastore, d.Id()))
-	datastore, err := dbaasClient.Datastore(ctx, d.Id())
+	datastore, err := getDBaaSDatastoreV1(ctx, dbaasClient, d.Id())
 	if err != nil {
 		return diag.FromErr(errGettingObject(objectDatastore, d.Id(), err))
 	}
@@ -352,6 +392,26 @@ func resourceDBaaSPostgreSQLDatastoreV1Read(ctx context.Context, d *schema.Resou
 		log.Print(errSettingComplexAttr("config", err))
 	}
 
+	if err := d.Set("replica_of", flattenDBaaSDatastoreV1ReplicaOf(datastore.Replication)); err != nil {
+		log.Print(errSettingComplexAttr("replica_of", err))
+	}
+
+	if err := d.Set("pooler", resourceDBaaSDatastoreV1PoolerToList(datastore.Pooler)); err != nil {
+		log.Print(errSettingComplexAttr("pooler", err))
+	}
+
+	if err := d.Set("firewall", resourceDBaaSDatastoreV1FirewallToList(d, datastore.Firewall)); err != nil {
+		log.Print(errSettingComplexAttr("firewall", err))
+	}
+
+	floatingIPs, err := resourceDBaaSDatastoreV1FloatingIPsToList(d, datastore.Datastore)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDatastore, d.Id(), err))
+	}
+	if err := d.Set("floating_ips", floatingIPs); err != nil {
+		log.Print(errSettingComplexAttr("floating_ips", err))
+	}
+
 	return nil
 }
 
@@ -361,6 +421,20 @@ func resourceDBaaSPostgreSQLDatastoreV1Update(ctx context.Context, d *schema.Res
 		return diagErr
 	}
 
+	var diags diag.Diagnostics
+
+	if d.HasChange("replica_of") {
+		err := updateDatastoreReplication(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("type_id") {
+		err := updateDatastoreType(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if d.HasChange("name") {
 		err := updateDatastoreName(ctx, d, dbaasClient)
 		if err != nil {
@@ -390,6 +464,7 @@ func resourceDBaaSPostgreSQLDatastoreV1Update(ctx context.Context, d *schema.Res
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		diags = append(diags, dbaasDatastoreV1ConfigRestartWarnings(ctx, d, dbaasClient)...)
 	}
 	if d.HasChange("backup_retention_days") {
 		err := updateDatastoreBackups(ctx, d, dbaasClient)
@@ -404,7 +479,7 @@ func resourceDBaaSPostgreSQLDatastoreV1Update(ctx context.Context, d *schema.Res
 		}
 	}
 
-	return resourceDBaaSPostgreSQLDatastoreV1Read(ctx, d, meta)
+	return append(diags, resourceDBaaSPostgreSQLDatastoreV1Read(ctx, d, meta)...)
 }
 
 func resourceDBaaSPostgreSQLDatastoreV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -424,8 +499,8 @@ func resourceDBaaSPostgreSQLDatastoreV1Delete(ctx context.Context, d *schema.Res
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasDatastoreV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become deleted", d.Id())
@@ -438,16 +513,14 @@ func resourceDBaaSPostgreSQLDatastoreV1Delete(ctx context.Context, d *schema.Res
 }
 
 func resourceDBaaSPostgreSQLDatastoreV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "datastore_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -1,12 +1,16 @@
 package selectel
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/dbaas-go"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/projects"
 )
@@ -25,9 +29,9 @@ func TestAccDBaaSPostgreSQLDatastoreV1Basic(t *testing.T) {
 	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -166,6 +170,273 @@ func TestAccDBaaSPostgreSQLDatastoreV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSPostgreSQLDatastoreV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	resourceName := "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
+	nodeCount := 1
+	resizeNodeCount := 2
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "name", datastoreName),
+					resource.TestCheckResourceAttr(resourceName, "node_count", strconv.Itoa(nodeCount)),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttr(resourceName, "flavor.0.ram", strconv.Itoa(4096)),
+					resource.TestCheckResourceAttr(resourceName, "config.work_mem", strconv.Itoa(128)),
+					resource.TestCheckResourceAttrSet(resourceName, "connections.master"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1UpdatePooler(projectName, updatedDatastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedDatastoreName),
+					resource.TestCheckResourceAttr(resourceName, "pooler.0.mode", "session"),
+					resource.TestCheckResourceAttr(resourceName, "pooler.0.size", strconv.Itoa(50)),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1UpdateFirewall(projectName, updatedDatastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedDatastoreName),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Resize(projectName, updatedDatastoreName, resizeNodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "node_count", strconv.Itoa(resizeNodeCount)),
+					resource.TestCheckResourceAttr(resourceName, "flavor.0.ram", strconv.Itoa(8192)),
+					resource.TestCheckResourceAttr(resourceName, "instances.#", strconv.Itoa(resizeNodeCount)),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1UpdateConfig(projectName, updatedDatastoreName, resizeNodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "config.work_mem", strconv.Itoa(256)),
+					resource.TestCheckResourceAttr(resourceName, "config.vacuum_cost_delay", strconv.Itoa(20)),
+					resource.TestCheckNoResourceAttr(resourceName, "config.transform_null_equals"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig:         testAccUnsetSelectelImportEnv,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccSelectelImportStateIDFunc(resourceName, "project_id", "region"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName, nodeCount))
+}
+
+func TestUnitDBaaSPostgreSQLDatastoreV1ConfigValidation(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	resourceName := "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1WithConfig(projectName, datastoreName, `
+    work_mem = 32
+    xmloption = "text"
+    shared_buffers = "1GB"`),
+				ExpectError: regexp.MustCompile(`(?s)unknown parameter "shared_buffers".*parameter "work_mem" must be at least 64 kB.*parameter "xmloption" must be one of content, document`),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1WithConfig(projectName, datastoreName, `
+    work_mem = 128
+    max_connections = 100`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "config.max_connections", strconv.Itoa(100)),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1WithConfig(projectName, datastoreName, `
+    work_mem = 128
+    max_connections = 200`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "config.max_connections", strconv.Itoa(200)),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1WithConfig(projectName, datastoreName, `
+    work_mem = "many"
+    max_connections = 200`),
+				ExpectError: regexp.MustCompile(`parameter "work_mem" must be an integer, got "many"`),
+			},
+		},
+	})
+}
+
+func TestUnitDBaaSPostgreSQLDatastoreV1Replica(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore, dbaasReplica dbaas.Datastore
+	resourceName := "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1"
+	replicaResourceName := "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_2"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	replicaName := acctest.RandomWithPrefix("tf-acc-ds-replica")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Replica(projectName, datastoreName, replicaName, "13", 32, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					testAccCheckDBaaSDatastoreV1Exists(replicaResourceName, &dbaasReplica),
+					resource.TestCheckResourceAttr(replicaResourceName, "region", "ru-9"),
+					resource.TestCheckResourceAttrPair(replicaResourceName, "replica_of.0.datastore_id", resourceName, "id"),
+					resource.TestCheckResourceAttr(replicaResourceName, "replica_of.0.region", "ru-3"),
+				),
+			},
+			{
+				ResourceName:      replicaResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccSelectelImportStateIDFunc(replicaResourceName, "project_id", "region"),
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Replica(projectName, datastoreName, replicaName, "14", 32, true),
+				ExpectError: regexp.MustCompile(`replica version 14 doesn't match version 13 of the source datastore`),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Replica(projectName, datastoreName, replicaName, "13", 16, true),
+				ExpectError: regexp.MustCompile(`replica flavor disk 16 GB is smaller than disk 32 GB of the source datastore`),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Replica(projectName, datastoreName, replicaName, "13", 32, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(replicaResourceName, "replica_of.#", "0"),
+					resource.TestCheckResourceAttrPtr(replicaResourceName, "id", &dbaasReplica.ID),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDBaaSPostgreSQLDatastoreV1Upgrade(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	var retiredTypeID string
+	resourceName := "selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Upgrade(projectName, datastoreName, "13"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttrPair(resourceName, "type_id", "data.selectel_dbaas_datastore_type_v1.dt", "datastore_types.0.id"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Upgrade(projectName, datastoreName, "15"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &dbaasDatastore.ID),
+					resource.TestCheckResourceAttrPair(resourceName, "type_id", "data.selectel_dbaas_datastore_type_v1.dt", "datastore_types.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "config.work_mem", strconv.Itoa(128)),
+					testAccCheckDBaaSDatastoreV1BackupsCount(resourceName, 1),
+					resource.TestCheckResourceAttrWith(resourceName, "type_id", func(value string) error {
+						retiredTypeID = value
+
+						return nil
+					}),
+				),
+			},
+			{
+				// The datastore of a retired type that the API doesn't return is
+				// replaced.
+				PreConfig: func() { backend.FailRequests(http.MethodGet, "/datastore-types/"+retiredTypeID) },
+				Config:    testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Upgrade(projectName, datastoreName, "16"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Replaced(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttrPair(resourceName, "type_id", "data.selectel_dbaas_datastore_type_v1.dt", "datastore_types.0.id"),
+				),
+			},
+			{
+				PreConfig: backend.ClearFailures,
+				Config:    testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLDatastoreV1Upgrade(projectName, datastoreName, "14"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Replaced(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttrPair(resourceName, "type_id", "data.selectel_dbaas_datastore_type_v1.dt", "datastore_types.0.id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDBaaSDatastoreV1Replaced(n string, dbaasDatastore *dbaas.Datastore) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == dbaasDatastore.ID {
+			return fmt.Errorf("datastore %s wasn't replaced", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDBaaSDatastoreV1BackupsCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		ctx := context.Background()
+		dbaasClient, err := newTestDBaaSClient(ctx, rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		backups, err := getDBaaSBackups(ctx, dbaasClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(backups) != count {
+			return fmt.Errorf("expected %d backups of datastore %s, got %d", count, rs.Primary.ID, len(backups))
+		}
+
+		return nil
+	}
+}
+
 func testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -426,3 +697,151 @@ resource "selectel_dbaas_postgresql_datastore_v1" "datastore_tf_acc_test_1" {
   }
 }`, projectName, datastoreName, nodeCount)
 }
+
+func testAccDBaaSPostgreSQLDatastoreV1WithConfig(projectName, datastoreName, config string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_vpc_subnet_v2" "subnet_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-3"
+}
+
+data "selectel_dbaas_datastore_type_v1" "dt" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  filter {
+    engine = "postgresql"
+    version = "13"
+  }
+}
+
+resource "selectel_dbaas_postgresql_datastore_v1" "datastore_tf_acc_test_1" {
+  name = "%s"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  subnet_id = "${selectel_vpc_subnet_v2.subnet_tf_acc_test_1.subnet_id}"
+  node_count = 1
+  flavor {
+    vcpus = 2
+    ram = 4096
+    disk = 32
+  }
+  config = {%s
+  }
+}`, projectName, datastoreName, config)
+}
+
+func testAccDBaaSPostgreSQLDatastoreV1Replica(projectName, datastoreName, replicaName, replicaVersion string, replicaDisk int, replicaOf bool) string {
+	replicaOfBlock := ""
+	if replicaOf {
+		replicaOfBlock = `
+  replica_of {
+    datastore_id = "${selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1.id}"
+    region = "ru-3"
+  }`
+	}
+
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_vpc_subnet_v2" "subnet_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-3"
+}
+
+resource "selectel_vpc_subnet_v2" "subnet_tf_acc_test_2" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-9"
+}
+
+data "selectel_dbaas_datastore_type_v1" "dt" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  filter {
+    engine = "postgresql"
+    version = "13"
+  }
+}
+
+data "selectel_dbaas_datastore_type_v1" "dt_replica" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-9"
+  filter {
+    engine = "postgresql"
+    version = "%s"
+  }
+}
+
+resource "selectel_dbaas_postgresql_datastore_v1" "datastore_tf_acc_test_1" {
+  name = "%s"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  subnet_id = "${selectel_vpc_subnet_v2.subnet_tf_acc_test_1.subnet_id}"
+  node_count = 1
+  flavor {
+    vcpus = 2
+    ram = 4096
+    disk = 32
+  }
+}
+
+resource "selectel_dbaas_postgresql_datastore_v1" "datastore_tf_acc_test_2" {
+  name = "%s"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-9"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt_replica.datastore_types[0].id}"
+  subnet_id = "${selectel_vpc_subnet_v2.subnet_tf_acc_test_2.subnet_id}"
+  node_count = 1
+  flavor {
+    vcpus = 2
+    ram = 4096
+    disk = %d
+  }%s
+}`, projectName, replicaVersion, datastoreName, replicaName, replicaDisk, replicaOfBlock)
+}
+
+func testAccDBaaSPostgreSQLDatastoreV1Upgrade(projectName, datastoreName, version string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_vpc_subnet_v2" "subnet_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-3"
+}
+
+data "selectel_dbaas_datastore_type_v1" "dt" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  filter {
+    engine = "postgresql"
+    version = "%s"
+  }
+}
+
+resource "selectel_dbaas_postgresql_datastore_v1" "datastore_tf_acc_test_1" {
+  name = "%s"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  subnet_id = "${selectel_vpc_subnet_v2.subnet_tf_acc_test_1.subnet_id}"
+  node_count = 1
+  backup_before_upgrade = true
+  flavor {
+    vcpus = 2
+    ram = 4096
+    disk = 32
+  }
+  config = {
+    work_mem = 128
+  }
+}`, projectName, version, datastoreName)
+}
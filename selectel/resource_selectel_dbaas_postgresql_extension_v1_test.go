@@ -26,9 +26,9 @@ func TestAccDBaaSPostgreSQLExtensionV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPostgreSQLExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, extensionName, nodeCount),
@@ -44,6 +44,43 @@ func TestAccDBaaSPostgreSQLExtensionV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSPostgreSQLExtensionV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasExtension dbaas.Extension
+	resourceName := "selectel_dbaas_postgresql_extension_v1.extension_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, "hstore", nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSExtensionV1Exists(resourceName, &dbaasExtension),
+					resource.TestCheckResourceAttrPair(resourceName, "available_extension_id",
+						"data.selectel_dbaas_available_extension_v1.ae", "available_extensions.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "datastore_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "database_id"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSPostgreSQLExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, "hstore", nodeCount))
+}
+
 func testAccDBaaSPostgreSQLExtensionV1Basic(projectName, datastoreName, userName, userPassword, databaseName, extensionName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -28,9 +28,9 @@ func TestAccDBaaSPostgreSQLLogicalReplicationSlotV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPostgreSQLLogicalReplicationSlotV1Basic(projectName, datastoreName, userName, userPassword, databaseName, slotName, nodeCount),
@@ -46,6 +46,43 @@ func TestAccDBaaSPostgreSQLLogicalReplicationSlotV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSPostgreSQLLogicalReplicationSlotV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasSlot dbaas.LogicalReplicationSlot
+	resourceName := "selectel_dbaas_postgresql_logical_replication_slot_v1.slot_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	databaseName := RandomWithPrefix("tf_acc_db")
+	slotName := RandomWithPrefix("tf_acc_slot")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSPostgreSQLLogicalReplicationSlotV1Basic(projectName, datastoreName, userName, userPassword, databaseName, slotName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSLogicalReplicationSlotV1Exists(resourceName, &dbaasSlot),
+					resource.TestCheckResourceAttr(resourceName, "name", slotName),
+					resource.TestCheckResourceAttrSet(resourceName, "datastore_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "database_id"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSPostgreSQLLogicalReplicationSlotV1Basic(projectName, datastoreName, userName, userPassword, databaseName, slotName, nodeCount))
+}
+
 func testAccCheckDBaaSLogicalReplicationSlotV1Exists(n string, dbaasSlot *dbaas.LogicalReplicationSlot) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
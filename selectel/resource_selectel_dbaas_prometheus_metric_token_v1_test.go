@@ -24,9 +24,9 @@ func TestAccDBaaSPrometheusMetricTokenV1Basic(t *testing.T) {
 	updatedTokenName := acctest.RandomWithPrefix("tf-acc-token-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPrometheusMetricTokenV1Basic(projectName, tokenName),
@@ -48,6 +48,46 @@ func TestAccDBaaSPrometheusMetricTokenV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSPrometheusMetricTokenV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var dbaasToken dbaas.PrometheusMetricToken
+	resourceName := "selectel_dbaas_prometheus_metric_token_v1.prometheus_metric_token_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	tokenName := acctest.RandomWithPrefix("tf-acc-token")
+	updatedTokenName := acctest.RandomWithPrefix("tf-acc-token-updated")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDBaaSPrometheusMetricTokenV1Basic(projectName, tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDBaaSPrometheusMetricTokenV1Exists(resourceName, &dbaasToken),
+					resource.TestCheckResourceAttr(resourceName, "name", tokenName),
+					resource.TestCheckResourceAttrSet(resourceName, "value"),
+				),
+			},
+			{
+				Config: providerConfig + testAccDBaaSPrometheusMetricTokenV1Update(projectName, updatedTokenName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDBaaSPrometheusMetricTokenV1Exists(resourceName, &dbaasToken),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedTokenName),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccDBaaSPrometheusMetricTokenV1Basic(projectName, tokenName))
+}
+
 func testAccDBaaSPrometheusMetricTokenV1Exists(n string, dbaasToken *dbaas.PrometheusMetricToken) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
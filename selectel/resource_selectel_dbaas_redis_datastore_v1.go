@@ -2,7 +2,6 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -26,7 +25,11 @@ func resourceDBaaSRedisDatastoreV1() *schema.Resource {
 			StateContext: resourceDBaaSRedisDatastoreV1ImportState,
 		},
 		CustomizeDiff: customdiff.All(
+			dbaasDatastoreV1UpgradeDiff,
 			refreshDatastoreInstancesOutputsDiff,
+			dbaasDatastoreV1ConfigDiff,
+			dbaasPasswordConfigDiff("redis_password"),
+			dbaasGeneratedPasswordDiff("redis_password"),
 		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -57,7 +60,12 @@ func resourceDBaaSRedisDatastoreV1() *schema.Resource {
 			"type_id": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+				ForceNew: false,
+			},
+			"backup_before_upgrade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Take a backup of the datastore before the upgrade to a newer version.",
 			},
 			"flavor_id": {
 				Type:     schema.TypeString,
@@ -172,9 +180,36 @@ func resourceDBaaSRedisDatastoreV1() *schema.Resource {
 				},
 			},
 			"redis_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				ForceNew:  false,
+			},
+			"generate_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Generate the password of the datastore instead of taking it from the config.",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value that generates a new password when it changes.",
+			},
+			"rotation_period": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDBaaSPasswordRotationPeriod,
+				Description:  "Period after which a new password is generated, for example, 720h.",
+			},
+			"password_rotated_at": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: false,
+				Computed: true,
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key of the Secrets Manager secret to write the password to.",
 			},
 			"instances": {
 				Type:     schema.TypeList,
@@ -236,10 +271,11 @@ func resourceDBaaSRedisDatastoreV1Create(ctx context.Context, d *schema.Resource
 		datastoreCreateOpts.FlavorID = flavorID.(string)
 	}
 
-	redisPassword, redisPasswordOk := d.GetOk("redis_password")
-	if redisPasswordOk {
-		datastoreCreateOpts.RedisPassword = redisPassword.(string)
+	redisPassword, err := newDBaaSPassword(d, "redis_password")
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectDatastore, err))
 	}
+	datastoreCreateOpts.RedisPassword = redisPassword
 
 	backupRetentionDays, ok := d.GetOk("backup_retention_days")
 	if ok {
@@ -260,6 +296,20 @@ func resourceDBaaSRedisDatastoreV1Create(ctx context.Context, d *schema.Resource
 	}
 
 	d.SetId(datastore.ID)
+	setDBaaSGeneratedPassword(d, "redis_password", redisPassword)
+
+	diagErr = updateDBaaSPasswordSecret(ctx, d, meta, redisPassword, dbaasRedisPasswordSecretDescription(d))
+	if diagErr != nil {
+		return diagErr
+	}
+
+	// Firewall rules can't be passed in the create request.
+	if d.Get("firewall").(*schema.Set).Len() != 0 {
+		err = updateDatastoreFirewall(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	return resourceDBaaSRedisDatastoreV1Read(ctx, d, meta)
 }
@@ -307,6 +357,18 @@ func resourceDBaaSRedisDatastoreV1Read(ctx context.Context, d *schema.ResourceDa
 		log.Print(errSettingComplexAttr("config", err))
 	}
 
+	if err := d.Set("firewall", resourceDBaaSDatastoreV1FirewallToList(d, datastore.Firewall)); err != nil {
+		log.Print(errSettingComplexAttr("firewall", err))
+	}
+
+	floatingIPs, err := resourceDBaaSDatastoreV1FloatingIPsToList(d, datastore)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDatastore, d.Id(), err))
+	}
+	if err := d.Set("floating_ips", floatingIPs); err != nil {
+		log.Print(errSettingComplexAttr("floating_ips", err))
+	}
+
 	return nil
 }
 
@@ -316,6 +378,14 @@ func resourceDBaaSRedisDatastoreV1Update(ctx context.Context, d *schema.Resource
 		return diagErr
 	}
 
+	var diags diag.Diagnostics
+
+	if d.HasChange("type_id") {
+		err := updateDatastoreType(ctx, d, dbaasClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if d.HasChange("name") {
 		err := updateDatastoreName(ctx, d, dbaasClient)
 		if err != nil {
@@ -339,12 +409,34 @@ func resourceDBaaSRedisDatastoreV1Update(ctx context.Context, d *schema.Resource
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		diags = append(diags, dbaasDatastoreV1ConfigRestartWarnings(ctx, d, dbaasClient)...)
 	}
-	if d.HasChange("redis_password") {
-		err := updateRedisDatastorePassword(ctx, d, dbaasClient)
+	passwordChanged := dbaasPasswordChanged(d, "redis_password")
+	if passwordChanged {
+		redisPassword, err := newDBaaSPassword(d, "redis_password")
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectDatastore, d.Id(), err))
+		}
+		err = setRedisDatastorePassword(ctx, d, dbaasClient, redisPassword)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		// The password is accepted, so it's kept in the state and in the secret
+		// even if the datastore doesn't become active.
+		setDBaaSGeneratedPassword(d, "redis_password", redisPassword)
+
+		err = waitForRedisDatastorePassword(ctx, d, dbaasClient)
+		if err != nil {
+			diagErr = updateDBaaSPasswordSecret(ctx, d, meta, redisPassword, dbaasRedisPasswordSecretDescription(d))
+
+			return append(diag.FromErr(err), diagErr...)
+		}
+	}
+	if passwordChanged || d.HasChange("secret_key") {
+		diagErr = updateDBaaSPasswordSecret(ctx, d, meta, d.Get("redis_password").(string), dbaasRedisPasswordSecretDescription(d))
+		if diagErr != nil {
+			return diagErr
+		}
 	}
 	if d.HasChange("backup_retention_days") {
 		err := updateDatastoreBackups(ctx, d, dbaasClient)
@@ -359,7 +451,7 @@ func resourceDBaaSRedisDatastoreV1Update(ctx context.Context, d *schema.Resource
 		}
 	}
 
-	return resourceDBaaSRedisDatastoreV1Read(ctx, d, meta)
+	return append(diags, resourceDBaaSRedisDatastoreV1Read(ctx, d, meta)...)
 }
 
 func resourceDBaaSRedisDatastoreV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -379,8 +471,8 @@ func resourceDBaaSRedisDatastoreV1Delete(ctx context.Context, d *schema.Resource
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasDatastoreV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become deleted", d.Id())
@@ -389,20 +481,26 @@ func resourceDBaaSRedisDatastoreV1Delete(ctx context.Context, d *schema.Resource
 		return diag.FromErr(fmt.Errorf("error waiting for the datastore %s to become deleted: %s", d.Id(), err))
 	}
 
+	if secretKey := d.Get("secret_key").(string); secretKey != "" {
+		return deleteDBaaSPasswordSecret(ctx, d, meta, secretKey)
+	}
+
 	return nil
 }
 
+func dbaasRedisPasswordSecretDescription(d *schema.ResourceData) string {
+	return fmt.Sprintf("Password of the datastore %s", d.Id())
+}
+
 func resourceDBaaSRedisDatastoreV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "datastore_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -2,6 +2,8 @@ package selectel
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -23,9 +25,9 @@ func TestAccDBaaSRedisDatastoreV1Basic(t *testing.T) {
 	resizeNodeCount := 2
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSRedisDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -91,6 +93,98 @@ func TestAccDBaaSRedisDatastoreV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSRedisDatastoreV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	resourceName := "selectel_dbaas_redis_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	nodeCount := 1
+	resizeNodeCount := 2
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1Basic(projectName, datastoreName, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "name", datastoreName),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttrSet(resourceName, "connections.master"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1UpdateConfig(projectName, datastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1UpdatePassword(projectName, datastoreName, nodeCount),
+				Check:  testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1Resize(projectName, datastoreName, resizeNodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestCheckResourceAttr(resourceName, "node_count", strconv.Itoa(resizeNodeCount)),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"redis_password"},
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSRedisDatastoreV1Basic(projectName, datastoreName, nodeCount))
+}
+
+func TestUnitDBaaSRedisDatastoreV1GeneratedPassword(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasDatastore dbaas.Datastore
+	var password string
+	resourceName := "selectel_dbaas_redis_datastore_v1.datastore_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	secretKey := acctest.RandomWithPrefix("tf-acc-secret")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1GeneratedPassword(projectName, datastoreName, secretKey, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists(resourceName, &dbaasDatastore),
+					resource.TestMatchResourceAttr(resourceName, "redis_password", regexp.MustCompile(`^[a-zA-Z0-9]{32}$`)),
+					testAccCheckDBaaSPasswordSecret(resourceName, "redis_password", secretKey, true),
+					testAccSaveDBaaSPassword(resourceName, "redis_password", &password),
+				),
+			},
+			{
+				// The datastore accepts the password, but doesn't become active.
+				PreConfig: func() {
+					backend.FailRequestsAfter(http.MethodPut, "/datastores/"+dbaasDatastore.ID+"/password", http.MethodGet, "/datastores/"+dbaasDatastore.ID)
+				},
+				Config:      testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1GeneratedPassword(projectName, datastoreName, secretKey, "2"),
+				ExpectError: regexp.MustCompile(`error waiting for the datastore .* to become 'ACTIVE'`),
+			},
+			{
+				PreConfig: backend.ClearFailures,
+				Config:    testUnitProviderConfig(backend) + testAccDBaaSRedisDatastoreV1GeneratedPassword(projectName, datastoreName, secretKey, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSPasswordRotated(resourceName, "redis_password", &password),
+					testAccCheckDBaaSPasswordSecret(resourceName, "redis_password", secretKey, true),
+				),
+			},
+		},
+	})
+}
+
 func testAccDBaaSRedisDatastoreV1Basic(projectName, datastoreName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -262,3 +356,45 @@ config = {
 redis_password = "quie7Hoh7ohTo[i0bae3Leeb4mai7ca6123"
 }`, projectName, datastoreName, nodeCount)
 }
+
+func testAccDBaaSRedisDatastoreV1GeneratedPassword(projectName, datastoreName, secretKey, rotationTrigger string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_vpc_subnet_v2" "subnet_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-3"
+}
+
+data "selectel_dbaas_datastore_type_v1" "dt" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  filter {
+    engine = "redis"
+    version = "6"
+  }
+}
+
+data "selectel_dbaas_flavor_v1" "flavor" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-3"
+  filter {
+    datastore_type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  }
+}
+
+resource "selectel_dbaas_redis_datastore_v1" "datastore_tf_acc_test_1" {
+  name = "%s"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  subnet_id = "${selectel_vpc_subnet_v2.subnet_tf_acc_test_1.subnet_id}"
+  node_count = 1
+  flavor_id = "${data.selectel_dbaas_flavor_v1.flavor.flavors[0].id}"
+  generate_password = true
+  rotation_trigger = "%s"
+  secret_key = "%s"
+}`, projectName, datastoreName, rotationTrigger, secretKey)
+}
@@ -2,7 +2,6 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
@@ -24,6 +24,10 @@ func resourceDBaaSUserV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDBaaSUserV1ImportState,
 		},
+		CustomizeDiff: customdiff.All(
+			dbaasPasswordConfigDiff("password"),
+			dbaasGeneratedPasswordDiff("password"),
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -46,9 +50,36 @@ func resourceDBaaSUserV1() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				ForceNew:  false,
+			},
+			"generate_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Generate the password of the user instead of taking it from the config.",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value that generates a new password when it changes.",
+			},
+			"rotation_period": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDBaaSPasswordRotationPeriod,
+				Description:  "Period after which a new password is generated, for example, 720h.",
+			},
+			"password_rotated_at": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: false,
+				Computed: true,
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key of the Secrets Manager secret to write the password to.",
 			},
 			"status": {
 				Type:     schema.TypeString,
@@ -74,10 +105,15 @@ func resourceDBaaSUserV1Create(ctx context.Context, d *schema.ResourceData, meta
 		return diagErr
 	}
 
+	password, err := newDBaaSPassword(d, "password")
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectUser, err))
+	}
+
 	userCreateOpts := dbaas.UserCreateOpts{
 		DatastoreID: datastoreID,
 		Name:        d.Get("name").(string),
-		Password:    d.Get("password").(string),
+		Password:    password,
 	}
 
 	log.Print(msgCreate(objectUser, userCreateOpts))
@@ -94,6 +130,12 @@ func resourceDBaaSUserV1Create(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	d.SetId(user.ID)
+	setDBaaSGeneratedPassword(d, "password", password)
+
+	diagErr = updateDBaaSPasswordSecret(ctx, d, meta, password, dbaasUserPasswordSecretDescription(d))
+	if diagErr != nil {
+		return diagErr
+	}
 
 	return resourceDBaaSUserV1Read(ctx, d, meta)
 }
@@ -127,23 +169,38 @@ func resourceDBaaSUserV1Update(ctx context.Context, d *schema.ResourceData, meta
 		return diagErr
 	}
 
-	if d.HasChange("password") {
-		password := d.Get("password").(string)
+	passwordChanged := dbaasPasswordChanged(d, "password")
+	if passwordChanged {
+		password, err := newDBaaSPassword(d, "password")
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectUser, d.Id(), err))
+		}
 		updateOpts := dbaas.UserUpdateOpts{
 			Password: password,
 		}
 
 		log.Print(msgUpdate(objectUser, d.Id(), updateOpts))
-		_, err := dbaasClient.UpdateUser(ctx, d.Id(), updateOpts)
+		_, err = dbaasClient.UpdateUser(ctx, d.Id(), updateOpts)
 		if err != nil {
 			return diag.FromErr(errUpdatingObject(objectUser, d.Id(), err))
 		}
+		// The password is accepted, so it's kept in the state and in the secret
+		// even if the user doesn't become active.
+		setDBaaSGeneratedPassword(d, "password", password)
 
 		log.Printf("[DEBUG] waiting for user %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutCreate)
 		err = waitForDBaaSUserV1ActiveState(ctx, dbaasClient, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectUser, d.Id(), err))
+			diagErr = updateDBaaSPasswordSecret(ctx, d, meta, password, dbaasUserPasswordSecretDescription(d))
+
+			return append(diag.FromErr(errUpdatingObject(objectUser, d.Id(), err)), diagErr...)
+		}
+	}
+	if passwordChanged || d.HasChange("secret_key") {
+		diagErr = updateDBaaSPasswordSecret(ctx, d, meta, d.Get("password").(string), dbaasUserPasswordSecretDescription(d))
+		if diagErr != nil {
+			return diagErr
 		}
 	}
 
@@ -172,8 +229,8 @@ func resourceDBaaSUserV1Delete(ctx context.Context, d *schema.ResourceData, meta
 		Target:     []string{strconv.Itoa(http.StatusNotFound)},
 		Refresh:    dbaasUserV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for user %s to become deleted", d.Id())
@@ -182,20 +239,26 @@ func resourceDBaaSUserV1Delete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(fmt.Errorf("error waiting for the user %s to become deleted: %s", d.Id(), err))
 	}
 
+	if secretKey := d.Get("secret_key").(string); secretKey != "" {
+		return deleteDBaaSPasswordSecret(ctx, d, meta, secretKey)
+	}
+
 	return nil
 }
 
+func dbaasUserPasswordSecretDescription(d *schema.ResourceData) string {
+	return fmt.Sprintf("Password of the user %s of the datastore %s", d.Get("name").(string), d.Get("datastore_id").(string))
+}
+
 func resourceDBaaSUserV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "user_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -2,8 +2,11 @@ package selectel
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -26,9 +29,9 @@ func TestAccDBaaSUserV1Basic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSUserV1Basic(projectName, datastoreName, userName, userPassword, nodeCount),
@@ -44,6 +47,204 @@ func TestAccDBaaSUserV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSUserV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasUser dbaas.User
+	resourceName := "selectel_dbaas_user_v1.user_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	nodeCount := 1
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSUserV1Basic(projectName, datastoreName, userName, userPassword, nodeCount),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSUserV1Exists(resourceName, &dbaasUser),
+					resource.TestCheckResourceAttr(resourceName, "name", userName),
+					resource.TestCheckResourceAttr(resourceName, "password", userPassword),
+					resource.TestCheckResourceAttr(resourceName, "status", string(dbaas.StatusActive)),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDBaaSUserV1Basic(projectName, datastoreName, userName, userPassword, nodeCount))
+}
+
+func TestUnitDBaaSUserV1GeneratedPassword(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var dbaasUser dbaas.User
+	var password string
+	resourceName := "selectel_dbaas_user_v1.user_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	userName := RandomWithPrefix("tf_acc_user")
+	userPassword := acctest.RandomWithPrefix("tf-acc-pass")
+	secretKey := acctest.RandomWithPrefix("tf-acc-secret")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSUserV1Exists(resourceName, &dbaasUser),
+					resource.TestMatchResourceAttr(resourceName, "password", regexp.MustCompile(`^[a-zA-Z0-9]{32}$`)),
+					resource.TestCheckResourceAttrSet(resourceName, "password_rotated_at"),
+					testAccCheckDBaaSPasswordSecret(resourceName, "password", secretKey, true),
+					testAccSaveDBaaSPassword(resourceName, "password", &password),
+				),
+			},
+			{
+				PreConfig:   func() { backend.FailRequests(http.MethodPost, "/"+secretKey) },
+				Config:      testUnitProviderConfig(backend) + testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, "2"),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("secret %s was deleted, but the password can't be written to it", secretKey)),
+			},
+			{
+				PreConfig: backend.ClearFailures,
+				Config:    testUnitProviderConfig(backend) + testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSPasswordRotated(resourceName, "password", &password),
+					testAccCheckDBaaSPasswordSecret(resourceName, "password", secretKey, true),
+					testAccSaveDBaaSPassword(resourceName, "password", &password),
+				),
+			},
+			{
+				// The user accepts the password, but doesn't become active.
+				PreConfig: func() {
+					backend.FailRequestsAfter(http.MethodPut, "/users/"+dbaasUser.ID, http.MethodGet, "/users/"+dbaasUser.ID)
+				},
+				Config:      testUnitProviderConfig(backend) + testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, "3"),
+				ExpectError: regexp.MustCompile(`error waiting for the user .* to become 'ACTIVE'`),
+			},
+			{
+				PreConfig: backend.ClearFailures,
+				Config:    testUnitProviderConfig(backend) + testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, "3"),
+				PlanOnly:  true,
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, "3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSPasswordRotated(resourceName, "password", &password),
+					testAccCheckDBaaSPasswordSecret(resourceName, "password", secretKey, true),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDBaaSUserV1Basic(projectName, datastoreName, userName, userPassword, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "password", userPassword),
+					resource.TestCheckResourceAttr(resourceName, "password_rotated_at", ""),
+					testAccCheckDBaaSPasswordSecret(resourceName, "password", secretKey, false),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDBaaSUserV1PasswordConfig(t *testing.T) {
+	backend := testUnitPreCheck(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + testUnitDBaaSUserV1PasswordConfig(""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`either "password" or "generate_password" must be set`),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testUnitDBaaSUserV1PasswordConfig("password = \"secret\"\n  generate_password = true"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"password" can't be set when "generate_password" is enabled`),
+			},
+			{
+				Config:             testUnitProviderConfig(backend) + testUnitDBaaSUserV1PasswordConfig("password = \"secret\"\n  generate_password = false"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckDBaaSPasswordSecret checks that the Secrets Manager secret
+// with the key holds the password of the resource or doesn't exist.
+func testAccCheckDBaaSPasswordSecret(n, passwordKey, secretKey string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		d := resourceSecretsManagerSecretV1().TestResourceData()
+		d.Set("project_id", rs.Primary.Attributes["project_id"])
+		cl, diagErr := getSecretsManagerClient(d, testAccProvider.Meta())
+		if diagErr != nil {
+			return fmt.Errorf("can't get secretsmanager client: %s", diagErr[0].Summary)
+		}
+
+		secret, err := cl.Secrets.Get(context.Background(), secretKey)
+		if !exists {
+			if err == nil {
+				return fmt.Errorf("secret %s still exists", secretKey)
+			}
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		value, err := base64.StdEncoding.DecodeString(secret.Version.Value)
+		if err != nil {
+			return err
+		}
+		if string(value) != rs.Primary.Attributes[passwordKey] {
+			return fmt.Errorf("secret %s doesn't hold the %s of %s", secretKey, passwordKey, n)
+		}
+
+		return nil
+	}
+}
+
+func testAccSaveDBaaSPassword(n, passwordKey string, password *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		*password = rs.Primary.Attributes[passwordKey]
+
+		return nil
+	}
+}
+
+func testAccCheckDBaaSPasswordRotated(n, passwordKey string, password *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if newPassword := rs.Primary.Attributes[passwordKey]; newPassword == "" || newPassword == *password {
+			return fmt.Errorf("%s of %s wasn't rotated", passwordKey, n)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckDBaaSUserV1Exists(n string, dbaasUser *dbaas.User) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -115,3 +316,60 @@ resource "selectel_dbaas_user_v1" "user_tf_acc_test_1" {
   password = "%s"
 }`, projectName, datastoreName, nodeCount, userName, userPassword)
 }
+
+func testAccDBaaSUserV1GeneratedPassword(projectName, datastoreName, userName, secretKey, rotationTrigger string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_vpc_subnet_v2" "subnet_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region     = "ru-3"
+}
+
+data "selectel_dbaas_datastore_type_v1" "dt" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  filter {
+    engine = "postgresql"
+    version = "12"
+  }
+}
+
+resource "selectel_dbaas_datastore_v1" "datastore_tf_acc_test_1" {
+  name = "%s"
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  subnet_id = "${selectel_vpc_subnet_v2.subnet_tf_acc_test_1.subnet_id}"
+  node_count = 1
+  flavor {
+    vcpus = 2
+    ram = 4096
+    disk = 32
+  }
+}
+
+resource "selectel_dbaas_user_v1" "user_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region = "ru-3"
+  datastore_id = "${selectel_dbaas_datastore_v1.datastore_tf_acc_test_1.id}"
+  name = "%s"
+  generate_password = true
+  rotation_trigger = "%s"
+  rotation_period = "720h"
+  secret_key = "%s"
+}`, projectName, datastoreName, userName, rotationTrigger, secretKey)
+}
+
+func testUnitDBaaSUserV1PasswordConfig(passwordConfig string) string {
+	return fmt.Sprintf(`
+resource "selectel_dbaas_user_v1" "user_tf_acc_test_1" {
+  project_id = "project"
+  region = "ru-3"
+  datastore_id = "datastore"
+  name = "user"
+  %s
+}`, passwordConfig)
+}
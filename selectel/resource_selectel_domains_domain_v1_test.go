@@ -19,9 +19,9 @@ func TestAccDomainsDomainV1Basic(t *testing.T) {
 	testDomainName := fmt.Sprintf("%s.xyz", acctest.RandomWithPrefix("tf-acc"))
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV1DomainDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV1DomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsDomainV1Basic(testDomainName),
@@ -36,6 +36,35 @@ func TestAccDomainsDomainV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDomainsDomainV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var testDomain domain.View
+	resourceName := "selectel_domains_domain_v1.domain_tf_acc_test_1"
+	testDomainName := fmt.Sprintf("%s.xyz", acctest.RandomWithPrefix("tf-acc"))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV1DomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsDomainV1Basic(testDomainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainsDomainV1Exists(resourceName, &testDomain),
+					resource.TestCheckResourceAttr(resourceName, "name", testDomainName),
+					resource.TestCheckResourceAttrSet(resourceName, "user_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDomainsDomainV1Basic(testDomainName))
+}
+
 func testAccDomainsDomainV1Basic(domainName string) string {
 	return fmt.Sprintf(`
 resource "selectel_domains_domain_v1" "domain_tf_acc_test_1" {
@@ -194,6 +194,7 @@ func resourceDomainsRecordV1Read(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(errGettingObject(objectRecord, d.Id(), err))
 	}
 
+	d.Set("domain_id", domainID)
 	d.Set("name", recordObj.Name)
 	d.Set("type", recordObj.Type)
 	d.Set("ttl", recordObj.TTL)
@@ -42,9 +42,9 @@ func TestAccDomainsRecordV1Basic(t *testing.T) {
 	testRecordNameSSHFP := fmt.Sprintf("sshfp.%s", testDomainName)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsRecordV1Destroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsRecordV1Destroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRecordV1Basic(
@@ -436,6 +436,99 @@ func TestAccDomainsRecordV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDomainsRecordV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var (
+		testRecordA     record.View
+		testRecordSRV   record.View
+		testRecordSSHFP record.View
+	)
+	resourceName := "selectel_domains_record_v1.record_a_tf_acc_test_1"
+
+	testDomainName := fmt.Sprintf("%s.xyz", acctest.RandomWithPrefix("tf-acc"))
+	testRecordNameA := fmt.Sprintf("a.%s", testDomainName)
+	testRecordNameAAAA := fmt.Sprintf("aaaa.%s", testDomainName)
+	testRecordNameCNAME := fmt.Sprintf("cname.%s", testDomainName)
+	testRecordNameTXT := fmt.Sprintf("txt.%s", testDomainName)
+	testRecordNameNS := fmt.Sprintf("ns.%s", testDomainName)
+	testRecordNameMX := fmt.Sprintf("mx.%s", testDomainName)
+	testRecordNameSRV := fmt.Sprintf("srv.%s", testDomainName)
+	testRecordNameCAA := fmt.Sprintf("caa.%s", testDomainName)
+	testRecordNameALIAS := fmt.Sprintf("alias.%s", testDomainName)
+	testRecordNameSSHFP := fmt.Sprintf("sshfp.%s", testDomainName)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsRecordV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsRecordV1Basic(
+					testDomainName,
+					testRecordNameA,
+					testRecordNameAAAA,
+					testRecordNameCNAME,
+					testRecordNameTXT,
+					testRecordNameNS,
+					testRecordNameMX,
+					testRecordNameSRV,
+					testRecordNameCAA,
+					testRecordNameALIAS,
+					testRecordNameSSHFP,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainsRecordV1Exists(resourceName, &testRecordA),
+					resource.TestCheckResourceAttr(resourceName, "name", testRecordNameA),
+					resource.TestCheckResourceAttr(resourceName, "type", "A"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsRecordV1Update(
+					testDomainName,
+					testRecordNameA,
+					testRecordNameAAAA,
+					testRecordNameCNAME,
+					testRecordNameTXT,
+					testRecordNameNS,
+					testRecordNameMX,
+					testRecordNameSRV,
+					testRecordNameCAA,
+					testRecordNameALIAS,
+					testRecordNameSSHFP,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainsRecordV1Exists(resourceName, &testRecordA),
+					resource.TestCheckResourceAttr(resourceName, "content", "10.10.10.10"),
+					resource.TestCheckResourceAttr(resourceName, "ttl", "120"),
+					testAccCheckDomainsRecordV1Exists("selectel_domains_record_v1.record_srv_tf_acc_test_1", &testRecordSRV),
+					resource.TestCheckResourceAttr("selectel_domains_record_v1.record_srv_tf_acc_test_1", "port", "5061"),
+					resource.TestCheckResourceAttr("selectel_domains_record_v1.record_srv_tf_acc_test_1", "weight", "20"),
+					testAccCheckDomainsRecordV1Exists("selectel_domains_record_v1.record_sshfp_tf_acc_test_1", &testRecordSSHFP),
+					resource.TestCheckResourceAttr("selectel_domains_record_v1.record_sshfp_tf_acc_test_1", "type", "SSHFP"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDomainsRecordV1Basic(
+		testDomainName,
+		testRecordNameA,
+		testRecordNameAAAA,
+		testRecordNameCNAME,
+		testRecordNameTXT,
+		testRecordNameNS,
+		testRecordNameMX,
+		testRecordNameSRV,
+		testRecordNameCAA,
+		testRecordNameALIAS,
+		testRecordNameSSHFP,
+	))
+}
+
 func testAccDomainsRecordV1Basic(
 	domainName,
 	recordNameA,
@@ -142,22 +142,19 @@ func resourceDomainsRRSetV2Read(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceDomainsRRSetV2ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
+	// concat zone_name,rrset_name,rrset_type with symbol "/" instead of rrset id for importing rrset.
+	// example: terraform import domains_rrset_v2.<resource_name> [<project_id>/]<zone_name>/<rrset_name>/<rrset_type>
+	projectID, id, err := parseImportIDWithProject(meta.(*Config), d.Id(), "zone_name", "rrset_name", "rrset_type")
+	if err != nil {
+		return nil, err
 	}
-	d.Set("project_id", config.ProjectID)
+	d.Set("project_id", projectID)
 
 	client, err := getDomainsV2Client(d, meta)
 	if err != nil {
 		return nil, err
 	}
-	// concat zone_name,rrset_name,rrset_type with symbol "/" instead of rrset id for importing rrset.
-	// example: terraform import domains_rrset_v2.<resource_name> <zone_name>/<rrset_name>/<rrset_type>
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 3 {
-		return nil, errors.New("id must include three parts: zone_name/rrset_name/rrset_type")
-	}
+	parts := strings.Split(id, importIDSeparator)
 
 	zoneName := parts[0]
 	rrsetName := parts[1]
@@ -26,9 +26,9 @@ func TestAccDomainsRRSetV2Basic(t *testing.T) {
 	resourceRRSetName := "rrset_tf_acc_test_1"
 	dataSourceRRSetName := fmt.Sprintf("selectel_domains_rrset_v2.%[1]s", resourceRRSetName)
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2ZoneDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, testRRSetName, string(testRRSetType), testRRSetContent, testRRSetTTL, resourceZoneName, testZoneName),
@@ -43,6 +43,52 @@ func TestAccDomainsRRSetV2Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDomainsRRSetV2Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	testZoneName := fmt.Sprintf("%s.ru.", acctest.RandomWithPrefix("tf-acc"))
+	testRRSetName := fmt.Sprintf("%[1]s.%[2]s", acctest.RandomWithPrefix("tf-acc"), testZoneName)
+	testRRSetType := domainsV2.TXT
+	testRRSetContent := fmt.Sprintf("\"%[1]s\"", acctest.RandString(16))
+	newTestRRSetContent := fmt.Sprintf("\"%[1]s\"", acctest.RandString(16))
+	resourceName := fmt.Sprintf("selectel_domains_rrset_v2.%[1]s", resourceRRSetName)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2RRSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, testRRSetName, string(testRRSetType), testRRSetContent, 60, resourceZoneName, testZoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDomainsRRSetV2ID(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", testRRSetName),
+					resource.TestCheckResourceAttr(resourceName, "type", string(testRRSetType)),
+					resource.TestCheckResourceAttr(resourceName, "ttl", "60"),
+					resource.TestCheckResourceAttrSet(resourceName, "zone_id"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, testRRSetName, string(testRRSetType), newTestRRSetContent, 120, resourceZoneName, testZoneName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ttl", "120"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "records.*", map[string]string{
+						"content": newTestRRSetContent,
+					}),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getTestRRSetIDForImport,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, testRRSetName, string(testRRSetType), testRRSetContent, 60, resourceZoneName, testZoneName))
+}
+
 func testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, rrsetName, rrsetType, rrsetContent string, ttl int, resourceZoneName, zoneName string) string {
 	return fmt.Sprintf(`
 	%[7]s
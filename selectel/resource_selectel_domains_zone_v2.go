@@ -132,21 +132,19 @@ func resourceDomainsZoneV2Read(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceDomainsZoneV2ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
+	// use zone name instead of zone id for importing zone.
+	// example: terraform import domains_zone_v2.<resource_name> [<project_id>/]<zone_name>
+	projectID, zoneName, err := parseImportIDWithProject(meta.(*Config), d.Id(), "zone_name")
+	if err != nil {
+		return nil, err
 	}
-	d.Set("project_id", config.ProjectID)
+	d.Set("project_id", projectID)
 
 	client, err := getDomainsV2Client(d, meta)
 	if err != nil {
 		return nil, err
 	}
 
-	// use zone name instead of zone id for importing zone.
-	// example: terraform import domains_zone_v2.<resource_name> <zone_name>
-	zoneName := d.Id()
-
 	log.Println(msgImport(objectZone, zoneName))
 
 	zone, err := getZoneByName(ctx, client, zoneName)
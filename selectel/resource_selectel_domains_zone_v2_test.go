@@ -18,9 +18,9 @@ func TestAccDomainsZoneV2Basic(t *testing.T) {
 	testZoneName := fmt.Sprintf("%s.xyz.", acctest.RandomWithPrefix("tf-acc"))
 	resourceZoneName := "zone_tf_acc_test_1"
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2ZoneDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsZoneV2Basic(projectName, resourceZoneName, testZoneName),
@@ -33,6 +33,45 @@ func TestAccDomainsZoneV2Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDomainsZoneV2Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	testZoneName := fmt.Sprintf("%s.xyz.", acctest.RandomWithPrefix("tf-acc"))
+	resourceName := fmt.Sprintf("selectel_domains_zone_v2.%[1]s", resourceZoneName)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsZoneV2Basic(projectName, resourceZoneName, testZoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDomainsZoneV2Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", testZoneName),
+					resource.TestCheckResourceAttr(resourceName, "disabled", "false"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccDomainsZoneV2Update(projectName, resourceZoneName, testZoneName, "test comment", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDomainsZoneV2Exists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", "test comment"),
+					resource.TestCheckResourceAttr(resourceName, "disabled", "true"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getTestZoneIDForImport,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccDomainsZoneV2Basic(projectName, resourceZoneName, testZoneName))
+}
+
 func testAccDomainsZoneV2Basic(projectName, resourceName, zoneName string) string {
 	return fmt.Sprintf(`
 		resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -44,6 +83,19 @@ func testAccDomainsZoneV2Basic(projectName, resourceName, zoneName string) strin
 		}`, projectName, resourceName, zoneName)
 }
 
+func testAccDomainsZoneV2Update(projectName, resourceName, zoneName, comment string, disabled bool) string {
+	return fmt.Sprintf(`
+		resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+			name = %[1]q
+		}
+		resource "selectel_domains_zone_v2" %[2]q {
+			name = %[3]q
+			comment = %[4]q
+			disabled = %[5]t
+			project_id = selectel_vpc_project_v2.project_tf_acc_test_1.id
+		}`, projectName, resourceName, zoneName, comment, disabled)
+}
+
 func testAccCheckDomainsV2ZoneDestroy(s *terraform.State) error {
 	ctx := context.Background()
 
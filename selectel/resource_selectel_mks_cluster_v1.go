@@ -8,12 +8,14 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/quotamanager/quotas"
 	"github.com/selectel/mks-go/pkg/v1/cluster"
 )
@@ -33,6 +35,8 @@ func resourceMKSClusterV1() *schema.Resource {
 				func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
 					return d.HasChange("maintenance_window_start")
 				}),
+			mksClusterV1KubeVersionDiff,
+			mksClusterV1QuotaDiff,
 		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -68,6 +72,10 @@ func resourceMKSClusterV1() *schema.Resource {
 					return strings.TrimPrefix(v.(string), "v")
 				},
 			},
+			"sequential_minor_upgrade": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"enable_autorepair": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -146,6 +154,51 @@ func resourceMKSClusterV1() *schema.Resource {
 				Default:  false,
 				ForceNew: true,
 			},
+			"oidc": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"issuer_url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"username_claim": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "sub",
+						},
+						"groups_claim": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "groups",
+						},
+					},
+				},
+			},
+			"audit_logs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"secret_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -172,7 +225,6 @@ func resourceMKSClusterV1Create(ctx context.Context, d *schema.ResourceData, met
 	// Prepare cluster create options.
 	enableAutorepair := d.Get("enable_autorepair").(bool)
 	enablePatchVersionAutoUpgrade := d.Get("enable_patch_version_auto_upgrade").(bool)
-	enablePodSecurityPolicy := d.Get("enable_pod_security_policy").(bool)
 	zonal := d.Get("zonal").(bool)
 	privateKubeAPI := d.Get("private_kube_api").(bool)
 
@@ -182,45 +234,54 @@ func resourceMKSClusterV1Create(ctx context.Context, d *schema.ResourceData, met
 			"set to false in case of zonal cluster"))
 	}
 
-	featureGates, err := getSetAsStrings(d, featureGatesKey)
+	kubeOptions, err := expandMKSClusterV1KubernetesOptions(d)
 	if err != nil {
 		return diag.FromErr(errCreatingObject(objectCluster, err))
 	}
-
-	admissionControllers, err := getSetAsStrings(d, admissionControllersKey)
-	if err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+	// OIDC authentication and audit logging are omitted unless they are set,
+	// so clusters are created the same way as before.
+	if !kubeOptions.OIDC.Enabled {
+		kubeOptions.OIDC = nil
+	}
+	if !kubeOptions.AuditLogs.Enabled {
+		kubeOptions.AuditLogs = nil
 	}
 
-	createOpts := &cluster.CreateOpts{
-		Name:                          d.Get("name").(string),
-		NetworkID:                     d.Get("network_id").(string),
-		SubnetID:                      d.Get("subnet_id").(string),
-		KubeVersion:                   d.Get("kube_version").(string),
-		MaintenanceWindowStart:        d.Get("maintenance_window_start").(string),
-		EnableAutorepair:              &enableAutorepair,
-		EnablePatchVersionAutoUpgrade: &enablePatchVersionAutoUpgrade,
-		Region:                        region,
-		KubernetesOptions: &cluster.KubernetesOptions{
-			EnablePodSecurityPolicy: enablePodSecurityPolicy,
-			FeatureGates:            featureGates,
-			AdmissionControllers:    admissionControllers,
+	createOpts := &mksClusterV1CreateOpts{
+		CreateOpts: cluster.CreateOpts{
+			Name:                          d.Get("name").(string),
+			NetworkID:                     d.Get("network_id").(string),
+			SubnetID:                      d.Get("subnet_id").(string),
+			KubeVersion:                   d.Get("kube_version").(string),
+			MaintenanceWindowStart:        d.Get("maintenance_window_start").(string),
+			EnableAutorepair:              &enableAutorepair,
+			EnablePatchVersionAutoUpgrade: &enablePatchVersionAutoUpgrade,
+			Region:                        region,
+			Zonal:                         &zonal,
+			PrivateKubeAPI:                &privateKubeAPI,
 		},
-		Zonal:          &zonal,
-		PrivateKubeAPI: &privateKubeAPI,
+		KubernetesOptions: kubeOptions,
 	}
 
-	projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
-	if err != nil {
-		return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
-	}
+	// The new cluster is counted by the used quotas once it's requested.
+	releaseReservation := sync.OnceFunc(func() {
+		config.mksQuotaReservations.releaseCluster(mksQuotaReservationKey(d), d.Get("name").(string))
+	})
+	defer releaseReservation()
+	if !config.SkipQuotaCheck {
+		projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
+		}
 
-	if err := checkQuotasForCluster(projectQuotas, zonal); err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+		if err := checkQuotasForCluster(projectQuotas, zonal); err != nil {
+			return diag.FromErr(errCreatingObject(objectCluster, err))
+		}
 	}
 
 	log.Print(msgCreate(objectCluster, createOpts))
-	newCluster, _, err := cluster.Create(ctx, mksClient, createOpts)
+	newCluster, _, err := createMKSClusterV1Request(ctx, mksClient, createOpts)
+	releaseReservation()
 	if err != nil {
 		return diag.FromErr(errCreatingObject(objectCluster, err))
 	}
@@ -244,7 +305,7 @@ func resourceMKSClusterV1Read(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	log.Print(msgGet(objectCluster, d.Id()))
-	mksCluster, response, err := cluster.Get(ctx, mksClient, d.Id())
+	mksCluster, response, err := getMKSClusterV1(ctx, mksClient, d.Id())
 	if err != nil {
 		if response != nil {
 			if response.StatusCode == http.StatusNotFound {
@@ -268,10 +329,28 @@ func resourceMKSClusterV1Read(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("maintenance_window_end", mksCluster.MaintenanceWindowEnd)
 	d.Set("enable_autorepair", mksCluster.EnableAutorepair)
 	d.Set("enable_patch_version_auto_upgrade", mksCluster.EnablePatchVersionAutoUpgrade)
-	d.Set("enable_pod_security_policy", mksCluster.KubernetesOptions.EnablePodSecurityPolicy)
 	d.Set("zonal", mksCluster.Zonal)
 	d.Set("private_kube_api", mksCluster.PrivateKubeAPI)
 
+	// The API omits Kubernetes options of clusters that have none of them.
+	kubeOptions := mksCluster.KubernetesOptions
+	if kubeOptions == nil {
+		kubeOptions = &mksClusterV1KubernetesOptions{}
+	}
+	d.Set("enable_pod_security_policy", kubeOptions.EnablePodSecurityPolicy)
+	if err := d.Set(featureGatesKey, kubeOptions.FeatureGates); err != nil {
+		return diag.FromErr(errGettingObject(objectCluster, d.Id(), err))
+	}
+	if err := d.Set(admissionControllersKey, kubeOptions.AdmissionControllers); err != nil {
+		return diag.FromErr(errGettingObject(objectCluster, d.Id(), err))
+	}
+	if err := d.Set("oidc", flattenMKSClusterV1OIDC(kubeOptions.OIDC)); err != nil {
+		return diag.FromErr(errGettingObject(objectCluster, d.Id(), err))
+	}
+	if err := d.Set("audit_logs", flattenMKSClusterV1AuditLogs(kubeOptions.AuditLogs)); err != nil {
+		return diag.FromErr(errGettingObject(objectCluster, d.Id(), err))
+	}
+
 	return nil
 }
 
@@ -281,49 +360,22 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 		return diagErr
 	}
 
+	var diags diag.Diagnostics
 	if d.HasChange("kube_version") {
 		if err := upgradeMKSClusterV1KubeVersion(ctx, d, mksClient); err != nil {
 			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
 		}
+		diags = mksClusterV1KubeOptionsDiagnostics(ctx, d, mksClient)
 	}
 
-	var updateOpts cluster.UpdateOpts
-	if d.HasChange("maintenance_window_start") {
-		updateOpts.MaintenanceWindowStart = d.Get("maintenance_window_start").(string)
-	}
-	if d.HasChange("enable_autorepair") {
-		v := d.Get("enable_autorepair").(bool)
-		updateOpts.EnableAutorepair = &v
-	}
-	if d.HasChange("enable_patch_version_auto_upgrade") {
-		v := d.Get("enable_patch_version_auto_upgrade").(bool)
-		updateOpts.EnablePatchVersionAutoUpgrade = &v
-	}
-
-	kubeOptions := new(cluster.KubernetesOptions)
-	if d.HasChange("enable_pod_security_policy") {
-		v := d.Get("enable_pod_security_policy").(bool)
-		kubeOptions.EnablePodSecurityPolicy = v
-	}
-	if d.HasChange(featureGatesKey) {
-		v, err := getSetAsStrings(d, featureGatesKey)
-		if err != nil {
-			return diag.FromErr(errCreatingObject(objectCluster, err))
-		}
-		kubeOptions.FeatureGates = v
-	}
-	if d.HasChange(admissionControllersKey) {
-		v, err := getSetAsStrings(d, admissionControllersKey)
-		if err != nil {
-			return diag.FromErr(errCreatingObject(objectCluster, err))
-		}
-		kubeOptions.AdmissionControllers = v
+	updateOpts, err := expandMKSClusterV1UpdateOpts(d)
+	if err != nil {
+		return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
 	}
-	updateOpts.KubernetesOptions = kubeOptions
 
-	if updateOpts != (cluster.UpdateOpts{}) {
+	if updateOpts != (mksClusterV1UpdateOpts{}) {
 		log.Print(msgUpdate(objectCluster, d.Id(), updateOpts))
-		_, _, err := cluster.Update(ctx, mksClient, d.Id(), &updateOpts)
+		_, err := updateMKSClusterV1Request(ctx, mksClient, d.Id(), &updateOpts)
 		if err != nil {
 			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
 		}
@@ -336,7 +388,7 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	return resourceMKSClusterV1Read(ctx, d, meta)
+	return append(diags, resourceMKSClusterV1Read(ctx, d, meta)...)
 }
 
 func resourceMKSClusterV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -345,6 +397,11 @@ func resourceMKSClusterV1Delete(ctx context.Context, d *schema.ResourceData, met
 		return diagErr
 	}
 
+	// Nodegroups are deleted without waiting for the cluster.
+	if err := waitForMKSClusterV1Ready(ctx, mksClient, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(errDeletingObject(objectCluster, d.Id(), err))
+	}
+
 	log.Print(msgDelete(objectCluster, d.Id()))
 	_, err := cluster.Delete(ctx, mksClient, d.Id())
 	if err != nil {
@@ -367,8 +424,8 @@ func resourceMKSClusterV1Delete(ctx context.Context, d *schema.ResourceData, met
 			return result, strconv.Itoa(response.StatusCode), err
 		},
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		Delay:      waitTime(10 * time.Second),
+		MinTimeout: waitTime(3 * time.Second),
 	}
 
 	log.Printf("[DEBUG] waiting for cluster %s to become deleted", d.Id())
@@ -381,16 +438,14 @@ func resourceMKSClusterV1Delete(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceMKSClusterV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "cluster_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/selectel/mks-go/pkg/v1/cluster"
 	"github.com/selectel/mks-go/pkg/v1/kubeoptions"
 	"github.com/selectel/mks-go/pkg/v1/kubeversion"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeselectel"
 )
 
 func TestAccMKSClusterV1Basic(t *testing.T) {
@@ -38,9 +41,9 @@ func TestAccMKSClusterV1Basic(t *testing.T) {
 	admissionControllersUpdate := defaultAdmissionControllers[1:2]
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSClusterV1BasicWithKubeOptions(projectName, clusterName, kubeVersion, maintenanceWindowStart, featureGates, admissionControllers),
@@ -89,9 +92,9 @@ func TestAccMKSClusterV1Zonal(t *testing.T) {
 	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSClusterV1Zonal(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -125,9 +128,9 @@ func TestAccMKSClusterV1PrivateKubeAPI(t *testing.T) {
 	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSClusterV1PrivateKubeAPI(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -149,6 +152,263 @@ func TestAccMKSClusterV1PrivateKubeAPI(t *testing.T) {
 	})
 }
 
+func TestUnitMKSClusterV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksCluster cluster.View
+	resourceName := "selectel_mks_cluster_v1.cluster_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	maintenanceWindowStartUpdated := testAccMKSClusterV1GetMaintenanceWindowStart(14 * time.Hour)
+	featureGates := fakeselectel.FeatureGates[:1]
+	featureGatesUpdate := fakeselectel.FeatureGates[1:2]
+	admissionControllers := fakeselectel.AdmissionControllers[:1]
+	admissionControllersUpdate := fakeselectel.AdmissionControllers[1:2]
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1BasicWithKubeOptions(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, featureGates, admissionControllers),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
+					resource.TestCheckResourceAttr(resourceName, "kube_version", fakeselectel.DefaultKubeVersion),
+					resource.TestCheckResourceAttr(resourceName, "enable_autorepair", "true"),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window_start", maintenanceWindowStart),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(resourceName, "feature_gates.0", featureGates[0]),
+					resource.TestCheckResourceAttrSet(resourceName, "kube_api_ip"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1UpdateWithKubeOptions(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStartUpdated, featureGatesUpdate, admissionControllersUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "enable_autorepair", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enable_patch_version_auto_upgrade", "false"),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window_start", maintenanceWindowStartUpdated),
+					resource.TestCheckResourceAttr(resourceName, "admission_controllers.0", admissionControllersUpdate[0]),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccMKSClusterV1BasicWithKubeOptions(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, featureGates, admissionControllers))
+}
+
+func TestUnitMKSClusterV1UpgradeKubeVersion(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksCluster cluster.View
+	resourceName := "selectel_mks_cluster_v1.cluster_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Basic(projectName, clusterName, "1.28.5", maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "kube_version", "1.28.5"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Basic(projectName, clusterName, "1.28.6", maintenanceWindowStart),
+				Check:  resource.TestCheckResourceAttr(resourceName, "kube_version", "1.28.6"),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Basic(projectName, clusterName, "1.29", maintenanceWindowStart),
+				Check:  resource.TestCheckResourceAttr(resourceName, "kube_version", "1.29.2"),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSClusterV1Basic(projectName, clusterName, "1.31", maintenanceWindowStart),
+				ExpectError: regexp.MustCompile("kubernetes versions must be upgraded one by one"),
+			},
+		},
+	})
+}
+
+func TestUnitMKSClusterV1SequentialMinorUpgrade(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksCluster cluster.View
+	resourceName := "selectel_mks_cluster_v1.cluster_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	featureGates := fakeselectel.FeatureGates[2:]
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1SequentialMinorUpgrade(projectName, clusterName, "1.27.10", maintenanceWindowStart, false, featureGates),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "kube_version", "1.27.10"),
+				),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSClusterV1SequentialMinorUpgrade(projectName, clusterName, "1.29", maintenanceWindowStart, false, featureGates),
+				ExpectError: regexp.MustCompile("kubernetes versions must be upgraded one by one"),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSClusterV1SequentialMinorUpgrade(projectName, clusterName, "1.29.1", maintenanceWindowStart, true, featureGates),
+				ExpectError: regexp.MustCompile("kubernetes version 1.29.1 is not available"),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSClusterV1SequentialMinorUpgrade(projectName, clusterName, "1.30", maintenanceWindowStart, true, featureGates),
+				ExpectError: regexp.MustCompile("kubernetes version 1.30 is not available"),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1SequentialMinorUpgrade(projectName, clusterName, "1.29", maintenanceWindowStart, true, featureGates),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "kube_version", "1.29.2"),
+					resource.TestCheckResourceAttr(resourceName, "sequential_minor_upgrade", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSClusterV1Drift(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksCluster cluster.View
+	resourceName := "selectel_mks_cluster_v1.cluster_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	maintenanceWindowStartUpdated := testAccMKSClusterV1GetMaintenanceWindowStart(14 * time.Hour)
+	featureGates := fakeselectel.FeatureGates[:1]
+	featureGatesUpdate := fakeselectel.FeatureGates[1:2]
+	admissionControllers := fakeselectel.AdmissionControllers[:1]
+	enableAutorepair := false
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Drift(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, featureGates, admissionControllers),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "enable_pod_security_policy", "true"),
+					testAccCheckMKSClusterV1UpdateOutOfBand(resourceName, &cluster.UpdateOpts{
+						MaintenanceWindowStart: maintenanceWindowStartUpdated,
+						EnableAutorepair:       &enableAutorepair,
+						KubernetesOptions: &cluster.KubernetesOptions{
+							FeatureGates:         featureGatesUpdate,
+							AdmissionControllers: []string{},
+						},
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Drift(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, featureGates, admissionControllers),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					testAccCheckMKSClusterV1KubeOptions(&mksCluster, true, featureGates, admissionControllers),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window_start", maintenanceWindowStart),
+					resource.TestCheckResourceAttr(resourceName, "enable_autorepair", "true"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Drift(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, featureGatesUpdate, admissionControllers),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					testAccCheckMKSClusterV1KubeOptions(&mksCluster, true, featureGatesUpdate, admissionControllers),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1Drift(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStartUpdated, featureGatesUpdate, admissionControllers),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					testAccCheckMKSClusterV1KubeOptions(&mksCluster, true, featureGatesUpdate, admissionControllers),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window_start", maintenanceWindowStartUpdated),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSClusterV1OIDCAndAuditLogs(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksCluster cluster.View
+	resourceName := "selectel_mks_cluster_v1.cluster_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	oidc := `
+  oidc {
+    provider_name = "keycloak"
+    issuer_url    = "https://sso.example.com/realms/k8s"
+    client_id     = "%s"
+  }`
+	auditLogs := `
+  audit_logs {
+    secret_name = "audit-logs-credentials"
+  }`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSClusterV1WithBlocks(projectName, clusterName, maintenanceWindowStart, strings.Replace(oidc, "https://", "http://", 1)),
+				ExpectError: regexp.MustCompile(`expected "oidc.0.issuer_url" to have a url with schema of: "https"`),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1WithBlocks(projectName, clusterName, maintenanceWindowStart, fmt.Sprintf(oidc, "kubernetes"), auditLogs),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "oidc.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "oidc.0.provider_name", "keycloak"),
+					resource.TestCheckResourceAttr(resourceName, "oidc.0.issuer_url", "https://sso.example.com/realms/k8s"),
+					resource.TestCheckResourceAttr(resourceName, "oidc.0.client_id", "kubernetes"),
+					resource.TestCheckResourceAttr(resourceName, "oidc.0.username_claim", "sub"),
+					resource.TestCheckResourceAttr(resourceName, "oidc.0.groups_claim", "groups"),
+					resource.TestCheckResourceAttr(resourceName, "audit_logs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "audit_logs.0.secret_name", "audit-logs-credentials"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1WithBlocks(projectName, clusterName, maintenanceWindowStart, fmt.Sprintf(oidc, "kubernetes-sso")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "oidc.0.client_id", "kubernetes-sso"),
+					resource.TestCheckResourceAttr(resourceName, "audit_logs.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccSelectelImportStateIDFunc(resourceName, "project_id", "region"),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSClusterV1WithBlocks(projectName, clusterName, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists(resourceName, &mksCluster),
+					resource.TestCheckResourceAttr(resourceName, "oidc.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccMKSClusterV1GetMaintenanceWindowStart(delay time.Duration) string {
 	return time.Now().UTC().Add(delay).Format("15:04:00")
 }
@@ -161,9 +421,9 @@ func testAccMKSClusterV1GetDefaultKubeVersion(t *testing.T) string {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -238,6 +498,44 @@ func testAccCheckMKSClusterV1Exists(n string, mksCluster *cluster.View) resource
 	}
 }
 
+func testAccCheckMKSClusterV1UpdateOutOfBand(n string, updateOpts *cluster.UpdateOpts) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		mksClient, err := newTestMKSClient(rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = cluster.Update(context.Background(), mksClient, rs.Primary.ID, updateOpts)
+
+		return err
+	}
+}
+
+func testAccCheckMKSClusterV1KubeOptions(mksCluster *cluster.View, enablePodSecurityPolicy bool, featureGates, admissionControllers []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		kubeOptions := mksCluster.KubernetesOptions
+		if kubeOptions == nil {
+			return errors.New("cluster has no kubernetes options")
+		}
+		if kubeOptions.EnablePodSecurityPolicy != enablePodSecurityPolicy {
+			return fmt.Errorf("expected enable_pod_security_policy %t, got %t", enablePodSecurityPolicy, kubeOptions.EnablePodSecurityPolicy)
+		}
+		if !reflect.DeepEqual(kubeOptions.FeatureGates, featureGates) {
+			return fmt.Errorf("expected feature gates %v, got %v", featureGates, kubeOptions.FeatureGates)
+		}
+		if !reflect.DeepEqual(kubeOptions.AdmissionControllers, admissionControllers) {
+			return fmt.Errorf("expected admission controllers %v, got %v", admissionControllers, kubeOptions.AdmissionControllers)
+		}
+
+		return nil
+	}
+}
+
 func testAccMKSClusterV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -293,6 +591,56 @@ resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
 }`, projectName, clusterName, kubeVersion, maintenanceWindowStart, flatFeatureGates, flatAdmissionControllers)
 }
 
+func testAccMKSClusterV1SequentialMinorUpgrade(projectName, clusterName, kubeVersion, maintenanceWindowStart string, sequential bool, featureGates []string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                              = "%s"
+  kube_version                      = "%s"
+  project_id                        = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                            = "ru-9"
+  maintenance_window_start          = "%s"
+  enable_patch_version_auto_upgrade = false
+  sequential_minor_upgrade          = %t
+  feature_gates                     = [%s]
+}`, projectName, clusterName, kubeVersion, maintenanceWindowStart, sequential, flatStringsListWithQuotes(featureGates))
+}
+
+func testAccMKSClusterV1Drift(projectName, clusterName, kubeVersion, maintenanceWindowStart string, featureGates, admissionControllers []string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                              = "%s"
+  kube_version                      = "%s"
+  project_id                        = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                            = "ru-9"
+  maintenance_window_start          = "%s"
+  enable_patch_version_auto_upgrade = false
+  enable_pod_security_policy        = true
+  feature_gates                     = [%s]
+  admission_controllers             = [%s]
+}`, projectName, clusterName, kubeVersion, maintenanceWindowStart, flatStringsListWithQuotes(featureGates), flatStringsListWithQuotes(admissionControllers))
+}
+
+func testAccMKSClusterV1WithBlocks(projectName, clusterName, maintenanceWindowStart string, blocks ...string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                     = "%s"
+  kube_version             = "%s"
+  project_id               = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                   = "ru-9"
+  maintenance_window_start = "%s"
+%s
+}`, projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, strings.Join(blocks, "\n"))
+}
+
 func testAccMKSClusterV1Zonal(projectName, clusterName, kubeVersion, maintenanceWindowStart string) string {
 	return fmt.Sprintf(`
  resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -332,9 +680,9 @@ func testDefaultFeatureGates(t *testing.T) []string {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -395,9 +743,9 @@ func testDefaultAdmissionControllers(t *testing.T) []string {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -464,7 +812,12 @@ func newTestMKSClientWithParams(projectID, region string) (*v1.ServiceClient, er
 		return nil, fmt.Errorf("can't get endpoint for mks acc tests: %w", err)
 	}
 
-	mksClient := v1.NewMKSClientV1(selvpcClient.GetXAuthToken(), endpoint.URL)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for mks acc tests: %w", err)
+	}
+
+	mksClient := v1.NewMKSClientV1(token, endpoint.URL)
 
 	return mksClient, nil
 }
@@ -2,13 +2,14 @@ package selectel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/quotamanager/quotas"
@@ -24,6 +25,10 @@ func resourceMKSNodegroupV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMKSNodegroupV1ImportState,
 		},
+		CustomizeDiff: customdiff.All(
+			mksNodegroupV1UpdateStrategyDiff,
+			mksNodegroupV1QuotaDiff,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -72,24 +77,22 @@ func resourceMKSNodegroupV1() *schema.Resource {
 				Type:          schema.TypeInt,
 				ConflictsWith: []string{"flavor_id"},
 				Optional:      true,
-				ForceNew:      true,
+				Computed:      true,
 			},
 			"ram_mb": {
 				Type:          schema.TypeInt,
 				ConflictsWith: []string{"flavor_id"},
 				Optional:      true,
-				ForceNew:      true,
+				Computed:      true,
 			},
 			"volume_gb": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
 			},
 			"volume_type": {
 				Type:          schema.TypeString,
 				ConflictsWith: []string{"local_volume"},
 				Optional:      true,
-				ForceNew:      true,
 			},
 			"local_volume": {
 				Type:     schema.TypeBool,
@@ -101,7 +104,6 @@ func resourceMKSNodegroupV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"labels": {
 				Type:     schema.TypeMap,
@@ -156,12 +158,68 @@ func resourceMKSNodegroupV1() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(0, 65535),
+				ValidateFunc: validateMKSNodegroupV1UserData,
+				StateFunc: func(v interface{}) string {
+					return normalizeMKSNodegroupV1UserData(v.(string))
+				},
+			},
+			"preemptible": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"update_strategy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								mksNodegroupV1UpdateStrategyRecreate,
+								mksNodegroupV1UpdateStrategyRolling,
+							}, false),
+						},
+						"max_surge": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_unavailable": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"wait_for_drain": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"cluster_lock_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateMKSNodegroupV1LockTimeout,
 			},
 			"nodegroup_type": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"rolling_nodegroup_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"nodes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -188,7 +246,10 @@ func resourceMKSNodegroupV1() *schema.Resource {
 
 func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	clusterID := d.Get("cluster_id").(string)
-	selMutexKV.Lock(clusterID)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if err := lockMKSClusterV1ForNodegroup(ctx, d, clusterID, "create", timeout); err != nil {
+		return diag.FromErr(errCreatingObject(objectNodegroup, err))
+	}
 	defer selMutexKV.Unlock(clusterID)
 
 	mksClient, diagErr := getMKSClient(d, meta)
@@ -196,6 +257,10 @@ func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, m
 		return diagErr
 	}
 
+	if err := waitForMKSClusterV1Ready(ctx, mksClient, clusterID, timeout); err != nil {
+		return diag.FromErr(errCreatingObject(objectNodegroup, err))
+	}
+
 	config := meta.(*Config)
 	projectID := d.Get("project_id").(string)
 	region := d.Get("region").(string)
@@ -210,93 +275,32 @@ func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf("can't validate region: %w", err))
 	}
 
-	// Get a list of all nodegroups in the cluster.
-	allNodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
-	if err != nil {
-		return diag.FromErr(errGettingObject("all nodegroups in the cluster", clusterID, err))
-	}
+	createOpts := expandMKSNodegroupV1CreateOpts(d)
 
-	// Prepare a map with known nodegroup IDs.
-	nodegroupIDs := make(map[string]struct{})
-	for _, ng := range allNodegroups {
-		if _, ok := nodegroupIDs[ng.ID]; !ok {
-			nodegroupIDs[ng.ID] = struct{}{}
+	// The new nodes are counted by the used quotas once the nodegroup is requested.
+	releaseReservation := sync.OnceFunc(func() {
+		config.mksQuotaReservations.releaseNodegroup(mksQuotaReservationKey(d), expandMKSNodegroupV1QuotaReservation(d))
+	})
+	defer releaseReservation()
+	if !config.SkipQuotaCheck {
+		projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
 		}
-	}
-
-	// Prepare nodegroup create options.
-	createOpts := &nodegroup.CreateOpts{
-		Count:            d.Get("nodes_count").(int),
-		FlavorID:         d.Get("flavor_id").(string),
-		CPUs:             d.Get("cpus").(int),
-		RAMMB:            d.Get("ram_mb").(int),
-		VolumeGB:         d.Get("volume_gb").(int),
-		VolumeType:       d.Get("volume_type").(string),
-		LocalVolume:      d.Get("local_volume").(bool),
-		KeypairName:      d.Get("keypair_name").(string),
-		AffinityPolicy:   d.Get("affinity_policy").(string),
-		AvailabilityZone: d.Get("availability_zone").(string),
-		UserData:         d.Get("user_data").(string),
-	}
-
-	projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
-	if err != nil {
-		return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
-	}
-
-	if err := checkQuotasForNodegroup(projectQuotas, createOpts); err != nil {
-		return diag.FromErr(errCreatingObject(objectNodegroup, err))
-	}
-
-	// Check nodegroup autoscaling options.
-	if v, ok := d.GetOk("enable_autoscale"); ok {
-		enableAutoscale := v.(bool)
-		createOpts.EnableAutoscale = &enableAutoscale
-	}
-	if v, ok := d.GetOk("autoscale_min_nodes"); ok {
-		autoscaleMinNodes := v.(int)
-		createOpts.AutoscaleMinNodes = &autoscaleMinNodes
-	}
-	if v, ok := d.GetOk("autoscale_max_nodes"); ok {
-		autoscaleMaxNodes := v.(int)
-		createOpts.AutoscaleMaxNodes = &autoscaleMaxNodes
-	}
-
-	labels := d.Get("labels").(map[string]interface{})
-	createOpts.Labels = expandMKSNodegroupV1Labels(labels)
-
-	taints := d.Get("taints").([]interface{})
-	createOpts.Taints = expandMKSNodegroupV1Taints(taints)
 
-	log.Print(msgCreate(objectNodegroup, createOpts))
-	_, err = nodegroup.Create(ctx, mksClient, clusterID, createOpts)
-	if err != nil {
-		return diag.FromErr(errCreatingObject(objectNodegroup, err))
-	}
-
-	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
-	timeout := d.Timeout(schema.TimeoutCreate)
-	err = waitForMKSClusterV1ActiveState(ctx, mksClient, clusterID, timeout)
-	if err != nil {
-		return diag.FromErr(errCreatingObject(objectNodegroup, err))
+		if err := checkQuotasForNodegroup(projectQuotas, &createOpts.CreateOpts); err != nil {
+			return diag.FromErr(errCreatingObject(objectNodegroup, err))
+		}
 	}
 
-	// Get a list of all nodegroups in the cluster and find a new nodegroup.
-	allNodegroups, _, err = nodegroup.List(ctx, mksClient, clusterID)
+	nodegroupID, err := createMKSNodegroupV1(ctx, mksClient, clusterID, createOpts, timeout, releaseReservation)
 	if err != nil {
-		return diag.FromErr(errGettingObject("all nodegroups in the cluster", clusterID, err))
-	}
-
-	var nodegroupID string
-	for _, ng := range allNodegroups {
-		if _, ok := nodegroupIDs[ng.ID]; !ok {
-			nodegroupID = ng.ID
+		// The created nodegroup is kept in the state to be replaced.
+		if nodegroupID != "" {
+			d.SetId(fmt.Sprintf("%s/%s", clusterID, nodegroupID))
 		}
-	}
-	if nodegroupID == "" {
-		return diag.FromErr(errCreatingObject(objectNodegroup,
-			errors.New("unable to find new nodegroup by ID after creating"),
-		))
+
+		return diag.FromErr(err)
 	}
 
 	// The ID must be a combination of the cluster and nodegroup ID
@@ -320,7 +324,7 @@ func resourceMKSNodegroupV1Read(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	log.Print(msgGet(objectNodegroup, d.Id()))
-	mksNodegroup, response, err := nodegroup.Get(ctx, mksClient, clusterID, nodegroupID)
+	mksNodegroup, response, err := getMKSNodegroupV1(ctx, mksClient, clusterID, nodegroupID)
 	if err != nil {
 		if response != nil {
 			if response.StatusCode == http.StatusNotFound {
@@ -344,6 +348,23 @@ func resourceMKSNodegroupV1Read(ctx context.Context, d *schema.ResourceData, met
 	d.Set("autoscale_max_nodes", mksNodegroup.AutoscaleMaxNodes)
 	d.Set("nodegroup_type", mksNodegroup.NodegroupType)
 	d.Set("user_data", mksNodegroup.UserData)
+	d.Set("preemptible", mksNodegroup.Preemptible)
+	d.Set("image_id", mksNodegroup.ImageID)
+
+	if rollingNodegroupID := d.Get("rolling_nodegroup_id").(string); rollingNodegroupID != "" {
+		log.Print(msgGet(objectNodegroup, rollingNodegroupID))
+		_, response, err := nodegroup.Get(ctx, mksClient, clusterID, rollingNodegroupID)
+		if err != nil {
+			if response == nil || response.StatusCode != http.StatusNotFound {
+				return diag.FromErr(errGettingObject(objectNodegroup, rollingNodegroupID, err))
+			}
+			d.Set("rolling_nodegroup_id", "")
+		}
+	}
+
+	// Nodegroup API doesn't return CPUs and RAM of the nodes, so they are
+	// read from the flavor to be set on import.
+	setMKSNodegroupV1FlavorResources(d, meta, mksNodegroup.FlavorID)
 
 	if err := d.Set("labels", mksNodegroup.Labels); err != nil {
 		log.Print(errSettingComplexAttr("labels", err))
@@ -369,7 +390,9 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
 	}
 
-	selMutexKV.Lock(clusterID)
+	if err := lockMKSClusterV1ForNodegroup(ctx, d, clusterID, "update", d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+	}
 	defer selMutexKV.Unlock(clusterID)
 
 	mksClient, diagErr := getMKSClient(d, meta)
@@ -377,9 +400,18 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 		return diagErr
 	}
 
+	if err := waitForMKSClusterV1Ready(ctx, mksClient, clusterID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+	}
+
 	config := meta.(*Config)
 	projectID := d.Get("project_id").(string)
 	region := d.Get("region").(string)
+	// The new nodes are counted by the used quotas once the resize is requested.
+	releaseReservation := sync.OnceFunc(func() {
+		config.mksQuotaReservations.releaseNodegroup(mksQuotaReservationKey(d), expandMKSNodegroupV1QuotaReservation(d))
+	})
+	defer releaseReservation()
 
 	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
@@ -391,6 +423,46 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf("can't validate region: %w", err))
 	}
 
+	// The rolling update replaces all nodes with new ones, so other changes
+	// are applied to the new nodes at once.
+	strategy := expandMKSNodegroupV1UpdateStrategy(d.Get("update_strategy").([]interface{}))
+	if strategy.Type == mksNodegroupV1UpdateStrategyRolling && d.HasChanges(mksNodegroupV1RollingUpdateKeys...) {
+		// The rolling update checks quotas for every step of its own.
+		releaseReservation()
+		newNodegroupID, oldNodegroupDeleted, err := rollMKSNodegroupV1(ctx, d, mksClient, selvpcClient, config.SkipQuotaCheck)
+		// The resource tracks the old nodegroup until it's deleted, and the new
+		// one in rolling_nodegroup_id, so no nodegroup is left out of the state.
+		if oldNodegroupDeleted {
+			d.SetId(fmt.Sprintf("%s/%s", clusterID, newNodegroupID))
+			d.Set("rolling_nodegroup_id", "")
+		} else {
+			d.Set("rolling_nodegroup_id", newNodegroupID)
+		}
+		if err != nil {
+			if !oldNodegroupDeleted {
+				// The old arguments are kept, so the next apply resumes the update.
+				for _, key := range mksNodegroupV1RollingUpdateKeys {
+					oldValue, _ := d.GetChange(key)
+					d.Set(key, oldValue)
+				}
+			}
+
+			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), fmt.Errorf(
+				"rolling update from nodegroup %s stopped: %w", nodegroupID, err)))
+		}
+
+		return resourceMKSNodegroupV1Read(ctx, d, meta)
+	}
+
+	if rollingNodegroupID, _ := d.GetChange("rolling_nodegroup_id"); rollingNodegroupID.(string) != "" {
+		// The rolling update isn't needed anymore, so its new nodegroup is deleted.
+		err := deleteMKSRollingNodegroupV1(ctx, mksClient, clusterID, rollingNodegroupID.(string), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+		}
+		d.Set("rolling_nodegroup_id", "")
+	}
+
 	var (
 		updateOpts nodegroup.UpdateOpts
 		hasChanged bool
@@ -447,13 +519,15 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 			AvailabilityZone: d.Get("availability_zone").(string),
 		}
 
-		projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
-		if err != nil {
-			return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
-		}
+		if !config.SkipQuotaCheck {
+			projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+			if err != nil {
+				return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
+			}
 
-		if err := checkQuotasForNodegroup(projectQuotas, &newNodesRequest); err != nil {
-			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			if err := checkQuotasForNodegroup(projectQuotas, &newNodesRequest); err != nil {
+				return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			}
 		}
 
 		resizeOpts := nodegroup.ResizeOpts{
@@ -462,6 +536,7 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 
 		log.Print(msgUpdate(objectNodegroup, d.Id(), resizeOpts))
 		_, err = nodegroup.Resize(ctx, mksClient, clusterID, nodegroupID, &resizeOpts)
+		releaseReservation()
 		if err != nil {
 			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
 		}
@@ -484,7 +559,10 @@ func resourceMKSNodegroupV1Delete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
 	}
 
-	selMutexKV.Lock(clusterID)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	if err := lockMKSClusterV1ForNodegroup(ctx, d, clusterID, "delete", timeout); err != nil {
+		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+	}
 	defer selMutexKV.Unlock(clusterID)
 
 	mksClient, diagErr := getMKSClient(d, meta)
@@ -492,15 +570,34 @@ func resourceMKSNodegroupV1Delete(ctx context.Context, d *schema.ResourceData, m
 		return diagErr
 	}
 
+	if err := waitForMKSClusterV1Ready(ctx, mksClient, clusterID, timeout); err != nil {
+		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+	}
+
+	if d.Get("wait_for_drain").(bool) {
+		log.Printf("[DEBUG] draining nodes of nodegroup %s", d.Id())
+		if err := drainMKSNodegroupV1(ctx, mksClient, clusterID, nodegroupID, timeout); err != nil {
+			return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+		}
+	}
+
+	if rollingNodegroupID := d.Get("rolling_nodegroup_id").(string); rollingNodegroupID != "" {
+		err := deleteMKSRollingNodegroupV1(ctx, mksClient, clusterID, rollingNodegroupID, timeout)
+		if err != nil {
+			return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+		}
+	}
+
 	log.Print(msgDelete(objectNodegroup, d.Id()))
 	_, err = nodegroup.Delete(ctx, mksClient, clusterID, nodegroupID)
 	if err != nil {
 		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
 	}
 
-	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
-	timeout := d.Timeout(schema.TimeoutDelete)
-	err = waitForMKSClusterV1ActiveState(ctx, mksClient, clusterID, timeout)
+	// The cluster isn't waited for since parallel operations of other
+	// nodegroups wait for it to become 'ACTIVE' before their changes.
+	log.Printf("[DEBUG] waiting for nodegroup %s to become deleted", d.Id())
+	err = waitForMKSNodegroupV1Deleted(ctx, mksClient, clusterID, nodegroupID, timeout)
 	if err != nil {
 		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
 	}
@@ -509,16 +606,14 @@ func resourceMKSNodegroupV1Delete(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceMKSNodegroupV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-	if config.Region == "" {
-		return nil, errors.New("SEL_REGION must be set for the resource import")
+	projectID, region, id, err := parseImportIDWithProjectRegion(meta.(*Config), d.Id(), "cluster_id", "nodegroup_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
-	d.Set("region", config.Region)
+	d.SetId(id)
+	d.Set("project_id", projectID)
+	d.Set("region", region)
 
 	return []*schema.ResourceData{d}, nil
 }
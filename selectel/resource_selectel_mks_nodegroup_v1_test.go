@@ -2,8 +2,12 @@ package selectel
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/projects"
 	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeselectel"
 )
 
 func TestAccMKSNodegroupV1Basic(t *testing.T) {
@@ -26,9 +31,9 @@ func TestAccMKSNodegroupV1Basic(t *testing.T) {
 	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSNodegroupV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -95,6 +100,437 @@ func TestAccMKSNodegroupV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitMKSNodegroupV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksNodegroup nodegroup.GetView
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1Basic(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &mksNodegroup),
+					resource.TestCheckResourceAttr(resourceName, "availability_zone", "ru-9a"),
+					resource.TestCheckResourceAttr(resourceName, "nodes_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "enable_autoscale", "true"),
+					resource.TestCheckResourceAttr(resourceName, "labels.label-key0", "label-value0"),
+					resource.TestCheckResourceAttr(resourceName, "taints.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "taints.2.effect", "PreferNoSchedule"),
+					resource.TestCheckResourceAttr(resourceName, "nodegroup_type", "STANDARD"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1Update(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &mksNodegroup),
+					resource.TestCheckResourceAttr(resourceName, "nodes_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "enable_autoscale", "false"),
+					resource.TestCheckResourceAttr(resourceName, "autoscale_max_nodes", "4"),
+					resource.TestCheckResourceAttr(resourceName, "labels.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "taints.2.key", "test-key-3"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig:         testAccUnsetSelectelImportEnv,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccSelectelImportStateIDFunc(resourceName, "project_id", "region"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccMKSNodegroupV1Basic(projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart))
+}
+
+func TestUnitMKSNodegroupV1RollingUpdate(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var oldNodegroup, newNodegroup nodegroup.GetView
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 1, 1024, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &oldNodegroup),
+					resource.TestCheckResourceAttr(resourceName, "cpus", "1"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "3"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 2, 2048, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &newNodegroup),
+					testAccCheckMKSNodegroupV1Rolled(resourceName, &oldNodegroup, &newNodegroup),
+					resource.TestCheckResourceAttr(resourceName, "cpus", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ram_mb", "2048"),
+					resource.TestCheckResourceAttr(resourceName, "volume_gb", "20"),
+					resource.TestCheckResourceAttr(resourceName, "nodes_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "labels.label-key0", "label-value0"),
+					resource.TestCheckResourceAttr(resourceName, "taints.0.key", "test-key-0"),
+					resource.TestCheckResourceAttr(resourceName, "update_strategy.0.type", "rolling"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1RollingUpdateFailure(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var oldNodegroup, failedNodegroup, newNodegroup nodegroup.GetView
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 1, 1024, 10),
+				Check:  testAccCheckMKSNodegroupV1Exists(resourceName, &oldNodegroup),
+			},
+			{
+				// The new nodegroup is created with one node and fails to grow.
+				PreConfig:   func() { backend.FailRequests(http.MethodPost, "/resize") },
+				Config:      testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 2, 2048, 20),
+				ExpectError: regexp.MustCompile("rolling update from nodegroup .* stopped"),
+			},
+			{
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &failedNodegroup),
+					testAccCheckMKSNodegroupV1Same(&oldNodegroup, &failedNodegroup),
+					resource.TestCheckResourceAttrSet(resourceName, "rolling_nodegroup_id"),
+					testAccCheckMKSNodegroupV1Tracked(resourceName),
+				),
+			},
+			{
+				PreConfig: backend.ClearFailures,
+				Config:    testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 2, 2048, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &newNodegroup),
+					testAccCheckMKSNodegroupV1Rolled(resourceName, &oldNodegroup, &newNodegroup),
+					testAccCheckMKSNodegroupV1Tracked(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rolling_nodegroup_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "cpus", "2"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1RollingUpdateAbandoned(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var oldNodegroup, currentNodegroup nodegroup.GetView
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 1, 1024, 10),
+				Check:  testAccCheckMKSNodegroupV1Exists(resourceName, &oldNodegroup),
+			},
+			{
+				PreConfig:   func() { backend.FailRequests(http.MethodPost, "/resize") },
+				Config:      testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 2, 2048, 20),
+				ExpectError: regexp.MustCompile("rolling update from nodegroup .* stopped"),
+			},
+			{
+				// The old arguments delete the new nodegroup and restore the
+				// number of nodes of the old one.
+				PreConfig: backend.ClearFailures,
+				Config:    testUnitProviderConfig(backend) + testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 1, 1024, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &currentNodegroup),
+					testAccCheckMKSNodegroupV1Same(&oldNodegroup, &currentNodegroup),
+					testAccCheckMKSNodegroupV1Tracked(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rolling_nodegroup_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "cpus", "1"),
+					resource.TestCheckResourceAttr(resourceName, "nodes.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1RollingUpdateInvalid(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	config := strings.Replace(testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart, 1, 1024, 10),
+		"max_surge       = 1", "max_surge       = 0", 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + config,
+				ExpectError: regexp.MustCompile("max_surge and max_unavailable of the rolling update strategy can't be both 0"),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1WaitForDrain(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var drainedNodegroup, mksNodegroup nodegroup.GetView
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1WaitForDrain(projectName, clusterName, maintenanceWindowStart, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", &drainedNodegroup),
+					testAccCheckMKSNodegroupV1Exists("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_2", &mksNodegroup),
+					resource.TestCheckResourceAttr("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", "wait_for_drain", "true"),
+					resource.TestCheckResourceAttr("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", "cluster_lock_timeout", "5m"),
+					resource.TestCheckResourceAttr("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", "nodes.#", "3"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1WaitForDrain(projectName, clusterName, maintenanceWindowStart, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_2", &mksNodegroup),
+					testAccCheckMKSNodegroupV1Deleted("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_2", &drainedNodegroup),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1InvalidLockTimeout(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	config := strings.Replace(testAccMKSNodegroupV1WaitForDrain(projectName, clusterName, maintenanceWindowStart, true),
+		`cluster_lock_timeout = "5m"`, `cluster_lock_timeout = "5"`, 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + config,
+				ExpectError: regexp.MustCompile(`"cluster_lock_timeout" must be a duration`),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1InstallOptions(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var mksNodegroup nodegroup.GetView
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	userData := "#cloud-config\npackages:\n  - mtr\n"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSNodegroupV1InstallOptions(projectName, clusterName, maintenanceWindowStart, "#cloud-config\npackages: [mtr"),
+				ExpectError: regexp.MustCompile(`"user_data" is not a valid cloud-config YAML`),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSNodegroupV1InstallOptions(projectName, clusterName, maintenanceWindowStart, "apt -y install mtr"),
+				ExpectError: regexp.MustCompile(`"user_data" must be a cloud-config`),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1InstallOptions(projectName, clusterName, maintenanceWindowStart, userData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSNodegroupV1Exists(resourceName, &mksNodegroup),
+					resource.TestCheckResourceAttr(resourceName, "preemptible", "true"),
+					resource.TestCheckResourceAttr(resourceName, "image_id", "2a8d2a0c-0b4b-4c3c-9e0c-0f4f5b1d8c3e"),
+					resource.TestCheckResourceAttr(resourceName, "user_data", base64.StdEncoding.EncodeToString([]byte(userData))),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccSelectelImportStateIDFunc(resourceName, "project_id", "region"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1QuotaPlanCheck(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	skipQuotaCheckConfig := strings.Replace(testUnitProviderConfig(backend), "  endpoints {", "  skip_quota_check = true\n  endpoints {", 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheck(projectName, clusterName, maintenanceWindowStart, 0),
+			},
+			{
+				Config:      testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheck(projectName, clusterName, maintenanceWindowStart, 2),
+				ExpectError: regexp.MustCompile(`not enough quota to create nodes of 2 nodegroups pending in the plan(.|\n)*ru-9a: CPU 3 remaining, 4 required`),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheck(projectName, clusterName, maintenanceWindowStart, 1),
+				Check:  resource.TestCheckResourceAttr("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_0", "nodes_count", "1"),
+			},
+			{
+				Config: skipQuotaCheckConfig + testAccMKSNodegroupV1QuotaPlanCheck(projectName, clusterName, maintenanceWindowStart, 2),
+				Check:  resource.TestCheckResourceAttr("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1", "nodes_count", "1"),
+			},
+		},
+	})
+}
+
+// testAccCheckMKSNodegroupV1Deleted checks that the nodegroup doesn't exist
+// in the cluster of the n nodegroup.
+func testAccCheckMKSNodegroupV1Deleted(n string, deletedNodegroup *nodegroup.GetView) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		mksClient, err := newTestMKSClient(rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		allNodegroups, _, err := nodegroup.List(context.Background(), mksClient, deletedNodegroup.ClusterID)
+		if err != nil {
+			return err
+		}
+		for _, ng := range allNodegroups {
+			if ng.ID == deletedNodegroup.ID {
+				return fmt.Errorf("nodegroup %s still exists", ng.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckMKSNodegroupV1Rolled checks that the rolling update has created
+// a new nodegroup with new nodes and deleted the old one.
+func testAccCheckMKSNodegroupV1Rolled(n string, oldNodegroup, newNodegroup *nodegroup.GetView) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if newNodegroup.ID == oldNodegroup.ID {
+			return errors.New("nodegroup wasn't replaced")
+		}
+
+		oldNodeIDs := make(map[string]struct{}, len(oldNodegroup.Nodes))
+		for _, oldNode := range oldNodegroup.Nodes {
+			oldNodeIDs[oldNode.ID] = struct{}{}
+		}
+		for _, newNode := range newNodegroup.Nodes {
+			if _, ok := oldNodeIDs[newNode.ID]; ok {
+				return fmt.Errorf("node %s wasn't replaced", newNode.ID)
+			}
+		}
+
+		rs := s.RootModule().Resources[n]
+		mksClient, err := newTestMKSClient(rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		allNodegroups, _, err := nodegroup.List(context.Background(), mksClient, newNodegroup.ClusterID)
+		if err != nil {
+			return err
+		}
+		for _, ng := range allNodegroups {
+			if ng.ID == oldNodegroup.ID {
+				return fmt.Errorf("old nodegroup %s still exists", ng.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMKSNodegroupV1Same(expected, actual *nodegroup.GetView) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if actual.ID != expected.ID {
+			return fmt.Errorf("expected nodegroup %s, but got %s", expected.ID, actual.ID)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckMKSNodegroupV1Tracked checks that every nodegroup of the
+// cluster is either the nodegroup of the resource or its rolling_nodegroup_id.
+func testAccCheckMKSNodegroupV1Tracked(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		clusterID, nodegroupID, err := mksNodegroupV1ParseID(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error parsing resource id: %s", err)
+		}
+
+		mksClient, err := newTestMKSClient(rs, testAccProvider)
+		if err != nil {
+			return err
+		}
+
+		allNodegroups, _, err := nodegroup.List(context.Background(), mksClient, clusterID)
+		if err != nil {
+			return err
+		}
+		for _, ng := range allNodegroups {
+			if ng.ID != nodegroupID && ng.ID != rs.Primary.Attributes["rolling_nodegroup_id"] {
+				return fmt.Errorf("nodegroup %s isn't tracked by %s", ng.ID, n)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckMKSNodegroupV1Exists(n string, mksNodegroup *nodegroup.GetView) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -232,3 +668,249 @@ resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_1" {
   }
 }`, projectName, clusterName, kubeVersion, maintenanceWindowStart)
 }
+
+func testAccMKSNodegroupV1RollingUpdate(projectName, clusterName, maintenanceWindowStart string, cpus, ramMB, volumeGB int) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                     = "%s"
+  kube_version             = "%s"
+  project_id               = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                   = "ru-9"
+  maintenance_window_start = "%s"
+}
+
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_1" {
+  cluster_id          = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id          = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region              = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone   = "ru-9a"
+  nodes_count         = 3
+  cpus                = %d
+  ram_mb              = %d
+  volume_gb           = %d
+  volume_type         = "fast.ru-9a"
+  labels = {
+    label-key0 = "label-value0"
+  }
+  taints {
+    key = "test-key-0"
+    value = "test-value-0"
+    effect = "NoSchedule"
+  }
+  update_strategy {
+    type            = "rolling"
+    max_surge       = 1
+    max_unavailable = 0
+  }
+}`, projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, cpus, ramMB, volumeGB)
+}
+
+func testAccMKSNodegroupV1WaitForDrain(projectName, clusterName, maintenanceWindowStart string, withDrainedNodegroup bool) string {
+	drainedNodegroup := ""
+	if withDrainedNodegroup {
+		drainedNodegroup = `
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_1" {
+  cluster_id           = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id           = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region               = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone    = "ru-9a"
+  nodes_count          = 3
+  cpus                 = 1
+  ram_mb               = 1024
+  volume_gb            = 10
+  volume_type          = "fast.ru-9a"
+  wait_for_drain       = true
+  cluster_lock_timeout = "5m"
+}`
+	}
+
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                     = "%s"
+  kube_version             = "%s"
+  project_id               = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                   = "ru-9"
+  maintenance_window_start = "%s"
+}
+
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_2" {
+  cluster_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region            = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone = "ru-9a"
+  nodes_count       = 1
+  cpus              = 1
+  ram_mb            = 1024
+  volume_gb         = 10
+  volume_type       = "fast.ru-9a"
+}
+%s`, projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, drainedNodegroup)
+}
+
+func testAccMKSNodegroupV1InstallOptions(projectName, clusterName, maintenanceWindowStart, userData string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name        = "%s"
+}
+
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                     = "%s"
+  kube_version             = "%s"
+  project_id               = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                   = "ru-9"
+  maintenance_window_start = "%s"
+}
+
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_1" {
+  cluster_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region            = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone = "ru-9a"
+  nodes_count       = 1
+  cpus              = 1
+  ram_mb            = 1024
+  volume_gb         = 10
+  volume_type       = "fast.ru-9a"
+  preemptible       = true
+  image_id          = "2a8d2a0c-0b4b-4c3c-9e0c-0f4f5b1d8c3e"
+  user_data         = %q
+}`, projectName, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart, userData)
+}
+
+func TestUnitMKSNodegroupV1QuotaPlanCheckReplaced(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	nodegroup := func(nodesCount int, userData string) string {
+		return fmt.Sprintf(`
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_1" {
+  cluster_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region            = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone = "ru-9a"
+  nodes_count       = %d
+  cpus              = 2
+  ram_mb            = 1024
+  volume_gb         = 10
+  volume_type       = "fast.ru-9a"
+  user_data         = "%s"
+}`, nodesCount, userData)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheckCluster(projectName, clusterName, maintenanceWindowStart, 5) +
+					nodegroup(1, `#!/bin/sh\necho 1`),
+				Check: resource.TestCheckResourceAttr(resourceName, "nodes_count", "1"),
+			},
+			{
+				// The replaced nodegroup takes 4 CPUs and isn't counted twice.
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheckCluster(projectName, clusterName, maintenanceWindowStart, 5) +
+					nodegroup(2, `#!/bin/sh\necho 2`),
+				Check: resource.TestCheckResourceAttr(resourceName, "nodes_count", "2"),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheckCluster(projectName, clusterName, maintenanceWindowStart, 5) +
+					nodegroup(3, `#!/bin/sh\necho 3`),
+				ExpectError: regexp.MustCompile(`ru-9a: CPU 5 remaining, 6 required`),
+			},
+		},
+	})
+}
+
+func TestUnitMKSNodegroupV1QuotaPlanCheckFlavor(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	config := testUnitProviderConfig(backend) + testAccMKSNodegroupV1QuotaPlanCheck(projectName, clusterName, maintenanceWindowStart, 1)
+	flavorNodegroup := func(nodesCount int) string {
+		return fmt.Sprintf(`
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_flavor" {
+  cluster_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region            = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone = "ru-9a"
+  nodes_count       = %d
+  flavor_id         = "${selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_0.flavor_id}"
+  volume_gb         = 10
+  volume_type       = "fast.ru-9a"
+}`, nodesCount)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// The nodes of the flavor take 2 CPUs each.
+				Config:      config + flavorNodegroup(2),
+				ExpectError: regexp.MustCompile(`ru-9a: CPU 3 remaining, 4 required`),
+			},
+			{
+				Config: config + flavorNodegroup(1),
+				Check:  resource.TestCheckResourceAttr("selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_flavor", "cpus", "2"),
+			},
+		},
+	})
+}
+
+func testAccMKSNodegroupV1QuotaPlanCheck(projectName, clusterName, maintenanceWindowStart string, nodegroupsCount int) string {
+	var nodegroups strings.Builder
+	for i := 0; i < nodegroupsCount; i++ {
+		fmt.Fprintf(&nodegroups, `
+resource "selectel_mks_nodegroup_v1" "nodegroup_tf_acc_test_%d" {
+  cluster_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.id}"
+  project_id        = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.project_id}"
+  region            = "${selectel_mks_cluster_v1.cluster_tf_acc_test_1.region}"
+  availability_zone = "ru-9a"
+  nodes_count       = 1
+  cpus              = 2
+  ram_mb            = 1024
+  volume_gb         = 10
+  volume_type       = "fast.ru-9a"
+}`, i)
+	}
+
+	return testAccMKSNodegroupV1QuotaPlanCheckCluster(projectName, clusterName, maintenanceWindowStart, 3) + nodegroups.String()
+}
+
+func testAccMKSNodegroupV1QuotaPlanCheckCluster(projectName, clusterName, maintenanceWindowStart string, cpuQuota int) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+  quotas {
+    resource_name = "compute_cores"
+    resource_quotas {
+      region = "ru-9"
+      zone   = "ru-9a"
+      value  = %d
+    }
+  }
+}
+
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                     = "%s"
+  kube_version             = "%s"
+  project_id               = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  region                   = "ru-9"
+  maintenance_window_start = "%s"
+}
+`, projectName, cpuQuota, clusterName, fakeselectel.DefaultKubeVersion, maintenanceWindowStart)
+}
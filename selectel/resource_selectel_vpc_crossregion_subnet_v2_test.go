@@ -0,0 +1,44 @@
+package selectel
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitVPCCrossRegionSubnetV2Deprecated(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + testAccVPCCrossRegionSubnetV2Basic(projectName),
+				ExpectError: regexp.MustCompile("selectel_vpc_crossregion_subnet_v2 resource has been deprecated"),
+			},
+		},
+	})
+}
+
+func testAccVPCCrossRegionSubnetV2Basic(projectName string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+}
+
+resource "selectel_vpc_crossregion_subnet_v2" "crossregion_subnet_tf_acc_test_1" {
+  project_id = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  cidr       = "192.168.200.0/24"
+  regions {
+    region = "ru-1"
+  }
+  regions {
+    region = "ru-3"
+  }
+}`, projectName)
+}
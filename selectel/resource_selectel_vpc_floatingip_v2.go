@@ -5,85 +5,153 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/clients"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/floatingips"
 )
 
-func resourceVPCFloatingIPV2() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceVPCFloatingIPV2Create,
-		ReadContext:   resourceVPCFloatingIPV2Read,
-		DeleteContext: resourceVPCFloatingIPV2Delete,
-		Importer: &schema.ResourceImporter{
-			StateContext: resourceVPCFloatingIPV2ImportState,
-		},
-		Schema: map[string]*schema.Schema{
-			"project_id": {
-				Type:     schema.TypeString,
+var (
+	_ resource.Resource                = &vpcFloatingIPV2Resource{}
+	_ resource.ResourceWithConfigure   = &vpcFloatingIPV2Resource{}
+	_ resource.ResourceWithImportState = &vpcFloatingIPV2Resource{}
+)
+
+// regionRegexp matches names of the pools, for example, ru-3.
+var regionRegexp = regexp.MustCompile(`^[a-z]+-[0-9]+$`)
+
+// vpcFloatingIPV2Resource is the reference terraform-plugin-framework
+// resource. It keeps the schema of its SDKv2 version, so existing states are
+// read without an upgrade.
+type vpcFloatingIPV2Resource struct {
+	config *Config
+}
+
+type vpcFloatingIPV2ResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	Region            types.String `tfsdk:"region"`
+	PortID            types.String `tfsdk:"port_id"`
+	FloatingIPAddress types.String `tfsdk:"floating_ip_address"`
+	FixedIPAddress    types.String `tfsdk:"fixed_ip_address"`
+	Status            types.String `tfsdk:"status"`
+	Servers           types.Set    `tfsdk:"servers"`
+}
+
+func newVPCFloatingIPV2Resource() resource.Resource {
+	return &vpcFloatingIPV2Resource{}
+}
+
+func (r *vpcFloatingIPV2Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpc_floatingip_v2"
+}
+
+func (r *vpcFloatingIPV2Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
-			"region": {
-				Type:     schema.TypeString,
+			"region": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regionRegexp, "must be a pool name, for example, ru-3"),
+				},
 			},
-			"port_id": {
-				Type:     schema.TypeString,
+			"port_id": schema.StringAttribute{
 				Computed: true,
 			},
-			"floating_ip_address": {
-				Type:     schema.TypeString,
+			"floating_ip_address": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"fixed_ip_address": {
-				Type:     schema.TypeString,
+			"fixed_ip_address": schema.StringAttribute{
 				Computed: true,
 			},
-			"status": {
-				Type:     schema.TypeString,
+			"status": schema.StringAttribute{
 				Computed: true,
 			},
-			"servers": {
-				Type:     schema.TypeSet,
+			"servers": schema.SetNestedAttribute{
 				Computed: true,
-				Set:      hashServers,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"status": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-					},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: serversAttributes(),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
 				},
 			},
 		},
 	}
 }
 
-func resourceVPCFloatingIPV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-	projectID := d.Get("project_id").(string)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+func (r *vpcFloatingIPV2Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// ProviderData is nil until the provider is configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *Config, got %T", req.ProviderData))
+
+		return
+	}
+
+	r.config = config
+}
+
+func (r *vpcFloatingIPV2Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan vpcFloatingIPV2ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	projectID := plan.ProjectID.ValueString()
+	selvpcClient, err := r.config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("can't get selvpc client for floatingip: %w", err))
+		resp.Diagnostics.AddError(fmt.Sprintf("can't get selvpc client for floatingip: %s", err), "")
+
+		return
 	}
 
-	region := d.Get("region").(string)
+	region := plan.Region.ValueString()
 	err = validateRegion(selvpcClient, clients.ResellServiceType, region)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("can't validate region: %w", err))
+		resp.Diagnostics.AddAttributeError(path.Root("region"), fmt.Sprintf("can't validate region: %s", err), "")
+
+		return
 	}
 
 	opts := floatingips.FloatingIPOpts{
@@ -98,84 +166,164 @@ func resourceVPCFloatingIPV2Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectFloatingIP, opts))
 	floatingIPs, _, err := floatingips.Create(selvpcClient, projectID, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectFloatingIP, err))
+		resp.Diagnostics.AddError(errCreatingObject(objectFloatingIP, err).Error(), "")
+
+		return
 	}
 	if len(floatingIPs) != 1 {
-		return diag.FromErr(errReadFromResponse(objectFloatingIP))
+		resp.Diagnostics.AddError(errReadFromResponse(objectFloatingIP).Error(), "")
+
+		return
+	}
+
+	// Save the ID first, so the floating IP isn't lost if the read fails.
+	plan.ID = types.StringValue(floatingIPs[0].ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), plan.ProjectID)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(floatingIPs[0].ID)
+	found, diags := r.read(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(errGettingObject(objectFloatingIP, plan.ID.ValueString(), fmt.Errorf("not found")).Error(), "")
 
-	return resourceVPCFloatingIPV2Read(ctx, d, meta)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceVPCFloatingIPV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-	projectID := d.Get("project_id").(string)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("can't get selvpc client for floatingips: %w", err))
+func (r *vpcFloatingIPV2Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state vpcFloatingIPV2ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	log.Print(msgGet(objectFloatingIP, d.Id()))
-	floatingIP, response, err := floatingips.Get(selvpcClient, d.Id())
-	if err != nil {
-		if response != nil {
-			if response.StatusCode == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
-		}
+	found, diags := r.read(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
 
-		return diag.FromErr(errGettingObject(objectFloatingIP, d.Id(), err))
+		return
 	}
 
-	d.Set("fixed_ip_address", floatingIP.FixedIPAddress)
-	d.Set("floating_ip_address", floatingIP.FloatingIPAddress)
-	d.Set("port_id", floatingIP.PortID)
-	d.Set("project_id", floatingIP.ProjectID)
-	d.Set("region", floatingIP.Region)
-	d.Set("status", floatingIP.Status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
 
-	associatedServers := serversMapsFromStructs(floatingIP.Servers)
-	if err := d.Set("servers", associatedServers); err != nil {
-		log.Print(errSettingComplexAttr("servers", err))
+// Update is never called since changes of all arguments require replacement.
+func (r *vpcFloatingIPV2Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan vpcFloatingIPV2ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceVPCFloatingIPV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
-	projectID := d.Get("project_id").(string)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+func (r *vpcFloatingIPV2Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state vpcFloatingIPV2ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	selvpcClient, err := r.config.GetSelVPCClientWithProjectScope(state.ProjectID.ValueString())
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("can't get selvpc client for floatingips: %w", err))
+		resp.Diagnostics.AddError(fmt.Sprintf("can't get selvpc client for floatingips: %s", err), "")
+
+		return
 	}
 
-	log.Print(msgDelete(objectFloatingIP, d.Id()))
-	response, err := floatingips.Delete(selvpcClient, d.Id())
+	log.Print(msgDelete(objectFloatingIP, state.ID.ValueString()))
+	response, err := floatingips.Delete(selvpcClient, state.ID.ValueString())
 	if err != nil {
-		if response != nil {
-			if response.StatusCode == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return
 		}
 
-		return diag.FromErr(errDeletingObject(objectFloatingIP, d.Id(), err))
+		resp.Diagnostics.AddError(errDeletingObject(objectFloatingIP, state.ID.ValueString(), err).Error(), "")
 	}
+}
+
+func (r *vpcFloatingIPV2Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	projectID, id, err := parseImportIDWithProject(r.config, req.ID, "floatingip_id")
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
 
-	return nil
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
 }
 
-func resourceVPCFloatingIPV2ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, fmt.Errorf("SEL_PROJECT_ID must be set for the resource import")
+// configured reports whether the provider is configured and adds an error
+// diagnostic if it isn't.
+func (r *vpcFloatingIPV2Resource) configured(diags *diag.Diagnostics) bool {
+	if r.config == nil {
+		diags.AddError("Unconfigured provider", "The provider must be configured before managing floating IPs.")
+
+		return false
+	}
+
+	return true
+}
+
+// read refreshes the model from the API. It returns false if the floating IP
+// doesn't exist.
+func (r *vpcFloatingIPV2Resource) read(ctx context.Context, model *vpcFloatingIPV2ResourceModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !r.configured(&diags) {
+		return false, diags
+	}
+
+	selvpcClient, err := r.config.GetSelVPCClientWithProjectScope(model.ProjectID.ValueString())
+	if err != nil {
+		diags.AddError(fmt.Sprintf("can't get selvpc client for floatingips: %s", err), "")
+
+		return false, diags
 	}
 
-	d.Set("project_id", config.ProjectID)
+	log.Print(msgGet(objectFloatingIP, model.ID.ValueString()))
+	floatingIP, response, err := floatingips.Get(selvpcClient, model.ID.ValueString())
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return false, diags
+		}
+
+		diags.AddError(errGettingObject(objectFloatingIP, model.ID.ValueString(), err).Error(), "")
+
+		return false, diags
+	}
+
+	model.FixedIPAddress = types.StringValue(floatingIP.FixedIPAddress)
+	model.FloatingIPAddress = types.StringValue(floatingIP.FloatingIPAddress)
+	model.PortID = types.StringValue(floatingIP.PortID)
+	model.ProjectID = types.StringValue(floatingIP.ProjectID)
+	model.Region = types.StringValue(floatingIP.Region)
+	model.Status = types.StringValue(floatingIP.Status)
+
+	var serversDiags diag.Diagnostics
+	model.Servers, serversDiags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: serverAttrTypes}, serverModelsFromStructs(floatingIP.Servers))
+	diags.Append(serversDiags...)
 
-	return []*schema.ResourceData{d}, nil
+	return true, diags
 }
@@ -1,10 +1,13 @@
 package selectel
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -20,9 +23,9 @@ func TestAccVPCV2FloatingIPBasic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2FloatingIPDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2FloatingIPDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2FloatingIPBasic(projectName),
@@ -37,6 +40,67 @@ func TestAccVPCV2FloatingIPBasic(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2FloatingIPBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var floatingip floatingips.FloatingIP
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2FloatingIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccVPCV2FloatingIPBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2FloatingIPExists("selectel_vpc_floatingip_v2.floatingip_tf_acc_test_1", &floatingip),
+					resource.TestCheckResourceAttr("selectel_vpc_floatingip_v2.floatingip_tf_acc_test_1", "region", "ru-2"),
+					resource.TestCheckResourceAttr("selectel_vpc_floatingip_v2.floatingip_tf_acc_test_1", "status", "DOWN"),
+					resource.TestCheckResourceAttrSet("selectel_vpc_floatingip_v2.floatingip_tf_acc_test_1", "floating_ip_address"),
+					testAccCheckSelectelImportEnv("selectel_vpc_floatingip_v2.floatingip_tf_acc_test_1"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_floatingip_v2.floatingip_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccVPCV2FloatingIPBasic(projectName))
+}
+
+func TestUnitVPCV2FloatingIPInvalidRegion(t *testing.T) {
+	backend := testUnitPreCheck(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + `
+resource "selectel_vpc_floatingip_v2" "floatingip_tf_acc_test_1" {
+  project_id = "project"
+  region     = "ru 2"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be a pool name, for example, ru-3"),
+			},
+		},
+	})
+}
+
+func TestVPCFloatingIPV2ResourceImportStateUnconfigured(t *testing.T) {
+	r := newVPCFloatingIPV2Resource().(*vpcFloatingIPV2Resource)
+	req := frameworkresource.ImportStateRequest{ID: "project/floatingip"}
+	resp := &frameworkresource.ImportStateResponse{}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unconfigured provider" {
+		t.Errorf("Expected unconfigured provider error, but got: %v", resp.Diagnostics)
+	}
+}
+
 func testAccCheckVPCV2FloatingIPDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
@@ -34,6 +34,7 @@ func resourceVPCKeypairV2() *schema.Resource {
 			"regions": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
@@ -104,6 +105,7 @@ func resourceVPCKeypairV2Read(_ context.Context, d *schema.ResourceData, meta in
 			d.Set("name", keypair.Name)
 			d.Set("public_key", keypair.PublicKey)
 			d.Set("user_id", keypair.UserID)
+			d.Set("regions", keypair.Regions)
 		}
 	}
 
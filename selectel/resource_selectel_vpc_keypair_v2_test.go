@@ -24,9 +24,9 @@ func TestAccVPCV2KeypairBasic(t *testing.T) {
 	userPassword := acctest.RandString(8)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2KeypairDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2KeypairDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2KeypairBasic(userName, userPassword, keypairName, publicKey),
@@ -43,6 +43,40 @@ func TestAccVPCV2KeypairBasic(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2KeypairBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var keypair keypairs.Keypair
+	keypairName := acctest.RandomWithPrefix("tf-acc")
+	publicKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGDsRZqu5WjJA5bh4+6iAvXhWv+/OQw0sDkuSrGKOv3w example@example.org"
+	userName := acctest.RandomWithPrefix("tf-acc")
+	userPassword := "Tf1" + acctest.RandString(8)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2KeypairDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccVPCV2KeypairBasic(userName, userPassword, keypairName, publicKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2KeypairExists("selectel_vpc_keypair_v2.keypair_tf_acc_test_1", &keypair),
+					resource.TestCheckResourceAttr("selectel_vpc_keypair_v2.keypair_tf_acc_test_1", "name", keypairName),
+					resource.TestCheckResourceAttr("selectel_vpc_keypair_v2.keypair_tf_acc_test_1", "public_key", publicKey),
+					resource.TestCheckResourceAttr("selectel_vpc_keypair_v2.keypair_tf_acc_test_1", "regions.#", "2"),
+					resource.TestCheckResourceAttrPair("selectel_vpc_keypair_v2.keypair_tf_acc_test_1", "user_id",
+						"selectel_vpc_user_v2.user_tf_acc_test_1", "id"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_keypair_v2.keypair_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccVPCV2KeypairBasic(userName, userPassword, keypairName, publicKey))
+}
+
 func testAccCheckVPCV2KeypairDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
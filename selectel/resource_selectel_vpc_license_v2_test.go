@@ -21,9 +21,9 @@ func TestAccVPCV2LicenseBasic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2LicenseDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2LicenseDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2LicenseBasic(projectName),
@@ -42,6 +42,37 @@ func TestAccVPCV2LicenseBasic(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2LicenseBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var license licenses.License
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2LicenseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccVPCV2LicenseBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2LicenseExists("selectel_vpc_license_v2.license_tf_acc_test_1", &license),
+					resource.TestCheckResourceAttr("selectel_vpc_license_v2.license_tf_acc_test_1", "region", "ru-1"),
+					resource.TestCheckResourceAttr("selectel_vpc_license_v2.license_tf_acc_test_1", "type", "license_windows_2012_standard"),
+					resource.TestCheckResourceAttr("selectel_vpc_license_v2.license_tf_acc_test_1", "status", "DOWN"),
+					resource.TestCheckResourceAttrSet("selectel_vpc_license_v2.license_tf_acc_test_1", "network_id"),
+					testAccCheckSelectelImportEnv("selectel_vpc_license_v2.license_tf_acc_test_1"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_license_v2.license_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccVPCV2LicenseBasic(projectName))
+}
+
 func testAccCheckVPCV2LicenseDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
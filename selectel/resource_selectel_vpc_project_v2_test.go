@@ -21,9 +21,9 @@ func TestAccVPCV2ProjectBasic(t *testing.T) {
 	projectCustomURL := acctest.RandomWithPrefix("tf-acc-url") + ".selvpc.ru"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -77,9 +77,9 @@ func TestAccVPCV2ProjectWithSpecificQuotas(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectWithSpecificQuotas(projectName),
@@ -90,6 +90,60 @@ func TestAccVPCV2ProjectWithSpecificQuotas(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2ProjectBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var project projects.Project
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	projectNameUpdated := acctest.RandomWithPrefix("tf-acc-updated")
+	projectCustomURL := acctest.RandomWithPrefix("tf-acc-url") + ".selvpc.ru"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccVPCV2ProjectBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2ProjectExists("selectel_vpc_project_v2.project_tf_acc_test_1", &project),
+					resource.TestCheckResourceAttr("selectel_vpc_project_v2.project_tf_acc_test_1", "name", projectName),
+					resource.TestCheckResourceAttr("selectel_vpc_project_v2.project_tf_acc_test_1", "enabled", "true"),
+				),
+			},
+			{
+				Config: providerConfig + testAccVPCV2ProjectUpdate1(projectName, projectCustomURL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "custom_url", projectCustomURL),
+					resource.TestCheckResourceAttr(
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "theme.color", "000000"),
+					resource.TestCheckResourceAttr(
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "theme.logo", "fake.png"),
+				),
+			},
+			{
+				Config: providerConfig + testAccVPCV2ProjectUpdate3(projectNameUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "name", projectNameUpdated),
+					resource.TestCheckResourceAttr(
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "theme.color", "5D6D7E"),
+					resource.TestCheckResourceAttr(
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "quotas.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "selectel_vpc_project_v2.project_tf_acc_test_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"quotas"},
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccVPCV2ProjectBasic(projectName))
+}
+
 func testAccCheckVPCV2ProjectDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
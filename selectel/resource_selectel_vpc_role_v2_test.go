@@ -24,9 +24,9 @@ func TestAccVPCV2RoleBasic(t *testing.T) {
 	userPassword := acctest.RandString(8)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2RoleDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2RoleDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2RoleBasic(projectName, userName, userPassword),
@@ -42,6 +42,38 @@ func TestAccVPCV2RoleBasic(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2RoleBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var role roles.Role
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	userName := acctest.RandomWithPrefix("tf-acc")
+	userPassword := "Tf1" + acctest.RandString(8)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2RoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccVPCV2RoleBasic(projectName, userName, userPassword),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2RoleExists("selectel_vpc_role_v2.role_tf_acc_test_1", &role),
+					resource.TestCheckResourceAttrPair("selectel_vpc_role_v2.role_tf_acc_test_1", "project_id",
+						"selectel_vpc_project_v2.project_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttrPair("selectel_vpc_role_v2.role_tf_acc_test_1", "user_id",
+						"selectel_vpc_user_v2.user_tf_acc_test_1", "id"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_role_v2.role_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccVPCV2RoleBasic(projectName, userName, userPassword))
+}
+
 func testAccCheckVPCV2RoleDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
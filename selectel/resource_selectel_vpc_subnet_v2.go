@@ -201,12 +201,13 @@ func resourceVPCSubnetV2Delete(_ context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceVPCSubnetV2ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, fmt.Errorf("SEL_PROJECT_ID must be set for the resource import")
+	projectID, id, err := parseImportIDWithProject(meta.(*Config), d.Id(), "subnet_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
+	d.SetId(id)
+	d.Set("project_id", projectID)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -21,9 +21,9 @@ func TestAccVPCV2SubnetBasic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2SubnetDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2SubnetDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2SubnetBasic(projectName),
@@ -38,6 +38,37 @@ func TestAccVPCV2SubnetBasic(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2SubnetBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	var subnet subnets.Subnet
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2SubnetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccVPCV2SubnetBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2SubnetExists("selectel_vpc_subnet_v2.subnet_tf_acc_test_1", &subnet),
+					resource.TestCheckResourceAttr("selectel_vpc_subnet_v2.subnet_tf_acc_test_1", "region", "ru-3"),
+					resource.TestCheckResourceAttr("selectel_vpc_subnet_v2.subnet_tf_acc_test_1", "status", "DOWN"),
+					resource.TestCheckResourceAttr("selectel_vpc_subnet_v2.subnet_tf_acc_test_1", "prefix_length", "29"),
+					resource.TestCheckResourceAttr("selectel_vpc_subnet_v2.subnet_tf_acc_test_1", "ip_version", "ipv4"),
+					testAccCheckSelectelImportEnv("selectel_vpc_subnet_v2.subnet_tf_acc_test_1"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_subnet_v2.subnet_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccVPCV2SubnetBasic(projectName))
+}
+
 func testAccCheckVPCV2SubnetDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
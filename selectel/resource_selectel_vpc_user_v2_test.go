@@ -19,9 +19,9 @@ func TestAccVPCV2UserBasic(t *testing.T) {
 	userPasswordUpdated := acctest.RandString(12)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2UserDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2UserDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2UserBasic(userName, userPassword),
@@ -69,6 +69,45 @@ func TestAccVPCV2UserBasic(t *testing.T) {
 	})
 }
 
+func TestUnitVPCV2UserBasic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	providerConfig := testUnitProviderConfig(backend)
+	var user users.User
+	userName := acctest.RandomWithPrefix("tf-acc")
+	userNameUpdated := acctest.RandomWithPrefix("tf-acc")
+	userPassword := "Tf1" + acctest.RandString(8)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2UserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccVPCV2UserBasic(userName, userPassword),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2UserExists("selectel_vpc_user_v2.user_tf_acc_test_1", &user),
+					resource.TestCheckResourceAttr("selectel_vpc_user_v2.user_tf_acc_test_1", "name", userName),
+					resource.TestCheckResourceAttr("selectel_vpc_user_v2.user_tf_acc_test_1", "enabled", "true"),
+				),
+			},
+			{
+				Config: providerConfig + testAccVPCV2UserDisabled(userNameUpdated, userPassword),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_vpc_user_v2.user_tf_acc_test_1", "name", userNameUpdated),
+					resource.TestCheckResourceAttr("selectel_vpc_user_v2.user_tf_acc_test_1", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:            "selectel_vpc_user_v2.user_tf_acc_test_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, providerConfig+testAccVPCV2UserBasic(userName, userPassword))
+}
+
 func testAccCheckVPCV2UserDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	selvpcClient, err := config.GetSelVPCClient()
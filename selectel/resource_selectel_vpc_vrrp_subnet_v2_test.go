@@ -0,0 +1,39 @@
+package selectel
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitVPCVRRPSubnetV2Deprecated(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(backend) + testAccVPCVRRPSubnetV2Basic(projectName),
+				ExpectError: regexp.MustCompile("selectel_vpc_vrrp_subnet_v2 resource has been deprecated"),
+			},
+		},
+	})
+}
+
+func testAccVPCVRRPSubnetV2Basic(projectName string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+}
+
+resource "selectel_vpc_vrrp_subnet_v2" "vrrp_subnet_tf_acc_test_1" {
+  project_id    = "${selectel_vpc_project_v2.project_tf_acc_test_1.id}"
+  master_region = "ru-1"
+  slave_region  = "ru-3"
+}`, projectName)
+}
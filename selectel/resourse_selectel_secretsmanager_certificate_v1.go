@@ -2,11 +2,12 @@ package selectel
 
 import (
 	"context"
-	"errors"
+	"encoding/pem"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/secretsmanager-go"
 	"github.com/selectel/secretsmanager-go/service/certs"
 )
 
@@ -183,6 +184,12 @@ func resourceSecretsManagerCertificateV1Read(ctx context.Context, d *schema.Reso
 	// Set fields in case called from resourceSecretsManagerCertificateV1ImportState.
 	d.Set("name", cert.Name)
 	d.Set("id", cert.ID)
+	if _, ok := d.GetOk("private_key"); !ok {
+		diagErr = setSecretsManagerCertificateV1PEM(ctx, d, cl, cert.ID)
+		if diagErr != nil {
+			return diagErr
+		}
+	}
 
 	return nil
 }
@@ -246,12 +253,13 @@ func resourceSecretsManagerCertificateV1Delete(ctx context.Context, d *schema.Re
 // to avoid difficulties occurred with required SEL_PROJECT_ID env in
 // resourceSecretsManagerCertificateV1Read when uising schema.ImportStatePassthroughContext.
 func resourceSecretsManagerCertificateV1ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
+	projectID, id, err := parseImportIDWithProject(meta.(*Config), d.Id(), "certificate_id")
+	if err != nil {
+		return nil, err
 	}
 
-	d.Set("project_id", config.ProjectID)
+	d.SetId(id)
+	d.Set("project_id", projectID)
 
 	log.Print(msgImport(objectCertificate, d.Id()))
 	resourceSecretsManagerCertificateV1Read(ctx, d, meta)
@@ -259,6 +267,40 @@ func resourceSecretsManagerCertificateV1ImportState(ctx context.Context, d *sche
 	return []*schema.ResourceData{d}, nil
 }
 
+// setSecretsManagerCertificateV1PEM sets the certificates chain and the private
+// key of the imported certificate.
+func setSecretsManagerCertificateV1PEM(ctx context.Context, d *schema.ResourceData, cl *secretsmanager.Client, id string) diag.Diagnostics {
+	chain, err := cl.Certificates.GetPublicCerts(ctx, id)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectCertificate, id, err))
+	}
+	privateKey, err := cl.Certificates.GetPrivateKey(ctx, id)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectCertificate, id, err))
+	}
+
+	d.Set("certificates", splitPEMCertificates(chain))
+	d.Set("private_key", privateKey)
+
+	return nil
+}
+
+// splitPEMCertificates splits the PEM encoded chain into certificates.
+func splitPEMCertificates(chain string) []string {
+	certificates := make([]string, 0)
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		certificates = append(certificates, string(pem.EncodeToMemory(block)))
+	}
+
+	return certificates
+}
+
 // convertSMIssuedByToList — helper for setting "issued_by" attribute with nested structure.
 func convertSMIssuedByToList(ib certs.IssuedBy) []interface{} {
 	return []interface{}{
@@ -17,9 +17,9 @@ func TestAccSecretsManagerCertificateV1Basic(t *testing.T) {
 	newCertificateName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSecretsManagerCertificateV1BasicConfig(projectName, certificateName),
@@ -41,6 +41,45 @@ func TestAccSecretsManagerCertificateV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitSecretsManagerCertificateV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	resourceName := "selectel_secretsmanager_certificate_v1.certificate_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	certificateName := acctest.RandomWithPrefix("tf-acc")
+	newCertificateName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccSecretsManagerCertificateV1BasicConfig(projectName, certificateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", certificateName),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "serial"),
+					resource.TestCheckResourceAttr(resourceName, "validity.0.basic_constraints", "true"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccSecretsManagerCertificateV1UpdateConfig(projectName, newCertificateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", newCertificateName),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccSecretsManagerCertificateV1BasicConfig(projectName, certificateName))
+}
+
 func testAccSecretsManagerCertificateV1BasicConfig(projectName, certificateName string) string {
 	return fmt.Sprintf(`
 		resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
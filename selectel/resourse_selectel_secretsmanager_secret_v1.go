@@ -2,7 +2,7 @@ package selectel
 
 import (
 	"context"
-	"errors"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"strings"
@@ -115,8 +115,14 @@ func resourceSecretsManagerSecretV1Read(ctx context.Context, d *schema.ResourceD
 	d.Set("name", secret.Name)
 	d.Set("key", secret.Name)
 	d.Set("description", secret.Description)
+	// Value is set only on import, since changes of the value outside
+	// of Terraform aren't tracked.
 	if _, ok := d.GetOk("value"); !ok {
-		d.Set("value", "UNKNOWN")
+		value, err := base64.StdEncoding.DecodeString(secret.Version.Value)
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectSecret, d.Id(), err))
+		}
+		d.Set("value", string(value))
 	}
 	d.Set("created_at", secret.Version.CreatedAt)
 
@@ -169,18 +175,14 @@ func resourceSecretsManagerSecretV1Update(ctx context.Context, d *schema.Resourc
 // to avoid difficulties occurred with required SEL_PROJECT_ID env in
 // resourceSecretsManagerSecretV1Read when uising schema.ImportStatePassthroughContext.
 func resourceSecretsManagerSecretV1ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("SEL_PROJECT_ID must be set for the resource import")
-	}
-
-	d.Set("project_id", config.ProjectID)
-
-	_, key, err := resourceSecretsManagerSecretV1ParseID(d.Id())
+	projectID, key, err := parseImportIDWithProject(meta.(*Config), d.Id(), "key")
 	if err != nil {
-		return nil, errParseID(objectSecret, d.Id())
+		return nil, err
 	}
 
+	d.SetId(resourceSecretV1BuildID(projectID, key))
+	d.Set("project_id", projectID)
+
 	log.Print(msgImport(objectSecret, key))
 	resourceSecretsManagerSecretV1Read(ctx, d, meta)
 
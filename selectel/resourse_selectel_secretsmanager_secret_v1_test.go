@@ -19,9 +19,9 @@ func TestAccSecretsManagerSecretV1Basic(t *testing.T) {
 	newSecretDescription := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSecretsManagerSecretV1BasicConfig(projectName, secretKey, secretDescription, secretValue),
@@ -45,6 +45,48 @@ func TestAccSecretsManagerSecretV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitSecretsManagerSecretV1Basic(t *testing.T) {
+	backend := testUnitPreCheck(t)
+	resourceName := "selectel_secretsmanager_secret_v1.secret_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	secretKey := acctest.RandomWithPrefix("tf-acc")
+	secretValue := acctest.RandomWithPrefix("tf-acc")
+	secretDescription := acctest.RandomWithPrefix("tf-acc")
+	newSecretDescription := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(backend) + testAccSecretsManagerSecretV1BasicConfig(projectName, secretKey, secretDescription, secretValue),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "key", secretKey),
+					resource.TestCheckResourceAttr(resourceName, "name", secretKey),
+					resource.TestCheckResourceAttr(resourceName, "description", secretDescription),
+					resource.TestCheckResourceAttr(resourceName, "value", secretValue),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(backend) + testAccSecretsManagerSecretV1UpdateConfig(projectName, secretKey, newSecretDescription, secretValue),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", newSecretDescription),
+					resource.TestCheckResourceAttr(resourceName, "value", secretValue),
+					testAccCheckSelectelImportEnv(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	testUnitCheckImportPlanEmpty(t, backend, testUnitProviderConfig(backend)+testAccSecretsManagerSecretV1BasicConfig(projectName, secretKey, secretDescription, secretValue))
+}
+
 func testAccSecretsManagerSecretV1BasicConfig(projectName, key, description, value string) string {
 	return fmt.Sprintf(`
 		resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
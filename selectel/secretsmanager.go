@@ -10,29 +10,43 @@ import (
 
 func getSecretsManagerClient(d *schema.ResourceData, meta interface{}) (*secretsmanager.Client, diag.Diagnostics) {
 	config := meta.(*Config)
+	projectID := d.Get("project_id").(string)
 
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(d.Get("project_id").(string))
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
 		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for secretsmanager: %w", err))
 	}
 
-	endpointSM, err := selvpcClient.Catalog.GetEndpoint(SecretsManager, config.AuthRegion)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w, got %s", SecretsManager, err, endpointSM.URL))
+	urlSM, ok := config.endpointOverride(SecretsManager)
+	if !ok {
+		endpointSM, err := selvpcClient.Catalog.GetEndpoint(SecretsManager, config.AuthRegion)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w, got %s", SecretsManager, err, endpointSM.URL))
+		}
+		urlSM = endpointSM.URL
+	}
+
+	urlCM, ok := config.endpointOverride(CertificateManager)
+	if !ok {
+		endpointCM, err := selvpcClient.Catalog.GetEndpoint(CertificateManager, config.AuthRegion)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w, got %s", CertificateManager, err, endpointCM.URL))
+		}
+		urlCM = endpointCM.URL
 	}
 
-	endpointCM, err := selvpcClient.Catalog.GetEndpoint(CertificateManager, config.AuthRegion)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w, got %s", CertificateManager, err, endpointCM.URL))
+		return nil, diag.FromErr(fmt.Errorf("can't get token to init secretsmanager client: %w", err))
 	}
 
 	cl, err := secretsmanager.New(
 		secretsmanager.WithAuthOpts(
-			&secretsmanager.AuthOpts{KeystoneToken: selvpcClient.GetXAuthToken()},
+			&secretsmanager.AuthOpts{KeystoneToken: token},
 		),
-
-		secretsmanager.WithCustomURLSecrets(endpointSM.URL),
-		secretsmanager.WithCustomURLCertificates(endpointCM.URL),
+		secretsmanager.WithCustomHTTPClient(config.newHTTPClient(projectID)),
+		secretsmanager.WithCustomURLSecrets(urlSM),
+		secretsmanager.WithCustomURLCertificates(urlCM),
 	)
 	if err != nil {
 		return nil, diag.FromErr(fmt.Errorf("can't init secretsmanager client: %w", err))
@@ -44,16 +58,25 @@ func getSecretsManagerClient(d *schema.ResourceData, meta interface{}) (*secrets
 func getSecretsManagerClientForAccImportTests(meta interface{}) (*secretsmanager.Client, diag.Diagnostics) {
 	config := meta.(*Config)
 
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(config.ProjectID)
+	token, err := config.GetXAuthTokenWithProjectScope(config.ProjectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for secretsmanager: %w", err))
+		return nil, diag.FromErr(fmt.Errorf("can't get token to init secretsmanager client: %w", err))
 	}
 
-	cl, err := secretsmanager.New(
+	opts := []secretsmanager.ClientOption{
 		secretsmanager.WithAuthOpts(
-			&secretsmanager.AuthOpts{KeystoneToken: selvpcClient.GetXAuthToken()},
+			&secretsmanager.AuthOpts{KeystoneToken: token},
 		),
-	)
+		secretsmanager.WithCustomHTTPClient(config.newHTTPClient(config.ProjectID)),
+	}
+	if urlSM, ok := config.endpointOverride(SecretsManager); ok {
+		opts = append(opts, secretsmanager.WithCustomURLSecrets(urlSM))
+	}
+	if urlCM, ok := config.endpointOverride(CertificateManager); ok {
+		opts = append(opts, secretsmanager.WithCustomURLCertificates(urlCM))
+	}
+
+	cl, err := secretsmanager.New(opts...)
 	if err != nil {
 		return nil, diag.FromErr(fmt.Errorf("can't init secretsmanager client: %w", err))
 	}
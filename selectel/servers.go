@@ -3,10 +3,44 @@ package selectel
 import (
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/selectel/go-selvpcclient/v3/selvpcclient/resell/v2/servers"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/hashcode"
 )
 
+// serverModel is an element of the "servers" nested attribute of
+// terraform-plugin-framework resources.
+type serverModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Status types.String `tfsdk:"status"`
+}
+
+// serverAttrTypes are types of the serverModel attributes.
+var serverAttrTypes = map[string]attr.Type{
+	"id":     types.StringType,
+	"name":   types.StringType,
+	"status": types.StringType,
+}
+
+// serversAttributes returns the computed attributes of the "servers" nested
+// attribute.
+func serversAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+		"status": schema.StringAttribute{
+			Computed: true,
+		},
+	}
+}
+
 // serversMapsFromStructs converts the provided license.Servers to
 // the slice of maps correspondingly to the resource's schema.
 func serversMapsFromStructs(serverStructs []servers.Server) []map[string]interface{} {
@@ -23,6 +57,22 @@ func serversMapsFromStructs(serverStructs []servers.Server) []map[string]interfa
 	return associatedServers
 }
 
+// serverModelsFromStructs converts the provided servers to the models of
+// the "servers" nested attribute.
+func serverModelsFromStructs(serverStructs []servers.Server) []serverModel {
+	associatedServers := make([]serverModel, len(serverStructs))
+
+	for i, server := range serverStructs {
+		associatedServers[i] = serverModel{
+			ID:     types.StringValue(server.ID),
+			Name:   types.StringValue(server.Name),
+			Status: types.StringValue(server.Status),
+		}
+	}
+
+	return associatedServers
+}
+
 // hashServers is a hash function to use with the "servers" set.
 func hashServers(v interface{}) int {
 	m := v.(map[string]interface{})
@@ -6,4 +6,10 @@ const (
 	CRaaS              = "container-registry"
 	SecretsManager     = "secrets-manager"
 	CertificateManager = "certificate-manager"
+	Compute            = "compute"
+
+	// DNS and DomainsV1 are not registered in the catalog and use
+	// default endpoints unless they are overridden.
+	DNS       = "dns"
+	DomainsV1 = "domains-v1"
 )
@@ -0,0 +1,264 @@
+package selectel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	clientservices "github.com/selectel/go-selvpcclient/v3/selvpcclient/clients/services"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultRetryWaitMin = time.Second
+	defaultRetryWaitMax = 5 * time.Second
+)
+
+// newHTTPClient returns an HTTP client for service API clients that always
+// sends an up-to-date Keystone token of the given project scope, retries
+// failed requests according to the provider retry settings and logs every
+// request with TF_LOG=DEBUG.
+func (c *Config) newHTTPClient(projectID string) *http.Client {
+	httpClient := clientservices.NewHTTPClient()
+	httpClient.Transport = &retryTransport{
+		retryMax:     c.MaxRetries,
+		retryWaitMin: c.RetryWaitMin,
+		retryWaitMax: c.RetryWaitMax,
+		next: &authTransport{
+			config:    c,
+			projectID: projectID,
+			next:      &loggingTransport{next: httpClient.Transport},
+		},
+	}
+
+	return httpClient
+}
+
+// authTransport replaces the X-Auth-Token header of every request with the
+// current Keystone token and retries the request once with a new token
+// if the API responds with 401.
+type authTransport struct {
+	config    *Config
+	projectID string
+	next      http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.config.GetXAuthTokenWithProjectScope(t.projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(requestWithToken(req, token, body))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	token, err = t.config.reauthenticate(t.projectID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(requestWithToken(req, token, body))
+}
+
+// readRequestBody reads and closes the request body so it can be sent more than once.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	return io.ReadAll(req.Body)
+}
+
+func requestWithToken(req *http.Request, token string, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("X-Auth-Token", token)
+	if body != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	return r
+}
+
+// retryTransport retries requests that failed with a connection error, 429 or 5xx
+// status code. Requests with non-idempotent methods are retried only if they
+// failed to reach the API at all.
+type retryTransport struct {
+	retryMax     int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+	next         http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryableReq, err := retryablehttp.FromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &retryablehttp.Client{
+		HTTPClient: &http.Client{
+			Transport: t.next,
+			// Leave redirects to the outer client.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		Logger:       nil, // Ignore retyablehttp client logs
+		RetryWaitMin: t.retryWaitMin,
+		RetryWaitMax: t.retryWaitMax,
+		RetryMax:     t.retryMax,
+		CheckRetry:   retryPolicy(req.Method),
+		Backoff:      retryBackoff,
+		ErrorHandler: retryablehttp.PassthroughErrorHandler,
+	}
+
+	return client.Do(retryableReq)
+}
+
+// retryPolicy returns a retryablehttp.CheckRetry for the given request method.
+func retryPolicy(method string) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		if !isIdempotentMethod(method) {
+			return err != nil && isConnectionError(err), nil
+		}
+
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			return true, nil
+		}
+
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isConnectionError reports whether the request failed before it was sent to the API.
+func isConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryBackoff waits as long as the Retry-After header asks to and falls back
+// to the exponential backoff otherwise.
+func retryBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+}
+
+// parseRetryAfter parses the Retry-After header value that contains
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := time.Until(date)
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, true
+}
+
+// loggingTransport logs requests and responses with sensitive values redacted
+// if debug logging is enabled.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return t.next.RoundTrip(req)
+	}
+
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	log.Printf("[DEBUG] API request: %s %s\nHeaders: %v\nBody: %s",
+		req.Method, req.URL, redactHeaders(req.Header), redactBody(reqBody))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		log.Printf("[DEBUG] API request %s %s failed in %s: %s", req.Method, req.URL, latency, err)
+
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	log.Printf("[DEBUG] API response: %s for %s %s in %s\nHeaders: %v\nBody: %s",
+		resp.Status, req.Method, req.URL, latency, redactHeaders(resp.Header), redactBody(respBody))
+
+	return resp, nil
+}
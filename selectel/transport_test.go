@@ -0,0 +1,316 @@
+package selectel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthTransportRetriesUnauthorizedWithNewToken(t *testing.T) {
+	ks := newTestKeystone(t)
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"username":    "user",
+		"password":    "password",
+	})
+
+	var (
+		mu     sync.Mutex
+		tokens []string
+		bodies []string
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		tokens = append(tokens, r.Header.Get("X-Auth-Token"))
+		bodies = append(bodies, string(body))
+
+		// Reject the very first token as if it was revoked.
+		if r.Header.Get("X-Auth-Token") == "token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer service.Close()
+
+	req, err := http.NewRequest(http.MethodPost, service.URL, strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Auth-Token", "stale-token")
+
+	resp, err := config.newHTTPClient("").Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"token-1", "token-2"}, tokens)
+	assert.Equal(t, []string{`{"name":"test"}`, `{"name":"test"}`}, bodies)
+	assert.Equal(t, 2, ks.Issued())
+}
+
+func TestAuthTransportDoesNotRetryTwice(t *testing.T) {
+	ks := newTestKeystone(t)
+	config := configureTestProvider(t, map[string]interface{}{
+		"auth_url":    ks.AuthURL(),
+		"domain_name": "111111",
+		"username":    "user",
+		"password":    "password",
+	})
+
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer service.Close()
+
+	resp, err := config.newHTTPClient("").Get(service.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 2, requests)
+}
+
+func newTestRetryConfig(t *testing.T) *Config {
+	t.Helper()
+
+	ks := newTestKeystone(t)
+
+	return configureTestProvider(t, map[string]interface{}{
+		"auth_url":       ks.AuthURL(),
+		"domain_name":    "111111",
+		"username":       "user",
+		"password":       "password",
+		"max_retries":    3,
+		"retry_wait_min": 0,
+		"retry_wait_max": 0,
+	})
+}
+
+func TestRetryTransportRetriesIdempotentRequests(t *testing.T) {
+	config := newTestRetryConfig(t)
+
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer service.Close()
+
+	resp, err := config.newHTTPClient("").Get(service.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, requests)
+}
+
+func TestRetryTransportGivesUpAfterMaxRetries(t *testing.T) {
+	config := newTestRetryConfig(t)
+
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer service.Close()
+
+	req, err := http.NewRequest(http.MethodDelete, service.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := config.newHTTPClient("").Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 4, requests)
+}
+
+func TestRetryTransportDoesNotRetryPOSTResponses(t *testing.T) {
+	config := newTestRetryConfig(t)
+
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer service.Close()
+
+	resp, err := config.newHTTPClient("").Post(service.URL, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	tooManyRequests := &http.Response{StatusCode: http.StatusTooManyRequests}
+	notFound := &http.Response{StatusCode: http.StatusNotFound}
+
+	tableTests := []struct {
+		method   string
+		resp     *http.Response
+		err      error
+		expected bool
+	}{
+		{method: http.MethodGet, resp: unavailable, expected: true},
+		{method: http.MethodGet, resp: tooManyRequests, expected: true},
+		{method: http.MethodGet, resp: notFound, expected: false},
+		{method: http.MethodGet, err: readErr, expected: true},
+		{method: http.MethodPut, resp: unavailable, expected: true},
+		{method: http.MethodDelete, resp: tooManyRequests, expected: true},
+		{method: http.MethodPost, resp: unavailable, expected: false},
+		{method: http.MethodPost, resp: tooManyRequests, expected: false},
+		{method: http.MethodPost, err: readErr, expected: false},
+		{method: http.MethodPost, err: dialErr, expected: true},
+		{method: http.MethodPost, err: &net.DNSError{Err: "no such host"}, expected: true},
+		{method: http.MethodPatch, resp: unavailable, expected: false},
+		{method: http.MethodPatch, err: dialErr, expected: true},
+	}
+
+	for _, test := range tableTests {
+		retry, err := retryPolicy(test.method)(ctx, test.resp, test.err)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, retry, "method: %s, resp: %v, err: %v", test.method, test.resp, test.err)
+	}
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	retry, err := retryPolicy(http.MethodGet)(cancelledCtx, unavailable, nil)
+	assert.False(t, retry)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryBackoffHonoursRetryAfter(t *testing.T) {
+	newResponse := func(statusCode int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: statusCode, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+
+		return resp
+	}
+
+	assert.Equal(t, 7*time.Second, retryBackoff(time.Second, 5*time.Second, 0,
+		newResponse(http.StatusTooManyRequests, "7")))
+	assert.Equal(t, 3*time.Second, retryBackoff(time.Second, 5*time.Second, 0,
+		newResponse(http.StatusServiceUnavailable, "3")))
+
+	date := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	wait := retryBackoff(time.Second, 5*time.Second, 0, newResponse(http.StatusTooManyRequests, date))
+	assert.InDelta(t, 30*time.Second, wait, float64(2*time.Second))
+
+	// Invalid or missing Retry-After falls back to the exponential backoff.
+	assert.Equal(t, 2*time.Second, retryBackoff(time.Second, 5*time.Second, 1,
+		newResponse(http.StatusTooManyRequests, "soon")))
+	assert.Equal(t, 4*time.Second, retryBackoff(time.Second, 5*time.Second, 2,
+		newResponse(http.StatusBadGateway, "10")))
+	assert.Equal(t, 5*time.Second, retryBackoff(time.Second, 5*time.Second, 10, nil))
+}
+
+func TestLoggingTransportRedactsSecrets(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"user","password":"secret-password"}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"user":{"id":"123","name":"user","password":"secret-password"}}`))
+	}))
+	defer service.Close()
+
+	req, err := http.NewRequest(http.MethodPost, service.URL+"/users", strings.NewReader(`{"name":"user","password":"secret-password"}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Auth-Token", "secret-token")
+
+	transport := &loggingTransport{next: http.DefaultTransport}
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"user":{"id":"123","name":"user","password":"secret-password"}}`, string(body))
+
+	output := logs.String()
+	assert.Contains(t, output, "[DEBUG] API request: POST "+service.URL+"/users")
+	assert.Contains(t, output, "[DEBUG] API response: 201 Created for POST "+service.URL+"/users in ")
+	assert.Contains(t, output, `"name":"user"`)
+	assert.Contains(t, output, `"password":"[REDACTED]"`)
+	assert.Contains(t, output, "X-Auth-Token:["+redactedValue+"]")
+	assert.NotContains(t, output, "secret-password")
+	assert.NotContains(t, output, "secret-token")
+}
+
+func TestLoggingTransportIsSilentWithoutDebug(t *testing.T) {
+	t.Setenv("TF_LOG", "")
+
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer service.Close()
+
+	req, err := http.NewRequest(http.MethodGet, service.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := (&loggingTransport{next: http.DefaultTransport}).RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Empty(t, logs.String())
+}
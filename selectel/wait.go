@@ -0,0 +1,12 @@
+package selectel
+
+import "time"
+
+// waitTimeScale scales delays and poll intervals of state change waiters.
+// Unit tests set it to zero to poll the fake backend without waiting.
+var waitTimeScale time.Duration = 1
+
+// waitTime returns the waiter delay or poll interval scaled by waitTimeScale.
+func waitTime(d time.Duration) time.Duration {
+	return d * waitTimeScale
+}